@@ -1,29 +1,47 @@
 package main
 
 import (
+	"fmt"
+	"kubeui/internal/app/configs"
 	"kubeui/internal/app/cxs"
+	"kubeui/internal/app/deployments"
+	"kubeui/internal/app/navigator"
+	"kubeui/internal/app/nodes"
 	"kubeui/internal/app/pods"
+	"kubeui/internal/app/resources"
+	"kubeui/internal/app/services"
 	"kubeui/internal/pkg/k8s"
 	"kubeui/internal/pkg/k8s/k8scontext"
-	"kubeui/internal/pkg/k8s/namespace"
-	k8spods "kubeui/internal/pkg/k8s/pods"
+	"kubeui/internal/pkg/k8s/portforward"
 	"kubeui/internal/pkg/kubeui"
 	"log"
+	"os"
 
 	"github.com/alexflint/go-arg"
 	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 type args struct {
-	Program    string `arg:"positional" help:"Subcommand to run, one of [cxs, pods]"`
+	Program    string `arg:"positional" help:"Subcommand to run, one of [cxs, pods, deployments, nodes, configs, resources, services, navigator]"`
 	KubeConfig string `arg:"-c" help:"Absolute path to the kubeconfig file"`
+	Session    bool   `help:"Only switch context and namespace within kubeui, the kubeconfig is left unchanged"`
+	Export     string `help:"Print shell commands that make the shell use the selected context when kubeui exits, one of [kubectl, kubeconfig], implies --session. Neither changes the kubeconfig"`
+	ExportFile string `arg:"--export-file" help:"Path of the kubeconfig written for the shell when exporting with kubeconfig"`
 }
 
 func main() {
 
 	// Parse arguments given the 'args' struct
 	args := &args{}
-	arg.MustParse(args)
+	parser := arg.MustParse(args)
+
+	switch {
+	case args.Export != "" && args.Export != "kubectl" && args.Export != "kubeconfig":
+		parser.Fail(fmt.Sprintf("unknown export format %s", args.Export))
+	case args.Export == "kubeconfig" && args.ExportFile == "":
+		parser.Fail("--export-file is required when exporting with kubeconfig")
+	}
 
 	// If a specific kubeconfig file is specified then we load that, otherwise the defaults will be loaded.
 
@@ -37,27 +55,93 @@ func main() {
 
 	configAccess := clientConfig.ConfigAccess()
 
-	clientSet, err := k8s.NewKClientSet(args.KubeConfig, configAccess)
+	service, err := newService(args.KubeConfig, configAccess)
 
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
+	// Port forwards run in the background and are stopped when the program exits.
+	portForwards := portforward.NewManager(service)
+
+	// Exporting the selected context to the shell is the only way to use a context that was selected for the session outside of kubeui.
+	contextClient := k8scontext.NewClientImpl(configAccess, rawConfig, nil)
+	contextClient.SetSessionScoped(args.Session || args.Export != "")
+
 	var m tea.Model
 
 	switch args.Program {
 	case "cxs":
-		m = cxs.NewModel(k8scontext.NewClientImpl(configAccess, rawConfig, nil))
+		m = cxs.NewModel(contextClient)
 	case "pods":
-		m = pods.NewModel(k8scontext.NewClientImpl(configAccess, rawConfig, nil), k8s.NewK8sService(
-			k8spods.NewRepository(clientSet.CoreV1()),
-			namespace.NewRepository(clientSet.CoreV1()),
-		))
+		m = pods.NewModel(contextClient, service, k8s.NewK8sServiceForConfig, portForwards)
+	case "deployments":
+		m = deployments.NewModel(contextClient, service)
+	case "nodes":
+		m = nodes.NewModel(contextClient, service)
+	case "configs":
+		m = configs.NewModel(contextClient, service)
+	case "resources":
+		m = resources.NewModel(contextClient, service)
+	case "services":
+		m = services.NewModel(contextClient, service, portForwards)
+	case "navigator":
+		m = navigator.NewModel(contextClient, service, k8s.NewK8sServiceForConfig, portForwards)
 	default:
 		log.Fatalf("no command called %s", args.Program)
 	}
 
-	program := kubeui.NewProgram(m, true)
+	// The commands are printed on stdout when exporting, so the program is displayed on stderr instead.
+	output := os.Stdout
+	if args.Export != "" {
+		output = os.Stderr
+	}
+
+	program := kubeui.NewProgram(m, true, output)
 	kubeui.StartProgram(program)
 
+	portForwards.StopAll()
+
+	if args.Export != "" {
+		exportContext(args, contextClient, configAccess)
+	}
+
+}
+
+// exportContext prints the commands that make the shell use the context that was selected in kubeui.
+func exportContext(args *args, contextClient k8scontext.Client, configAccess clientcmd.ConfigAccess) {
+	var commands []string
+	var err error
+
+	switch args.Export {
+	case "kubectl":
+		commands, err = k8scontext.KubectlCommands(contextClient.Config())
+	case "kubeconfig":
+		commands, err = k8scontext.KubeconfigCommands(args.ExportFile, contextClient.Config(), configAccess.GetLoadingPrecedence())
+	}
+
+	if err != nil {
+		log.Fatalf("failed to export context: %v", err)
+	}
+
+	for _, command := range commands {
+		fmt.Println(command)
+	}
+}
+
+// newService creates a k8s.Service connected to the current context of the kubeconfig.
+func newService(kubeConfig string, configAccess clientcmd.ConfigAccess) (k8s.Service, error) {
+	clientSet, err := k8s.NewKClientSet(kubeConfig, configAccess)
+
+	if err != nil {
+		return nil, err
+	}
+
+	restConfig, err := k8s.NewRestConfig(configAccess)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return k8s.NewK8sService(k8s.NewRepositories(clientSet, restConfig)), nil
 }
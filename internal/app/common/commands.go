@@ -0,0 +1,31 @@
+package common
+
+import (
+	"kubeui/internal/pkg/component/commandpalette"
+	"kubeui/internal/pkg/kubeui"
+
+	"github.com/life4/genesis/slices"
+)
+
+// AllCommands are the commands of the command palette for every view that can be navigated to, in the order they are listed before anything has been typed.
+var AllCommands = []kubeui.Command{
+	{Command: commandpalette.Command{Name: "pods", Aliases: []string{"po", "pod"}, Description: "List pods"}, ViewId: "pod_selection"},
+	{Command: commandpalette.Command{Name: "deploy", Aliases: []string{"deployments", "deployment"}, Description: "List deployments"}, ViewId: "deployment_selection"},
+	{Command: commandpalette.Command{Name: "sts", Aliases: []string{"statefulsets", "statefulset"}, Description: "List statefulsets"}, ViewId: "statefulset_selection"},
+	{Command: commandpalette.Command{Name: "svc", Aliases: []string{"services", "service"}, Description: "List services"}, ViewId: "service_selection"},
+	{Command: commandpalette.Command{Name: "ing", Aliases: []string{"ingresses", "ingress"}, Description: "List ingress routes"}, ViewId: "ingress_selection"},
+	{Command: commandpalette.Command{Name: "cm", Aliases: []string{"configmaps", "configmap"}, Description: "List configmaps"}, ViewId: "configmap_selection"},
+	{Command: commandpalette.Command{Name: "secrets", Aliases: []string{"secret"}, Description: "List secrets"}, ViewId: "secret_selection"},
+	{Command: commandpalette.Command{Name: "nodes", Aliases: []string{"no", "node"}, Description: "List nodes"}, ViewId: "node_selection"},
+	{Command: commandpalette.Command{Name: "res", Aliases: []string{"resources", "api-resources"}, Description: "Browse any type of resource"}, ViewId: "resource_type_selection"},
+	{Command: commandpalette.Command{Name: "pf", Aliases: []string{"portforwards", "port-forward"}, Description: "List port forwards"}, ViewId: "port_forwards"},
+	{Command: commandpalette.Command{Name: "ns", Aliases: []string{"namespaces", "namespace"}, Description: "Switch namespace"}, ViewId: "namespace_selection"},
+	{Command: commandpalette.Command{Name: "ctx", Aliases: []string{"contexts", "context"}, Description: "Switch context"}, ViewId: "context_selection"},
+}
+
+// Commands returns the commands of the command palette that push one of the given views, which is used by programs that only have some of the views.
+func Commands(viewIds ...string) []kubeui.Command {
+	return slices.Filter(AllCommands, func(c kubeui.Command) bool {
+		return slices.Contains(viewIds, c.ViewId)
+	})
+}
@@ -0,0 +1,117 @@
+package common
+
+import (
+	"fmt"
+
+	"kubeui/internal/pkg/k8s"
+	"kubeui/internal/pkg/k8s/k8scontext"
+	"kubeui/internal/pkg/k8s/portforward"
+	"kubeui/internal/pkg/kubeui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Connection holds the views of a program together with the service they use to connect to the cluster of the current context.
+// Both are replaced when switching context.
+type Connection struct {
+	// Displays the views of the program.
+	Root kubeui.Root
+
+	// The service used by the views of Root.
+	service k8s.Service
+
+	// Name of the context that service is connected to.
+	context string
+
+	contextClient k8scontext.Client
+
+	// Used to connect to the cluster of a new context after switching context.
+	newService k8s.ServiceFactory
+
+	// portForwards keeps track of the port forwards running in the background.
+	portForwards *portforward.Manager
+
+	// Creates the root displaying the views of the program, using the given service.
+	newRoot func(k8s.Service) kubeui.Root
+}
+
+// NewConnection creates a new Connection whose views use the given service.
+func NewConnection(contextClient k8scontext.Client, service k8s.Service, newService k8s.ServiceFactory, portForwards *portforward.Manager, newRoot func(k8s.Service) kubeui.Root) Connection {
+	return Connection{
+		Root:          newRoot(service),
+		service:       service,
+		context:       contextClient.CurrentContext(),
+		contextClient: contextClient,
+		newService:    newService,
+		portForwards:  portForwards,
+		newRoot:       newRoot,
+	}
+}
+
+// ConnectionMsg is sent when connecting to the cluster of a context has either succeeded or failed.
+type ConnectionMsg struct {
+	// Name of the context that was connected to.
+	name    string
+	service k8s.Service
+	err     error
+}
+
+// Connect connects to the cluster of the current context in the background, after the context named name has been switched to.
+// The cluster is asked for its version, so that a cluster that can not be reached or does not accept the credentials is not switched to.
+// The result is delivered as a ConnectionMsg, which should be passed to Update.
+func (c Connection) Connect(name string) tea.Cmd {
+	// The service is created from the kubeconfig in memory, which is where the context was switched.
+	// It is copied, since the kubeconfig may change while connecting.
+	config := c.contextClient.Config()
+	config = *config.DeepCopy()
+
+	return func() tea.Msg {
+		service, err := c.newService(config)
+		if err != nil {
+			return ConnectionMsg{name: name, err: err}
+		}
+
+		if _, err := service.ServerVersion(); err != nil {
+			service.Close()
+			return ConnectionMsg{name: name, err: err}
+		}
+
+		return ConnectionMsg{name: name, service: service}
+	}
+}
+
+// Update replaces the views with new views using the connection of msg, sized to the given window size.
+// If the cluster could not be connected to, then the context is switched back, since the views keep using the previous connection.
+func (c Connection) Update(msg ConnectionMsg, windowWidth, windowHeight int) (Connection, tea.Cmd) {
+	// The context has been switched again while connecting, the connection to the latest context is used instead.
+	if msg.name != c.contextClient.CurrentContext() {
+		if msg.service != nil {
+			msg.service.Close()
+		}
+
+		return c, nil
+	}
+
+	if msg.err != nil {
+		err := fmt.Errorf("failed to connect to context %s: %v", msg.name, msg.err)
+
+		if switchErr := c.contextClient.SwitchContext(c.context, ""); switchErr != nil {
+			err = fmt.Errorf("%v, failed to switch back to context %s: %v", err, c.context, switchErr)
+		}
+
+		return c, kubeui.Error(err)
+	}
+
+	// Requests to the previous cluster are cancelled, forwards that are already running keep forwarding to the cluster they were started in.
+	c.service.Close()
+	c.service = msg.service
+	c.context = msg.name
+	c.portForwards.SetForwarder(msg.service)
+
+	// Everything that was selected belongs to the previous cluster, so we start over as if the program was started in the new context.
+	destroyCmd := c.Root.Destroy()
+	c.Root = c.newRoot(msg.service)
+	c.Root, _ = c.Root.UpdateRoot(tea.WindowSizeMsg{Width: windowWidth, Height: windowHeight})
+
+	return c, tea.Batch(destroyCmd, c.Root.Init())
+}
@@ -0,0 +1,216 @@
+package configmapinfo
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/integer"
+)
+
+// keyMap defines the keys that are handled by this view.
+type keyMap struct {
+	kubeui.GlobalKeyMap
+}
+
+// newKeyMap defines the actual key bindings and creates a keyMap.
+func newKeyMap() *keyMap {
+	return &keyMap{
+		GlobalKeyMap: kubeui.NewGlobalKeyMap(),
+	}
+}
+
+func (v View) fullHelp() [][]key.Binding {
+	viewPortKeys := viewport.DefaultKeyMap()
+
+	return [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView},
+		{
+			viewPortKeys.Up,
+			viewPortKeys.Down,
+			viewPortKeys.PageUp,
+			viewPortKeys.PageDown,
+			viewPortKeys.HalfPageUp,
+			viewPortKeys.HalfPageDown,
+		},
+	}
+}
+
+// K8sService represents the interface towards kubernetes needed by this view.
+type K8sService interface {
+	GetConfigMap(namespace, name string) (*v1.ConfigMap, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// View displays the keys and values of a configmap.
+type View struct {
+	keys *keyMap
+
+	// Viewport for scrolling the values.
+	valuesViewPort viewport.Model
+
+	windowWidth  int
+	windowHeight int
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	configMap *v1.ConfigMap
+
+	// Kubernetes client.
+	k8sClient K8sService
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(k8sClient K8sService, contextClient ContextClient, windowWidth, windowHeight int) View {
+	return View{
+		k8sClient:     k8sClient,
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          newKeyMap(),
+	}
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	// Keys
+	switch {
+
+	case msg.IsWindowResize():
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+		v = v.updateViewPort(c)
+		return c, v, nil
+
+	case msg.MatchesKeyBindings(v.keys.Quit):
+		return c, v, kubeui.Exit()
+
+	case msg.MatchesKeyBindings(v.keys.ExitView):
+		return c, v, kubeui.PopView(false)
+
+	case msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp:
+		v.showFullHelp = true
+		return c, v, nil
+
+	case msg.MatchesKeyBindings(v.keys.Refresh):
+		return c, v, v.Init(c)
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+	case k8smsg.GetConfigMapMsg:
+		v.configMap = t.ConfigMap
+		v = v.updateViewPort(c)
+
+		return c, v, nil
+	}
+
+	var cmd tea.Cmd
+	if v.configMap != nil {
+		v.valuesViewPort, cmd = v.valuesViewPort.Update(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+// updateViewPort resizes the viewport to fill the space below the header and renders the values.
+func (v View) updateViewPort(c kubeui.Context) View {
+	if v.configMap == nil {
+		return v
+	}
+
+	v.valuesViewPort.Width = v.windowWidth
+	v.valuesViewPort.Height = integer.IntMax(v.windowHeight-(lipgloss.Height(v.headerView(c))+lipgloss.Height(footerView(v.windowWidth, v.valuesViewPort))), 1)
+	v.valuesViewPort.SetContent(renderValues(v.windowWidth, v.configMap.Data, v.configMap.BinaryData))
+
+	return v
+}
+
+// headerView renders the help and status bar displayed above the values.
+func (v View) headerView(c kubeui.Context) string {
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView}))
+	builder.WriteString("\n\n")
+
+	headerLine := header.New(v.windowWidth-1, v.contextClient, header.Location{Namespace: c.Namespace, Path: []string{"configmaps", c.SelectedConfigMap}})
+	builder.WriteString(headerLine + "\n")
+
+	return builder.String()
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	if v.configMap == nil {
+		return "Loading..."
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(v.headerView(c))
+
+	if len(v.configMap.Data) == 0 && len(v.configMap.BinaryData) == 0 {
+		builder.WriteString(fmt.Sprintf("ConfigMap %s has no data", c.SelectedConfigMap))
+		return builder.String()
+	}
+
+	builder.WriteString(v.valuesViewPort.View())
+	builder.WriteString(footerView(v.windowWidth, v.valuesViewPort))
+
+	return builder.String()
+}
+
+// footerView creates the footerView which contains information about how far the user has scrolled through the viewPort.
+func footerView(width int, viewPort viewport.Model) string {
+	info := fmt.Sprintf("%3.f%%", viewPort.ScrollPercent()*100)
+	line := strings.Repeat("─", integer.IntMax(0, width-lipgloss.Width(info)))
+	return "\x1b[0m" + "\n" + lipgloss.NewStyle().Width(width).Render(lipgloss.JoinHorizontal(lipgloss.Center, line, info))
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return func() tea.Msg {
+		configMap, err := v.k8sClient.GetConfigMap(c.Namespace, c.SelectedConfigMap)
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewGetConfigMapMsg(configMap)
+	}
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	return nil
+}
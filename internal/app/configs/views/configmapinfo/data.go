@@ -0,0 +1,36 @@
+package configmapinfo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/life4/genesis/maps"
+)
+
+var (
+	keyStyle    = lipgloss.NewStyle().Bold(true)
+	binaryStyle = lipgloss.NewStyle().Faint(true)
+)
+
+// renderValues renders the keys of a configmap in alphabetical order, each followed by its value.
+// Binary data is only shown by its size.
+func renderValues(maxWidth int, data map[string]string, binaryData map[string][]byte) string {
+	keys := append(maps.Keys(data), maps.Keys(binaryData)...)
+	sort.Strings(keys)
+
+	style := lipgloss.NewStyle().Width(maxWidth)
+	sections := []string{}
+
+	for _, key := range keys {
+		if value, ok := data[key]; ok {
+			sections = append(sections, keyStyle.Render(key)+"\n"+style.Render(value))
+			continue
+		}
+
+		sections = append(sections, keyStyle.Render(key)+"\n"+binaryStyle.Render(fmt.Sprintf("<binary data, %d bytes>", len(binaryData[key]))))
+	}
+
+	return strings.Join(sections, "\n\n")
+}
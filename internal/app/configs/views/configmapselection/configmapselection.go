@@ -0,0 +1,238 @@
+package configmapselection
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeui/internal/pkg/component/columntable"
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+	"kubeui/internal/pkg/ui/k8stable"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+)
+
+// keyMap defines the keys that are handled by this view.
+type keyMap struct {
+	kubeui.GlobalKeyMap
+	SelectNamespace key.Binding
+	ShowSecrets     key.Binding
+}
+
+// newKeyMap defines the actual key bindings and creates a keyMap.
+func newKeyMap() *keyMap {
+	return &keyMap{
+		GlobalKeyMap: kubeui.NewGlobalKeyMap(),
+		SelectNamespace: key.NewBinding(
+			key.WithKeys("ctrl+n"),
+			key.WithHelp("ctrl+n", "Select namespace"),
+		),
+		ShowSecrets: key.NewBinding(
+			key.WithKeys("ctrl+w"),
+			key.WithHelp("ctrl+w", "Show secrets"),
+		),
+	}
+}
+
+func (v View) fullHelp() [][]key.Binding {
+	bindings := [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.SelectNamespace, v.keys.ShowSecrets},
+	}
+
+	if len(v.configMaps) > 0 {
+		bindings = append(bindings, v.configMapTable.KeyList())
+	}
+
+	return bindings
+}
+
+// K8sClient represents the interface towards kubernetes needed by this view.
+type K8sClient interface {
+	ListConfigMaps(namespace string) (*v1.ConfigMapList, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// View is used to select a configmap.
+type View struct {
+	keys *keyMap
+
+	windowWidth  int
+	windowHeight int
+
+	// ConfigMaps in current namespace.
+	configMaps []v1.ConfigMap
+
+	// ColumnTable used to select a configmap.
+	configMapTable columntable.Model
+
+	// Loading indicator
+	loading bool
+
+	// If the View has been initialized or not.
+	initialized bool
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	// Kubernetes client.
+	k8sClient K8sClient
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(k8sClient K8sClient, contextClient ContextClient, windowWidth, windowHeight int) View {
+	return View{
+		k8sClient:     k8sClient,
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          newKeyMap(),
+		loading:       true,
+	}
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	if msg.IsWindowResize() {
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+
+		var cmd tea.Cmd
+		if v.initialized {
+			v.configMapTable, cmd = v.configMapTable.Update(columntable.UpdateHeight{Height: v.tableHeight()})
+		}
+
+		return c, v, cmd
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp {
+		v.showFullHelp = true
+		return c, v, nil
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Quit) {
+		return c, v, kubeui.Exit()
+	}
+
+	if msg.MatchesKeyBindings(v.keys.SelectNamespace) {
+		return c, v, kubeui.PushView("namespace_selection", true)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.ShowSecrets) {
+		return c, v, kubeui.PushView("secret_selection", true)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Refresh) {
+		return c, v, v.Init(c)
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+	case k8smsg.ListConfigMapsMsg:
+		v.configMaps = t.ConfigMapList.Items
+		configMapColumns, configMapRows := k8stable.ConfigMapColumnsAndRows(v.configMaps)
+		var cmd tea.Cmd
+
+		// The first time we receive a list of configmaps then we create a new configMapTable.
+		// Otherwise we just update it.
+		if !v.initialized {
+			v.initialized = true
+			v.configMapTable = columntable.New(configMapColumns, configMapRows, columntable.PageSize(v.tableHeight()), "", false, columntable.Options{SingularItemName: "configmap", StartInSearchMode: true})
+		} else {
+			v.configMapTable, cmd = v.configMapTable.Update(columntable.UpdateRowsAndColumns{Rows: configMapRows, Columns: configMapColumns})
+		}
+
+		v.loading = false
+
+		return c, v, cmd
+
+	case columntable.Selection:
+		c.SelectedConfigMap = t.Id
+		return c, v, kubeui.PushView("configmap_info", true)
+	}
+
+	var cmd tea.Cmd
+	if v.initialized {
+		v.configMapTable, cmd = v.configMapTable.Update(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+// tableHeight returns the number of lines available to the table, which is displayed below the short help, an empty line and the status bar.
+func (v View) tableHeight() int {
+	// The height is not known before the first resize.
+	if v.windowHeight == 0 {
+		return 0
+	}
+
+	return v.windowHeight - 4
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.SelectNamespace, v.keys.ShowSecrets, v.keys.Refresh}))
+	builder.WriteString("\n\n")
+
+	headerLine := header.New(v.windowWidth-1, v.contextClient, header.Location{Namespace: c.Namespace, Path: []string{"configmaps"}})
+	builder.WriteString(headerLine + "\n")
+
+	if v.loading {
+		return "Loading..."
+	} else if len(v.configMaps) == 0 {
+		builder.WriteString(fmt.Sprintf("No configmaps found in namespace %s", c.Namespace))
+	} else {
+		builder.WriteString(v.configMapTable.View())
+	}
+
+	return builder.String()
+}
+
+// CapturesText reports whether the view captures typed text, which it does while the configmaps are searched.
+func (v View) CapturesText() bool {
+	return v.configMapTable.Searching()
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return func() tea.Msg {
+		configMapList, err := v.k8sClient.ListConfigMaps(c.Namespace)
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewListConfigMapsMsg(configMapList)
+	}
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	return nil
+}
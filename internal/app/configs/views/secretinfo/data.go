@@ -0,0 +1,87 @@
+package secretinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"kubeui/internal/pkg/jsoncolor"
+	"kubeui/internal/pkg/k8s/secrets"
+	"kubeui/internal/pkg/styles"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/life4/genesis/maps"
+)
+
+var (
+	keyStyle    = lipgloss.NewStyle().Bold(true)
+	maskedStyle = lipgloss.NewStyle().Faint(true)
+)
+
+// renderValues renders the keys of a secret in alphabetical order, each followed by its value.
+// The values are masked unless reveal is set.
+func renderValues(maxWidth int, data map[string][]byte, reveal bool) string {
+	keys := maps.Keys(data)
+	sort.Strings(keys)
+
+	sections := []string{}
+
+	for _, key := range keys {
+		value := data[key]
+		format := secrets.DetectFormat(key, value)
+
+		header := keyStyle.Render(key) + fmt.Sprintf(" (%s, %d bytes)", format, len(value))
+
+		if !reveal {
+			sections = append(sections, header+"\n"+maskedStyle.Render("••••••••"))
+			continue
+		}
+
+		sections = append(sections, header+"\n"+renderValue(maxWidth, format, value))
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+// renderValue renders a value according to its format.
+func renderValue(maxWidth int, format secrets.Format, value []byte) string {
+	style := lipgloss.NewStyle().Width(maxWidth)
+
+	switch format {
+	case secrets.JSON:
+		var obj interface{}
+		if err := json.Unmarshal(value, &obj); err == nil {
+			return style.Render(renderJSON(obj))
+		}
+
+	case secrets.DockerConfigJSON:
+		config, err := secrets.DecodeDockerConfig(value)
+		if err != nil {
+			return styles.ErrorMessage.Render(err.Error())
+		}
+
+		return style.Render(renderJSON(config))
+
+	case secrets.PEM:
+		return style.Render(strings.Join(secrets.DescribePEM(value), "\n") + "\n\n" + strings.TrimSpace(string(value)))
+
+	case secrets.Binary:
+		return maskedStyle.Render(fmt.Sprintf("<binary data, %d bytes>", len(value)))
+	}
+
+	return style.Render(string(value))
+}
+
+// renderJSON renders a json value with syntax highlighting.
+func renderJSON(obj interface{}) string {
+	formatter := jsoncolor.NewFormatter()
+	formatter.Indent = 2
+
+	rendered, err := formatter.Marshal(obj)
+	if err != nil {
+		return styles.ErrorMessage.Render("failed to render json")
+	}
+
+	return string(rendered)
+}
@@ -0,0 +1,234 @@
+package secretinfo
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/integer"
+)
+
+// keyMap defines the keys that are handled by this view.
+type keyMap struct {
+	kubeui.GlobalKeyMap
+	Reveal key.Binding
+}
+
+// newKeyMap defines the actual key bindings and creates a keyMap.
+func newKeyMap() *keyMap {
+	return &keyMap{
+		GlobalKeyMap: kubeui.NewGlobalKeyMap(),
+		Reveal: key.NewBinding(
+			key.WithKeys("ctrl+v"),
+			key.WithHelp("ctrl+v", "Reveal/hide values"),
+		),
+	}
+}
+
+func (v View) fullHelp() [][]key.Binding {
+	viewPortKeys := viewport.DefaultKeyMap()
+
+	return [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView, v.keys.Reveal},
+		{
+			viewPortKeys.Up,
+			viewPortKeys.Down,
+			viewPortKeys.PageUp,
+			viewPortKeys.PageDown,
+			viewPortKeys.HalfPageUp,
+			viewPortKeys.HalfPageDown,
+		},
+	}
+}
+
+// K8sService represents the interface towards kubernetes needed by this view.
+type K8sService interface {
+	GetSecret(namespace, name string) (*v1.Secret, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// View displays the keys of a secret, along with the decoded values once they are revealed.
+type View struct {
+	keys *keyMap
+
+	// Viewport for scrolling the values.
+	valuesViewPort viewport.Model
+
+	windowWidth  int
+	windowHeight int
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	// Values are masked until they are revealed by the user.
+	reveal bool
+
+	secret *v1.Secret
+
+	// Kubernetes client.
+	k8sClient K8sService
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(k8sClient K8sService, contextClient ContextClient, windowWidth, windowHeight int) View {
+	return View{
+		k8sClient:     k8sClient,
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          newKeyMap(),
+	}
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	// Keys
+	switch {
+
+	case msg.IsWindowResize():
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+		v = v.updateViewPort(c)
+		return c, v, nil
+
+	case msg.MatchesKeyBindings(v.keys.Quit):
+		return c, v, kubeui.Exit()
+
+	case msg.MatchesKeyBindings(v.keys.ExitView):
+		return c, v, kubeui.PopView(false)
+
+	case msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp:
+		v.showFullHelp = true
+		return c, v, nil
+
+	case msg.MatchesKeyBindings(v.keys.Reveal):
+		v.reveal = !v.reveal
+		v = v.updateViewPort(c)
+		return c, v, nil
+
+	case msg.MatchesKeyBindings(v.keys.Refresh):
+		return c, v, v.Init(c)
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+	case k8smsg.GetSecretMsg:
+		v.secret = t.Secret
+		v = v.updateViewPort(c)
+
+		return c, v, nil
+	}
+
+	var cmd tea.Cmd
+	if v.secret != nil {
+		v.valuesViewPort, cmd = v.valuesViewPort.Update(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+// updateViewPort resizes the viewport to fill the space below the header and renders the values.
+func (v View) updateViewPort(c kubeui.Context) View {
+	if v.secret == nil {
+		return v
+	}
+
+	v.valuesViewPort.Width = v.windowWidth
+	v.valuesViewPort.Height = integer.IntMax(v.windowHeight-(lipgloss.Height(v.headerView(c))+lipgloss.Height(footerView(v.windowWidth, v.valuesViewPort))), 1)
+	v.valuesViewPort.SetContent(renderValues(v.windowWidth, v.secret.Data, v.reveal))
+
+	return v
+}
+
+// headerView renders the help and status bar displayed above the values.
+func (v View) headerView(c kubeui.Context) string {
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView, v.keys.Reveal}))
+	builder.WriteString("\n\n")
+
+	secretType := ""
+	if v.secret != nil {
+		secretType = string(v.secret.Type)
+	}
+
+	headerLine := header.New(v.windowWidth-1, v.contextClient, header.Location{Namespace: c.Namespace, Path: []string{"secrets", c.SelectedSecret}, Details: []string{fmt.Sprintf("Type: %s", secretType)}})
+	builder.WriteString(headerLine + "\n")
+
+	return builder.String()
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	if v.secret == nil {
+		return "Loading..."
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(v.headerView(c))
+
+	if len(v.secret.Data) == 0 {
+		builder.WriteString(fmt.Sprintf("Secret %s has no data", c.SelectedSecret))
+		return builder.String()
+	}
+
+	builder.WriteString(v.valuesViewPort.View())
+	builder.WriteString(footerView(v.windowWidth, v.valuesViewPort))
+
+	return builder.String()
+}
+
+// footerView creates the footerView which contains information about how far the user has scrolled through the viewPort.
+func footerView(width int, viewPort viewport.Model) string {
+	info := fmt.Sprintf("%3.f%%", viewPort.ScrollPercent()*100)
+	line := strings.Repeat("─", integer.IntMax(0, width-lipgloss.Width(info)))
+	return "\x1b[0m" + "\n" + lipgloss.NewStyle().Width(width).Render(lipgloss.JoinHorizontal(lipgloss.Center, line, info))
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return func() tea.Msg {
+		secret, err := v.k8sClient.GetSecret(c.Namespace, c.SelectedSecret)
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewGetSecretMsg(secret)
+	}
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	return nil
+}
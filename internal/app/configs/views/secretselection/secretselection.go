@@ -0,0 +1,234 @@
+package secretselection
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeui/internal/pkg/component/columntable"
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+	"kubeui/internal/pkg/ui/k8stable"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+)
+
+// keyMap defines the keys that are handled by this view.
+type keyMap struct {
+	kubeui.GlobalKeyMap
+	ShowConfigMaps key.Binding
+}
+
+// newKeyMap defines the actual key bindings and creates a keyMap.
+func newKeyMap() *keyMap {
+	return &keyMap{
+		GlobalKeyMap: kubeui.NewGlobalKeyMap(),
+		ShowConfigMaps: key.NewBinding(
+			key.WithKeys("ctrl+w"),
+			key.WithHelp("ctrl+w", "Show configmaps"),
+		),
+	}
+}
+
+func (v View) fullHelp() [][]key.Binding {
+	bindings := [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView, v.keys.ShowConfigMaps},
+	}
+
+	if len(v.secrets) > 0 {
+		bindings = append(bindings, v.secretTable.KeyList())
+	}
+
+	return bindings
+}
+
+// K8sClient represents the interface towards kubernetes needed by this view.
+type K8sClient interface {
+	ListSecrets(namespace string) (*v1.SecretList, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// View is used to select a secret.
+type View struct {
+	keys *keyMap
+
+	windowWidth  int
+	windowHeight int
+
+	// Secrets in current namespace.
+	secrets []v1.Secret
+
+	// ColumnTable used to select a secret.
+	secretTable columntable.Model
+
+	// Loading indicator
+	loading bool
+
+	// If the View has been initialized or not.
+	initialized bool
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	// Kubernetes client.
+	k8sClient K8sClient
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(k8sClient K8sClient, contextClient ContextClient, windowWidth, windowHeight int) View {
+	return View{
+		k8sClient:     k8sClient,
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          newKeyMap(),
+		loading:       true,
+	}
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	if msg.IsWindowResize() {
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+
+		var cmd tea.Cmd
+		if v.initialized {
+			v.secretTable, cmd = v.secretTable.Update(columntable.UpdateHeight{Height: v.tableHeight()})
+		}
+
+		return c, v, cmd
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp {
+		v.showFullHelp = true
+		return c, v, nil
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Quit) {
+		return c, v, kubeui.Exit()
+	}
+
+	if msg.MatchesKeyBindings(v.keys.ExitView) {
+		return c, v, kubeui.PopView(false)
+	}
+
+	// The view is returned to if it is already in the navigation stack.
+	if msg.MatchesKeyBindings(v.keys.ShowConfigMaps) {
+		return c, v, kubeui.PushView("configmap_selection", false)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Refresh) {
+		return c, v, v.Init(c)
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+	case k8smsg.ListSecretsMsg:
+		v.secrets = t.SecretList.Items
+		secretColumns, secretRows := k8stable.SecretColumnsAndRows(v.secrets)
+		var cmd tea.Cmd
+
+		// The first time we receive a list of secrets then we create a new secretTable.
+		// Otherwise we just update it.
+		if !v.initialized {
+			v.initialized = true
+			v.secretTable = columntable.New(secretColumns, secretRows, columntable.PageSize(v.tableHeight()), "", false, columntable.Options{SingularItemName: "secret", StartInSearchMode: true})
+		} else {
+			v.secretTable, cmd = v.secretTable.Update(columntable.UpdateRowsAndColumns{Rows: secretRows, Columns: secretColumns})
+		}
+
+		v.loading = false
+
+		return c, v, cmd
+
+	case columntable.Selection:
+		c.SelectedSecret = t.Id
+		return c, v, kubeui.PushView("secret_info", true)
+	}
+
+	var cmd tea.Cmd
+	if v.initialized {
+		v.secretTable, cmd = v.secretTable.Update(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+// tableHeight returns the number of lines available to the table, which is displayed below the short help, an empty line and the status bar.
+func (v View) tableHeight() int {
+	// The height is not known before the first resize.
+	if v.windowHeight == 0 {
+		return 0
+	}
+
+	return v.windowHeight - 4
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.ShowConfigMaps, v.keys.Refresh}))
+	builder.WriteString("\n\n")
+
+	headerLine := header.New(v.windowWidth-1, v.contextClient, header.Location{Namespace: c.Namespace, Path: []string{"secrets"}})
+	builder.WriteString(headerLine + "\n")
+
+	if v.loading {
+		return "Loading..."
+	} else if len(v.secrets) == 0 {
+		builder.WriteString(fmt.Sprintf("No secrets found in namespace %s", c.Namespace))
+	} else {
+		builder.WriteString(v.secretTable.View())
+	}
+
+	return builder.String()
+}
+
+// CapturesText reports whether the view captures typed text, which it does while the secrets are searched.
+func (v View) CapturesText() bool {
+	return v.secretTable.Searching()
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return func() tea.Msg {
+		secretList, err := v.k8sClient.ListSecrets(c.Namespace)
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewListSecretsMsg(secretList)
+	}
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	return nil
+}
@@ -0,0 +1,286 @@
+package contextselection
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"kubeui/internal/pkg/component/confirm"
+	"kubeui/internal/pkg/component/searchtable"
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyMap defines the keys that are handled by this view.
+type keyMap struct {
+	kubeui.GlobalKeyMap
+	ToggleSessionScope key.Binding
+}
+
+// newKeyMap defines the actual key bindings and creates a keyMap.
+func newKeyMap() *keyMap {
+	return &keyMap{
+		GlobalKeyMap: kubeui.NewGlobalKeyMap(),
+		ToggleSessionScope: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("ctrl+o", "Toggle between switching context in the kubeconfig or only in this session"),
+		),
+	}
+}
+
+func (v View) fullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.ExitView, v.keys.ToggleSessionScope},
+		v.contextTable.KeyList(),
+	}
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+	Contexts() []string
+	SwitchContext(ctx, namespace string) (err error)
+	SessionScoped() bool
+	SetSessionScoped(scoped bool)
+	DeleteContext(ctx string) (err error)
+	DeleteUser(user string) (err error)
+	DeleteClusterEntry(cluster string) (err error)
+}
+
+// View allows the user to switch to another context, or to delete a context.
+// Switching context changes the kubeconfig unless the selection is session scoped, which is toggled by the user.
+// A k8smsg.ContextSwitchedMsg is returned after switching context, which allows an application to connect to the cluster of the new context.
+type View struct {
+	windowHeight int
+	windowWidth  int
+
+	keys *keyMap
+
+	// SearchTable used to select and delete contexts.
+	contextTable searchtable.Model
+
+	// Yes/No dialog.
+	// If non nil then the dialog is considered to be active.
+	// A new dialog is created when needed.
+	activeDialog *confirm.Model
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(contextClient ContextClient, windowWidth, windowHeight int) View {
+	v := View{
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          newKeyMap(),
+	}
+
+	v.contextTable = searchtable.New(
+		sortedContexts(contextClient),
+		searchtable.PageSize(v.tableHeight()),
+		contextClient.CurrentContext(),
+		true,
+		searchtable.Options{SingularItemName: "context"},
+	)
+
+	return v
+}
+
+// sortedContexts returns the available contexts in alphabetical order.
+func sortedContexts(contextClient ContextClient) []string {
+	contexts := contextClient.Contexts()
+	sort.Strings(contexts)
+	return contexts
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsWindowResize() {
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+
+		var cmd tea.Cmd
+		v.contextTable, cmd = v.contextTable.Update(searchtable.UpdateHeight{Height: v.tableHeight()})
+
+		return c, v, cmd
+	}
+
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp {
+		v.showFullHelp = true
+		return c, v, nil
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Quit) {
+		return c, v, kubeui.Exit()
+	}
+
+	if msg.MatchesKeyBindings(v.keys.ExitView) && v.activeDialog == nil {
+		return c, v, kubeui.PopView(false)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.ToggleSessionScope) && v.activeDialog == nil {
+		previous := v.contextClient.CurrentContext()
+		v.contextClient.SetSessionScoped(!v.contextClient.SessionScoped())
+
+		// The current context of the kubeconfig is restored when the selection of the session is discarded,
+		// which is handled the same way as switching context.
+		if current := v.contextClient.CurrentContext(); current != previous {
+			return c, v, func() tea.Msg {
+				return k8smsg.NewContextSwitchedMsg(current)
+			}
+		}
+
+		return c, v, nil
+	}
+
+	switch t := msg.TeaMsg.(type) {
+	case searchtable.Selection:
+		// Selecting the current context again keeps the current connection.
+		if t.Value == v.contextClient.CurrentContext() {
+			return c, v, kubeui.PopView(false)
+		}
+
+		return c, v, func() tea.Msg {
+			err := v.contextClient.SwitchContext(t.Value, "")
+			if err != nil {
+				return err
+			}
+
+			return k8smsg.NewContextSwitchedMsg(t.Value)
+		}
+
+	case k8smsg.ContextSwitchedMsg:
+		var cmd tea.Cmd
+		v.contextTable, cmd = v.contextTable.Update(searchtable.UpdateHighlighted{Item: t.Name})
+		return c, v, cmd
+
+	case searchtable.Deletion:
+		dialog := confirm.New([]confirm.Button{{Desc: "Yes", Id: t.Value}, {Desc: "No", Id: t.Value}}, fmt.Sprintf("Are you sure you want to delete %s", t.Value))
+		v.activeDialog = &dialog
+		return c, v, nil
+
+	case confirm.ButtonPress:
+		// If the user pressed No then we close the dialog.
+		if t.Pressed.Desc != "Yes" {
+			v.activeDialog = nil
+			return c, v, nil
+		}
+
+		return c, v, func() tea.Msg {
+			err := deleteContext(t.Pressed.Id, v.contextClient)
+			if err != nil {
+				return err
+			}
+
+			return k8smsg.NewContextDeletedMsg(t.Pressed.Id)
+		}
+
+	case k8smsg.ContextDeletedMsg:
+		v.activeDialog = nil
+		var cmd tea.Cmd
+		v.contextTable, cmd = v.contextTable.Update(searchtable.UpdateItems{Items: sortedContexts(v.contextClient)})
+		return c, v, cmd
+	}
+
+	if v.activeDialog != nil {
+		dialog, cmd := v.activeDialog.Update(msg.TeaMsg)
+		v.activeDialog = &dialog
+		return c, v, cmd
+	}
+
+	var cmd tea.Cmd
+	v.contextTable, cmd = v.contextTable.Update(msg.TeaMsg)
+	return c, v, cmd
+}
+
+// deleteContext deletes a kubernetes context and the corresponding cluster entry and user entry.
+func deleteContext(kubeCtx string, contextClient ContextClient) error {
+	err := contextClient.DeleteContext(kubeCtx)
+	if err != nil {
+		return err
+	}
+
+	err = contextClient.DeleteClusterEntry(kubeCtx)
+	if err != nil {
+		return err
+	}
+
+	err = contextClient.DeleteUser(kubeCtx)
+
+	return err
+}
+
+// tableHeight returns the number of lines available to the table, which is displayed below the short help, an empty line and the status bar.
+func (v View) tableHeight() int {
+	// The height is not known before the first resize.
+	if v.windowHeight == 0 {
+		return 0
+	}
+
+	return v.windowHeight - 4
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.ExitView, v.keys.ToggleSessionScope}))
+	builder.WriteString("\n\n")
+
+	scope := "Scope: kubeconfig"
+	if v.contextClient.SessionScoped() {
+		scope = "Scope: session"
+	}
+
+	headerLine := header.New(v.windowWidth-1, v.contextClient, header.Location{Path: []string{"contexts"}, Details: []string{scope}})
+	builder.WriteString(headerLine + "\n")
+
+	if v.activeDialog != nil {
+		builder.WriteString(v.activeDialog.View())
+		return builder.String()
+	}
+
+	builder.WriteString(v.contextTable.View())
+
+	return builder.String()
+}
+
+// CapturesText reports whether the view captures typed text, which it does while the contexts are searched.
+func (v View) CapturesText() bool {
+	return v.contextTable.Searching()
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return nil
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	return nil
+}
@@ -0,0 +1,80 @@
+package deployments
+
+import (
+	"fmt"
+
+	"kubeui/internal/app/common"
+	"kubeui/internal/app/deployments/views/deploymentinfo"
+	"kubeui/internal/app/deployments/views/deploymentpods"
+	"kubeui/internal/app/deployments/views/deploymentselection"
+	"kubeui/internal/app/deployments/views/statefulsetselection"
+	"kubeui/internal/app/pods/views/errorinfo"
+	"kubeui/internal/app/pods/views/namespaceselection"
+	"kubeui/internal/app/pods/views/podinfo"
+	"kubeui/internal/pkg/k8s"
+	"kubeui/internal/pkg/k8s/k8scontext"
+	"kubeui/internal/pkg/kubeui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// program contains what is needed to create the views of the program.
+type program struct {
+	contextClient k8scontext.Client
+	k8sService    k8s.Service
+}
+
+// NewModel creates a new model.
+func NewModel(contextClient k8scontext.Client, k8sService k8s.Service) kubeui.Root {
+	p := program{contextClient: contextClient, k8sService: k8sService}
+
+	return kubeui.NewRoot(kubeui.RootOptions{
+		Context: kubeui.Context{
+			Namespace: "default",
+		},
+		Start:    p.start,
+		NewView:  p.newView,
+		Commands: common.Commands("deployment_selection", "statefulset_selection", "namespace_selection"),
+	})
+}
+
+// start uses the namespace of the current context, the user selects a namespace first if the context has no namespace.
+func (p program) start(c kubeui.Context) (kubeui.Context, tea.Cmd) {
+	currentContext, ok := p.contextClient.CurrentApiContext()
+
+	if !ok {
+		return c, kubeui.Error(fmt.Errorf("invalid context"))
+	}
+
+	if currentContext.Namespace != "" {
+		c.Namespace = currentContext.Namespace
+	}
+
+	if c.Namespace == "default" {
+		return c, kubeui.PushView("namespace_selection", true)
+	}
+
+	return c, kubeui.PushView("deployment_selection", true)
+}
+
+// newView creates the view with the given id.
+func (p program) newView(id string, config kubeui.ViewConfig) kubeui.View {
+	switch id {
+	case "deployment_selection":
+		return deploymentselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "deployment_info":
+		return deploymentinfo.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "deployment_pods":
+		return deploymentpods.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "statefulset_selection":
+		return statefulsetselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "namespace_selection":
+		return namespaceselection.New(p.k8sService, p.contextClient, "deployment_selection", false, config.WindowWidth, config.WindowHeight)
+	case "pod_info":
+		return podinfo.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case kubeui.ErrorViewId:
+		return errorinfo.New(config.Err.Error(), kubeui.ErrorDetails(config.Err), config.WindowWidth, config.WindowHeight)
+	}
+
+	return namespaceselection.New(p.k8sService, p.contextClient, "deployment_selection", false, config.WindowWidth, config.WindowHeight)
+}
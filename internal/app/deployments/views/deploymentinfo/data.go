@@ -0,0 +1,79 @@
+package deploymentinfo
+
+import (
+	"time"
+
+	"kubeui/internal/pkg/k8s"
+	"kubeui/internal/pkg/ui/table"
+
+	"github.com/life4/genesis/slices"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/utils/integer"
+)
+
+// deploymentStatusColumnsAndRows creates the neccessary columns and row in order to display deployment status information.
+func deploymentStatusColumnsAndRows(deployment appsv1.Deployment) ([]table.DataColumn, table.DataRow) {
+	deploymentColumns := []table.DataColumn{
+		{Desc: "Name", Width: 6},
+		{Desc: "Ready", Width: 7},
+		{Desc: "Up-to-date", Width: 12},
+		{Desc: "Available", Width: 11},
+		{Desc: "Strategy", Width: 10},
+		{Desc: "Selector", Width: 10},
+		{Desc: "Age", Width: 3},
+	}
+
+	deploymentFormat := k8s.NewListDeploymentFormat(deployment, time.Now())
+	strategy := string(deployment.Spec.Strategy.Type)
+	selector := metav1.FormatLabelSelector(deployment.Spec.Selector)
+
+	deploymentColumns[0].Width = integer.IntMax(deploymentColumns[0].Width, len(deploymentFormat.Name)+2)
+	deploymentColumns[1].Width = integer.IntMax(deploymentColumns[1].Width, len(deploymentFormat.Ready)+2)
+	deploymentColumns[2].Width = integer.IntMax(deploymentColumns[2].Width, len(deploymentFormat.UpToDate)+2)
+	deploymentColumns[3].Width = integer.IntMax(deploymentColumns[3].Width, len(deploymentFormat.Available)+2)
+	deploymentColumns[4].Width = integer.IntMax(deploymentColumns[4].Width, len(strategy)+2)
+	deploymentColumns[5].Width = integer.IntMax(deploymentColumns[5].Width, len(selector)+2)
+	deploymentColumns[6].Width = integer.IntMax(deploymentColumns[6].Width, len(deploymentFormat.Age))
+
+	deploymentRow := table.DataRow{
+		Values: []string{deploymentFormat.Name, deploymentFormat.Ready, deploymentFormat.UpToDate, deploymentFormat.Available, strategy, selector, deploymentFormat.Age},
+	}
+
+	return deploymentColumns, deploymentRow
+}
+
+// conditionColumnsAndRows creates the neccessary columns and rows in order to display the conditions of a deployment.
+func conditionColumnsAndRows(maxWidth int, conditions []appsv1.DeploymentCondition) ([]table.DataColumn, []table.DataRow) {
+	conditionColumns := []table.DataColumn{
+		{Desc: "Type", Width: 6},
+		{Desc: "Status", Width: 8},
+		{Desc: "Reason", Width: 8},
+		{Desc: "Last Update", Width: 13},
+		{Desc: "Message", Width: 30},
+	}
+
+	now := time.Now()
+
+	conditionRows := slices.Map(conditions, func(c appsv1.DeploymentCondition) table.DataRow {
+		lastUpdate := duration.HumanDuration(now.Sub(c.LastUpdateTime.Time))
+
+		conditionColumns[0].Width = integer.IntMax(conditionColumns[0].Width, len(c.Type)+2)
+		conditionColumns[1].Width = integer.IntMax(conditionColumns[1].Width, len(c.Status)+2)
+		conditionColumns[2].Width = integer.IntMax(conditionColumns[2].Width, len(c.Reason)+2)
+		conditionColumns[3].Width = integer.IntMax(conditionColumns[3].Width, len(lastUpdate)+2)
+
+		remainingWidth := maxWidth - slices.Reduce(conditionColumns[0:4], 0, func(c table.DataColumn, acc int) int {
+			return acc + c.Width
+		})
+
+		conditionColumns[4].Width = integer.IntMax(integer.IntMax(remainingWidth-1, len(c.Message)), 30)
+
+		return table.DataRow{
+			Values: []string{string(c.Type), string(c.Status), c.Reason, lastUpdate, c.Message},
+		}
+	})
+
+	return conditionColumns, conditionRows
+}
@@ -0,0 +1,452 @@
+package deploymentinfo
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeui/internal/pkg/editor"
+	"kubeui/internal/pkg/k8s/deployments"
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+	"kubeui/internal/pkg/ui/k8stable"
+	"kubeui/internal/pkg/ui/selection"
+	"kubeui/internal/pkg/ui/table"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/utils/integer"
+)
+
+// keyMap defines the keys that are handled by this view.
+type keyMap struct {
+	kubeui.GlobalKeyMap
+	Left     key.Binding
+	Right    key.Binding
+	ShowPods key.Binding
+	Edit     key.Binding
+}
+
+// newKeyMap defines the actual key bindings and creates a keyMap.
+func newKeyMap() *keyMap {
+	return &keyMap{
+		GlobalKeyMap: kubeui.NewGlobalKeyMap(),
+		Left: key.NewBinding(
+			key.WithKeys("left"),
+			key.WithHelp("left", "Move cursor left one position"),
+		),
+		Right: key.NewBinding(
+			key.WithKeys("right"),
+			key.WithHelp("right", "Move cursor right one position"),
+		),
+		ShowPods: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("ctrl+p", "Show pods"),
+		),
+		Edit: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("ctrl+o", "Edit the deployment in $EDITOR"),
+		),
+	}
+}
+
+func (v View) fullHelp() [][]key.Binding {
+	bindings := [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView, v.keys.ShowPods, v.keys.Edit},
+	}
+
+	viewPortKeys := viewport.DefaultKeyMap()
+
+	bindings = append(bindings, []key.Binding{
+		v.keys.Left,
+		v.keys.Right,
+		viewPortKeys.Up,
+		viewPortKeys.Down,
+		viewPortKeys.PageUp,
+		viewPortKeys.PageDown,
+		viewPortKeys.HalfPageUp,
+		viewPortKeys.HalfPageDown,
+	})
+
+	return bindings
+}
+
+// K8sService represents the interface towards kubernetes needed by this view.
+type K8sService interface {
+	GetDeployment(namespace, name string) (*deployments.Deployment, error)
+	UpdateDeployment(namespace string, deployment *appsv1.Deployment) (string, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// View displays deployment information.
+type View struct {
+	keys *keyMap
+
+	tab  tab
+	tabs []string
+
+	// Indicates whether the deployment has been loaded or not.
+	initialized bool
+
+	// Viewports for scrolling content
+	conditionsViewPort  viewport.Model
+	annotationsViewPort viewport.Model
+	labelsViewPort      viewport.Model
+	eventsViewPort      viewport.Model
+
+	windowWidth  int
+	windowHeight int
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	deployment *deployments.Deployment
+
+	// Kubernetes client.
+	k8sClient K8sService
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(k8sClient K8sService, contextClient ContextClient, windowWidth, windowHeight int) View {
+	return View{
+		k8sClient:     k8sClient,
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          newKeyMap(),
+		tabs:          []string{STATUS.String(), CONDITIONS.String(), ANNOTATIONS.String(), LABELS.String(), EVENTS.String()},
+	}
+}
+
+// tab defines the different tabs of the component.
+type tab int
+
+const (
+	// STATUS is used to display status information about the deployment.
+	STATUS tab = iota
+	// CONDITIONS is used to display the conditions of the deployment.
+	CONDITIONS
+	// ANNOTATIONS is used to display the annotations set for the deployment.
+	ANNOTATIONS
+	// LABELS is used to display the labels set for the deployment.
+	LABELS
+	// EVENTS is used to display the latest events for the deployment.
+	EVENTS
+)
+
+// String implements the stringer interface for tab.
+func (t tab) String() string {
+	switch t {
+	case STATUS:
+		return "STATUS"
+	case CONDITIONS:
+		return "CONDITIONS"
+	case ANNOTATIONS:
+		return "ANNOTATIONS"
+	case LABELS:
+		return "LABELS"
+	case EVENTS:
+		return "EVENTS"
+	}
+	return "UNKNOWN"
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	// Keys
+	switch {
+
+	case msg.IsWindowResize():
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+		v = v.updateViewportsAfterResize()
+		return c, v, nil
+
+	case msg.MatchesKeyBindings(v.keys.Quit):
+		return c, v, kubeui.Exit()
+
+	case msg.MatchesKeyBindings(v.keys.ExitView):
+		return c, v, kubeui.PopView(false)
+
+	case msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp:
+		v.showFullHelp = true
+		return c, v, nil
+
+	case msg.MatchesKeyBindings(v.keys.ShowPods):
+		return c, v, kubeui.PushView("deployment_pods", true)
+
+	case msg.MatchesKeyBindings(v.keys.Edit) && v.deployment != nil:
+		deployment := v.deployment.Deployment.DeepCopy()
+		// Objects fetched using the typed clients have their type information stripped.
+		deployment.APIVersion = "apps/v1"
+		deployment.Kind = "Deployment"
+		return c, v, editor.Edit(deployment)
+
+	case msg.MatchesKeyBindings(v.keys.Left):
+		v = v.moveTabLeft()
+		return c, v, nil
+	case msg.MatchesKeyBindings(v.keys.Right):
+		v = v.moveTabRight()
+		return c, v, nil
+	case msg.MatchesKeyBindings(v.keys.Refresh):
+		return c, v, v.Init(c)
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+	case k8smsg.GetDeploymentMsg:
+
+		if !v.initialized {
+			v.initialized = true
+		}
+
+		v.deployment = t.Deployment
+		v = v.updateViewportsAfterResize()
+
+		return c, v, nil
+
+	case editor.EditedMsg:
+		if !t.Changed() {
+			return c, v, nil
+		}
+
+		return c, v, func() tea.Msg {
+			deployment := &appsv1.Deployment{}
+			if err := t.Unmarshal(deployment); err != nil {
+				return err
+			}
+
+			if _, err := v.k8sClient.UpdateDeployment(c.Namespace, deployment); err != nil {
+				return t.Error(err)
+			}
+
+			updatedDeployment, err := v.k8sClient.GetDeployment(c.Namespace, c.SelectedDeployment)
+			if err != nil {
+				return err
+			}
+
+			return k8smsg.NewGetDeploymentMsg(updatedDeployment)
+		}
+	}
+
+	// Update viewports.
+	var cmd tea.Cmd
+	if v.initialized {
+		v, cmd = v.updateViewports(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+func (v View) updateViewportsAfterResize() View {
+	if v.deployment == nil {
+		return v
+	}
+
+	v.conditionsViewPort.Height = v.windowHeight - (lipgloss.Height(v.headerView(v.windowWidth, CONDITIONS)) + lipgloss.Height(footerView(v.windowWidth, v.conditionsViewPort)))
+	v.conditionsViewPort.Width = v.windowWidth
+
+	v.annotationsViewPort.Height = v.windowHeight - (lipgloss.Height(v.headerView(v.windowWidth, ANNOTATIONS)) + lipgloss.Height(footerView(v.windowWidth, v.annotationsViewPort)))
+	v.annotationsViewPort.Width = v.windowWidth
+
+	v.labelsViewPort.Height = v.windowHeight - (lipgloss.Height(v.headerView(v.windowWidth, LABELS)) + lipgloss.Height(footerView(v.windowWidth, v.labelsViewPort)))
+	v.labelsViewPort.Width = v.windowWidth
+
+	v.eventsViewPort.Height = v.windowHeight - (lipgloss.Height(v.headerView(v.windowWidth, EVENTS)) + lipgloss.Height(footerView(v.windowWidth, v.eventsViewPort)))
+	v.eventsViewPort.Width = v.windowWidth
+
+	if v.conditionsViewPort.Height > 0 {
+		v.conditionsViewPort.SetContent(table.RowsToString(conditionColumnsAndRows(v.windowWidth, v.deployment.Deployment.Status.Conditions)))
+	}
+
+	if v.annotationsViewPort.Height > 0 {
+		v.annotationsViewPort.SetContent(table.RowsToString(table.StringMapColumnsAndRows(v.windowWidth, "Key", "Value", v.deployment.Deployment.Annotations)))
+	}
+
+	if v.labelsViewPort.Height > 0 {
+		v.labelsViewPort.SetContent(table.RowsToString(table.StringMapColumnsAndRows(v.windowWidth, "Key", "Value", v.deployment.Deployment.Labels)))
+	}
+
+	if v.eventsViewPort.Height > 0 {
+		v.eventsViewPort.SetContent(table.RowsToString(k8stable.EventColumnsAndRows(v.windowWidth, v.deployment.Events)))
+	}
+
+	return v
+}
+
+// updateViewports updates the currently active viewport.
+func (v View) updateViewports(msg tea.Msg) (View, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch v.tab {
+	case CONDITIONS:
+		v.conditionsViewPort, cmd = v.conditionsViewPort.Update(msg)
+	case ANNOTATIONS:
+		v.annotationsViewPort, cmd = v.annotationsViewPort.Update(msg)
+	case LABELS:
+		v.labelsViewPort, cmd = v.labelsViewPort.Update(msg)
+	case EVENTS:
+		v.eventsViewPort, cmd = v.eventsViewPort.Update(msg)
+	}
+
+	return v, cmd
+}
+
+func (v View) moveTabLeft() View {
+	if v.tab > 0 {
+		v.tab--
+	} else {
+		v.tab = tab(len(v.tabs) - 1)
+	}
+
+	return v
+}
+
+func (v View) moveTabRight() View {
+	if v.tab < tab(len(v.tabs)-1) {
+		v.tab++
+	} else {
+		v.tab = 0
+	}
+
+	return v
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	builder := strings.Builder{}
+	header := v.headerView(v.windowWidth, v.tab)
+	builder.WriteString(header)
+
+	if v.deployment == nil {
+		return builder.String()
+	}
+
+	switch v.tab {
+	case STATUS:
+		columns, row := deploymentStatusColumnsAndRows(v.deployment.Deployment)
+		builder.WriteString(table.RowsToString(columns, []table.DataRow{row}))
+		return builder.String()
+
+	case CONDITIONS:
+		footer := footerView(v.windowWidth, v.conditionsViewPort)
+		builder.WriteString(v.conditionsViewPort.View())
+		builder.WriteString(footer)
+
+	case ANNOTATIONS:
+		footer := footerView(v.windowWidth, v.annotationsViewPort)
+		builder.WriteString(v.annotationsViewPort.View())
+		builder.WriteString(footer)
+
+	case LABELS:
+		footer := footerView(v.windowWidth, v.labelsViewPort)
+		builder.WriteString(v.labelsViewPort.View())
+		builder.WriteString(footer)
+
+	case EVENTS:
+		footer := footerView(v.windowWidth, v.eventsViewPort)
+		builder.WriteString(v.eventsViewPort.View())
+		builder.WriteString(footer)
+	}
+
+	return builder.String()
+}
+
+func (v View) headerView(width int, forTab tab) string {
+	if v.deployment == nil {
+		return "Loading..."
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(width, []key.Binding{
+		v.keys.Help,
+		v.keys.Quit,
+		v.keys.Refresh,
+		v.keys.ShowPods,
+		v.keys.Edit,
+		v.keys.Left,
+		v.keys.Right,
+	}))
+
+	builder.WriteString("\n\n")
+
+	builder.WriteString(header.New(width-1, v.contextClient, header.Location{Namespace: v.deployment.Deployment.Namespace, Path: []string{"deployments", v.deployment.Deployment.Name}}) + "\n")
+
+	builder.WriteString(selection.Tabs(int(forTab), width, v.tabs) + "\n\n")
+
+	builder.WriteString(tableHeaderView(width, forTab, *v.deployment))
+
+	return builder.String()
+}
+
+// tableHeaderView creates the table header view.
+// Producing table headers seperately from the rows allows us to let the content scroll past the headers without hiding them.
+func tableHeaderView(width int, t tab, deployment deployments.Deployment) string {
+	var columns []table.DataColumn
+	switch t {
+	case STATUS:
+		columns, _ = deploymentStatusColumnsAndRows(deployment.Deployment)
+	case CONDITIONS:
+		columns, _ = conditionColumnsAndRows(width, deployment.Deployment.Status.Conditions)
+	case ANNOTATIONS:
+		columns, _ = table.StringMapColumnsAndRows(width, "Key", "Value", deployment.Deployment.Annotations)
+	case LABELS:
+		columns, _ = table.StringMapColumnsAndRows(width, "Key", "Value", deployment.Deployment.Labels)
+	case EVENTS:
+		columns, _ = k8stable.EventColumnsAndRows(width, deployment.Events)
+	}
+
+	line := strings.Repeat("─", width)
+	return lipgloss.NewStyle().Width(width).Render(table.ColumnsToString(columns)) + "\n" + lipgloss.JoinHorizontal(lipgloss.Center, line) + "\n\n"
+}
+
+// footerView creates the footerView which contains information about how far the user has scrolled through the viewPort.
+func footerView(width int, viewPort viewport.Model) string {
+	info := fmt.Sprintf("%3.f%%", viewPort.ScrollPercent()*100)
+	line := strings.Repeat("─", integer.IntMax(0, width-lipgloss.Width(info)))
+	return "\x1b[0m" + "\n" + lipgloss.NewStyle().Width(width).Render(lipgloss.JoinHorizontal(lipgloss.Center, line, info))
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return func() tea.Msg {
+		deployment, err := v.k8sClient.GetDeployment(c.Namespace, c.SelectedDeployment)
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewGetDeploymentMsg(deployment)
+	}
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	return nil
+}
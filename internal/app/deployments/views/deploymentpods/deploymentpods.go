@@ -0,0 +1,213 @@
+package deploymentpods
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeui/internal/pkg/component/columntable"
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+	"kubeui/internal/pkg/ui/k8stable"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+)
+
+func (v View) fullHelp() [][]key.Binding {
+	bindings := [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView},
+	}
+
+	if len(v.pods) > 0 {
+		bindings = append(bindings, v.podTable.KeyList())
+	}
+
+	return bindings
+}
+
+// K8sClient represents the interface towards kubernetes needed by this view.
+type K8sClient interface {
+	ListDeploymentPods(namespace, name string) (*v1.PodList, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// View is used to select one of the pods owned by a deployment.
+type View struct {
+	keys kubeui.GlobalKeyMap
+
+	windowWidth  int
+	windowHeight int
+
+	// Pods owned by the selected deployment.
+	pods []v1.Pod
+
+	// ColumnTable used to select a pod.
+	podTable columntable.Model
+
+	// Loading indicator
+	loading bool
+
+	// If the View has been initialized or not.
+	initialized bool
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	// Kubernetes client.
+	k8sClient K8sClient
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(k8sClient K8sClient, contextClient ContextClient, windowWidth, windowHeight int) View {
+	return View{
+		k8sClient:     k8sClient,
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          kubeui.NewGlobalKeyMap(),
+		loading:       true,
+	}
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	if msg.IsWindowResize() {
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+
+		var cmd tea.Cmd
+		if v.initialized {
+			v.podTable, cmd = v.podTable.Update(columntable.UpdateHeight{Height: v.tableHeight()})
+		}
+
+		return c, v, cmd
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp {
+		v.showFullHelp = true
+		return c, v, nil
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Quit) {
+		return c, v, kubeui.Exit()
+	}
+
+	if msg.MatchesKeyBindings(v.keys.ExitView) {
+		// The deployment has not changed, so we don't reinitialize it when going back.
+		return c, v, kubeui.PopView(false)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Refresh) {
+		return c, v, v.Init(c)
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+	case k8smsg.ListPodsMsg:
+		v.pods = t.PodList.Items
+		podColumns, podRows := k8stable.PodColumnsAndRows(v.pods, false)
+		var cmd tea.Cmd
+
+		// The first time we receive a list of pods then we create a new podTable.
+		// Otherwise we just update it.
+		if !v.initialized {
+			v.initialized = true
+			v.podTable = columntable.New(podColumns, podRows, columntable.PageSize(v.tableHeight()), "", false, columntable.Options{SingularItemName: "pod"})
+		} else {
+			v.podTable, cmd = v.podTable.Update(columntable.UpdateRowsAndColumns{Rows: podRows, Columns: podColumns})
+		}
+
+		v.loading = false
+
+		return c, v, cmd
+
+	case columntable.Selection:
+		c.SelectedPodNamespace, c.SelectedPod = k8stable.ParseRowId(t.Id)
+		return c, v, kubeui.PushView("pod_info", true)
+	}
+
+	var cmd tea.Cmd
+	if v.initialized {
+		v.podTable, cmd = v.podTable.Update(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+// tableHeight returns the number of lines available to the table, which is displayed below the short help, an empty line and the status bar.
+func (v View) tableHeight() int {
+	// The height is not known before the first resize.
+	if v.windowHeight == 0 {
+		return 0
+	}
+
+	return v.windowHeight - 4
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView}))
+	builder.WriteString("\n\n")
+
+	headerLine := header.New(v.windowWidth-1, v.contextClient, header.Location{Namespace: c.Namespace, Path: []string{"deployments", c.SelectedDeployment, "pods"}})
+	builder.WriteString(headerLine + "\n")
+
+	if v.loading {
+		return "Loading..."
+	} else if len(v.pods) == 0 {
+		builder.WriteString(fmt.Sprintf("No pods found for deployment %s", c.SelectedDeployment))
+	} else {
+		builder.WriteString(v.podTable.View())
+	}
+
+	return builder.String()
+}
+
+// CapturesText reports whether the view captures typed text, which it does while the pods are searched.
+func (v View) CapturesText() bool {
+	return v.podTable.Searching()
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return func() tea.Msg {
+		podList, err := v.k8sClient.ListDeploymentPods(c.Namespace, c.SelectedDeployment)
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewListPodsMsg(podList)
+	}
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	return nil
+}
@@ -0,0 +1,367 @@
+package deploymentselection
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeui/internal/pkg/component/columntable"
+	"kubeui/internal/pkg/component/confirm"
+	"kubeui/internal/pkg/component/numberinput"
+	"kubeui/internal/pkg/k8s"
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+	"kubeui/internal/pkg/ui/k8stable"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/life4/genesis/slices"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// keyMap defines the keys that are handled by this view.
+type keyMap struct {
+	kubeui.GlobalKeyMap
+	SelectNamespace  key.Binding
+	Scale            key.Binding
+	Restart          key.Binding
+	ShowStatefulSets key.Binding
+}
+
+// newKeyMap defines the actual key bindings and creates a keyMap.
+func newKeyMap() *keyMap {
+	return &keyMap{
+		GlobalKeyMap: kubeui.NewGlobalKeyMap(),
+		SelectNamespace: key.NewBinding(
+			key.WithKeys("ctrl+n"),
+			key.WithHelp("ctrl+n", "Select namespace"),
+		),
+		Scale: key.NewBinding(
+			key.WithKeys("ctrl+l"),
+			key.WithHelp("ctrl+l", "Scale deployment"),
+		),
+		Restart: key.NewBinding(
+			key.WithKeys("ctrl+t"),
+			key.WithHelp("ctrl+t", "Restart deployment"),
+		),
+		ShowStatefulSets: key.NewBinding(
+			key.WithKeys("ctrl+w"),
+			key.WithHelp("ctrl+w", "Show statefulsets"),
+		),
+	}
+}
+
+func (v View) fullHelp() [][]key.Binding {
+	bindings := [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.SelectNamespace, v.keys.ShowStatefulSets},
+	}
+
+	if len(v.deployments) > 0 {
+		bindings = append(bindings, append(v.deploymentTable.KeyList(), v.keys.Scale, v.keys.Restart))
+	}
+
+	return bindings
+}
+
+// K8sClient represents the interface towards kubernetes needed by this view.
+type K8sClient interface {
+	ListDeployments(namespace string) (*appsv1.DeploymentList, error)
+	ScaleDeployment(namespace, name string, replicas int32) (string, error)
+	RestartDeployment(namespace, name string) (string, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// action defines the actions that can be confirmed through the confirmation dialog.
+type action int
+
+const (
+	scaleAction action = iota
+	restartAction
+)
+
+// View is used to select a deployment.
+type View struct {
+	keys *keyMap
+
+	windowWidth  int
+	windowHeight int
+
+	// Deployments in current namespace.
+	deployments []appsv1.Deployment
+
+	// Dialog used to confirm an action.
+	activeDialog *confirm.Model
+	// The action to run if the active dialog is confirmed.
+	dialogAction action
+
+	// Input used to choose the number of replicas when scaling.
+	activeInput *numberinput.Model
+	// The number of replicas to scale to if the active dialog is confirmed.
+	replicas int32
+
+	// ColumnTable used to select a deployment.
+	deploymentTable columntable.Model
+
+	// Loading indicator
+	loading bool
+
+	// If the View has been initialized or not.
+	initialized bool
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	// Kubernetes client.
+	k8sClient K8sClient
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(k8sClient K8sClient, contextClient ContextClient, windowWidth, windowHeight int) View {
+	return View{
+		k8sClient:     k8sClient,
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          newKeyMap(),
+		loading:       true,
+	}
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	if msg.IsWindowResize() {
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+
+		var cmd tea.Cmd
+		if v.initialized {
+			v.deploymentTable, cmd = v.deploymentTable.Update(columntable.UpdateHeight{Height: v.tableHeight()})
+		}
+
+		return c, v, cmd
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp {
+		v.showFullHelp = true
+		return c, v, nil
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Quit) {
+		return c, v, kubeui.Exit()
+	}
+
+	// Key presses are handled by the input or dialog while they are displayed.
+	if msg.IsKeyMsg() && v.activeInput != nil {
+		input, cmd := v.activeInput.Update(msg.TeaMsg)
+		v.activeInput = &input
+		return c, v, cmd
+	}
+
+	if msg.IsKeyMsg() && v.activeDialog != nil {
+		dialog, cmd := v.activeDialog.Update(msg.TeaMsg)
+		v.activeDialog = &dialog
+		return c, v, cmd
+	}
+
+	if msg.MatchesKeyBindings(v.keys.SelectNamespace) {
+		return c, v, kubeui.PushView("namespace_selection", true)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.ShowStatefulSets) {
+		return c, v, kubeui.PushView("statefulset_selection", true)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Refresh) {
+		return c, v, v.Init(c)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Scale) && v.initialized {
+		name, ok := v.deploymentTable.HighlightedRow()
+		if !ok {
+			return c, v, nil
+		}
+
+		replicas := int32(1)
+		if deployment, err := slices.Find(v.deployments, func(d appsv1.Deployment) bool { return d.Name == name }); err == nil {
+			replicas = k8s.DesiredReplicas(deployment.Spec.Replicas)
+		}
+
+		input := numberinput.New(name, fmt.Sprintf("Number of replicas for %s", name), int(replicas), numberinput.Options{})
+		v.activeInput = &input
+		return c, v, input.Init()
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Restart) && v.initialized {
+		name, ok := v.deploymentTable.HighlightedRow()
+		if !ok {
+			return c, v, nil
+		}
+
+		dialog := confirm.New([]confirm.Button{{Desc: "Yes", Id: name}, {Desc: "No", Id: name}}, fmt.Sprintf("Are you sure you want to restart %s", name))
+		v.activeDialog = &dialog
+		v.dialogAction = restartAction
+		return c, v, nil
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+	case k8smsg.ListDeploymentsMsg:
+		v.deployments = t.DeploymentList.Items
+		deploymentColumns, deploymentRows := k8stable.DeploymentColumnsAndRows(v.deployments)
+		var cmd tea.Cmd
+
+		// The first time we receive a list of deployments then we create a new deploymentTable.
+		// Otherwise we just update it.
+		if !v.initialized {
+			v.initialized = true
+			v.deploymentTable = columntable.New(deploymentColumns, deploymentRows, columntable.PageSize(v.tableHeight()), "", false, columntable.Options{SingularItemName: "deployment", StartInSearchMode: true})
+		} else {
+			v.deploymentTable, cmd = v.deploymentTable.Update(columntable.UpdateRowsAndColumns{Rows: deploymentRows, Columns: deploymentColumns})
+		}
+
+		v.loading = false
+
+		return c, v, cmd
+
+	case columntable.Selection:
+		c.SelectedDeployment = t.Id
+		return c, v, kubeui.PushView("deployment_info", true)
+
+	// When the user has chosen the number of replicas we ask for a confirmation before scaling.
+	case numberinput.Submission:
+		v.activeInput = nil
+		dialog := confirm.New([]confirm.Button{{Desc: "Yes", Id: t.Id}, {Desc: "No", Id: t.Id}}, fmt.Sprintf("Are you sure you want to scale %s to %d replicas", t.Id, t.Value))
+		v.activeDialog = &dialog
+		v.dialogAction = scaleAction
+		v.replicas = int32(t.Value)
+		return c, v, nil
+
+	case numberinput.Cancellation:
+		v.activeInput = nil
+		return c, v, nil
+
+	case confirm.ButtonPress:
+		v.activeDialog = nil
+
+		if t.Pressed.Desc != "Yes" {
+			return c, v, nil
+		}
+
+		dialogAction := v.dialogAction
+		replicas := v.replicas
+
+		return c, v, func() tea.Msg {
+			var err error
+
+			switch dialogAction {
+			case scaleAction:
+				_, err = v.k8sClient.ScaleDeployment(c.Namespace, t.Pressed.Id, replicas)
+			case restartAction:
+				_, err = v.k8sClient.RestartDeployment(c.Namespace, t.Pressed.Id)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			deploymentList, err := v.k8sClient.ListDeployments(c.Namespace)
+			if err != nil {
+				return err
+			}
+			return k8smsg.NewListDeploymentsMsg(deploymentList)
+		}
+	}
+
+	var cmd tea.Cmd
+	if v.initialized {
+		v.deploymentTable, cmd = v.deploymentTable.Update(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+// tableHeight returns the number of lines available to the table, which is displayed below the short help, an empty line and the status bar.
+func (v View) tableHeight() int {
+	// The height is not known before the first resize.
+	if v.windowHeight == 0 {
+		return 0
+	}
+
+	return v.windowHeight - 4
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.SelectNamespace, v.keys.Refresh, v.keys.Scale, v.keys.Restart}))
+	builder.WriteString("\n\n")
+
+	headerLine := header.New(v.windowWidth-1, v.contextClient, header.Location{Namespace: c.Namespace, Path: []string{"deployments"}})
+	builder.WriteString(headerLine + "\n")
+
+	if v.activeInput != nil {
+		builder.WriteString(v.activeInput.View())
+		return builder.String()
+	}
+
+	if v.activeDialog != nil {
+		builder.WriteString(v.activeDialog.View())
+		return builder.String()
+	}
+
+	if v.loading {
+		return "Loading..."
+	} else if len(v.deployments) == 0 {
+		builder.WriteString(fmt.Sprintf("No deployments found in namespace %s", c.Namespace))
+	} else {
+		builder.WriteString(v.deploymentTable.View())
+	}
+
+	return builder.String()
+}
+
+// CapturesText reports whether the view captures typed text, which it does while the deployments are searched or a number of replicas is entered.
+func (v View) CapturesText() bool {
+	return v.activeInput != nil || v.deploymentTable.Searching()
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return func() tea.Msg {
+		deploymentList, err := v.k8sClient.ListDeployments(c.Namespace)
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewListDeploymentsMsg(deploymentList)
+	}
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	return nil
+}
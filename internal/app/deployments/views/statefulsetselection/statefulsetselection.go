@@ -0,0 +1,359 @@
+package statefulsetselection
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeui/internal/pkg/component/columntable"
+	"kubeui/internal/pkg/component/confirm"
+	"kubeui/internal/pkg/component/numberinput"
+	"kubeui/internal/pkg/k8s"
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+	"kubeui/internal/pkg/ui/k8stable"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/life4/genesis/slices"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// keyMap defines the keys that are handled by this view.
+type keyMap struct {
+	kubeui.GlobalKeyMap
+	Scale           key.Binding
+	Restart         key.Binding
+	ShowDeployments key.Binding
+}
+
+// newKeyMap defines the actual key bindings and creates a keyMap.
+func newKeyMap() *keyMap {
+	return &keyMap{
+		GlobalKeyMap: kubeui.NewGlobalKeyMap(),
+		Scale: key.NewBinding(
+			key.WithKeys("ctrl+l"),
+			key.WithHelp("ctrl+l", "Scale statefulset"),
+		),
+		Restart: key.NewBinding(
+			key.WithKeys("ctrl+t"),
+			key.WithHelp("ctrl+t", "Restart statefulset"),
+		),
+		ShowDeployments: key.NewBinding(
+			key.WithKeys("ctrl+w"),
+			key.WithHelp("ctrl+w", "Show deployments"),
+		),
+	}
+}
+
+func (v View) fullHelp() [][]key.Binding {
+	bindings := [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView, v.keys.ShowDeployments},
+	}
+
+	if len(v.statefulSets) > 0 {
+		bindings = append(bindings, append(v.statefulSetTable.KeyList(), v.keys.Scale, v.keys.Restart))
+	}
+
+	return bindings
+}
+
+// K8sClient represents the interface towards kubernetes needed by this view.
+type K8sClient interface {
+	ListStatefulSets(namespace string) (*appsv1.StatefulSetList, error)
+	ScaleStatefulSet(namespace, name string, replicas int32) (string, error)
+	RestartStatefulSet(namespace, name string) (string, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// action defines the actions that can be confirmed through the confirmation dialog.
+type action int
+
+const (
+	scaleAction action = iota
+	restartAction
+)
+
+// View is used to list statefulsets and to scale or restart them.
+type View struct {
+	keys *keyMap
+
+	windowWidth  int
+	windowHeight int
+
+	// StatefulSets in current namespace.
+	statefulSets []appsv1.StatefulSet
+
+	// Dialog used to confirm an action.
+	activeDialog *confirm.Model
+	// The action to run if the active dialog is confirmed.
+	dialogAction action
+
+	// Input used to choose the number of replicas when scaling.
+	activeInput *numberinput.Model
+	// The number of replicas to scale to if the active dialog is confirmed.
+	replicas int32
+
+	// ColumnTable used to select a statefulset.
+	statefulSetTable columntable.Model
+
+	// Loading indicator
+	loading bool
+
+	// If the View has been initialized or not.
+	initialized bool
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	// Kubernetes client.
+	k8sClient K8sClient
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(k8sClient K8sClient, contextClient ContextClient, windowWidth, windowHeight int) View {
+	return View{
+		k8sClient:     k8sClient,
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          newKeyMap(),
+		loading:       true,
+	}
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	if msg.IsWindowResize() {
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+
+		var cmd tea.Cmd
+		if v.initialized {
+			v.statefulSetTable, cmd = v.statefulSetTable.Update(columntable.UpdateHeight{Height: v.tableHeight()})
+		}
+
+		return c, v, cmd
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp {
+		v.showFullHelp = true
+		return c, v, nil
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Quit) {
+		return c, v, kubeui.Exit()
+	}
+
+	// Key presses are handled by the input or dialog while they are displayed.
+	if msg.IsKeyMsg() && v.activeInput != nil {
+		input, cmd := v.activeInput.Update(msg.TeaMsg)
+		v.activeInput = &input
+		return c, v, cmd
+	}
+
+	if msg.IsKeyMsg() && v.activeDialog != nil {
+		dialog, cmd := v.activeDialog.Update(msg.TeaMsg)
+		v.activeDialog = &dialog
+		return c, v, cmd
+	}
+
+	if msg.MatchesKeyBindings(v.keys.ExitView) {
+		return c, v, kubeui.PopView(false)
+	}
+
+	// The view is returned to if it is already in the navigation stack.
+	if msg.MatchesKeyBindings(v.keys.ShowDeployments) {
+		return c, v, kubeui.PushView("deployment_selection", false)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Refresh) {
+		return c, v, v.Init(c)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Scale) && v.initialized {
+		name, ok := v.statefulSetTable.HighlightedRow()
+		if !ok {
+			return c, v, nil
+		}
+
+		replicas := int32(1)
+		if statefulSet, err := slices.Find(v.statefulSets, func(d appsv1.StatefulSet) bool { return d.Name == name }); err == nil {
+			replicas = k8s.DesiredReplicas(statefulSet.Spec.Replicas)
+		}
+
+		input := numberinput.New(name, fmt.Sprintf("Number of replicas for %s", name), int(replicas), numberinput.Options{})
+		v.activeInput = &input
+		return c, v, input.Init()
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Restart) && v.initialized {
+		name, ok := v.statefulSetTable.HighlightedRow()
+		if !ok {
+			return c, v, nil
+		}
+
+		dialog := confirm.New([]confirm.Button{{Desc: "Yes", Id: name}, {Desc: "No", Id: name}}, fmt.Sprintf("Are you sure you want to restart %s", name))
+		v.activeDialog = &dialog
+		v.dialogAction = restartAction
+		return c, v, nil
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+	case k8smsg.ListStatefulSetsMsg:
+		v.statefulSets = t.StatefulSetList.Items
+		statefulSetColumns, statefulSetRows := k8stable.StatefulSetColumnsAndRows(v.statefulSets)
+		var cmd tea.Cmd
+
+		// The first time we receive a list of statefulSets then we create a new statefulSetTable.
+		// Otherwise we just update it.
+		if !v.initialized {
+			v.initialized = true
+			v.statefulSetTable = columntable.New(statefulSetColumns, statefulSetRows, columntable.PageSize(v.tableHeight()), "", false, columntable.Options{SingularItemName: "statefulset", StartInSearchMode: true})
+		} else {
+			v.statefulSetTable, cmd = v.statefulSetTable.Update(columntable.UpdateRowsAndColumns{Rows: statefulSetRows, Columns: statefulSetColumns})
+		}
+
+		v.loading = false
+
+		return c, v, cmd
+
+	// When the user has chosen the number of replicas we ask for a confirmation before scaling.
+	case numberinput.Submission:
+		v.activeInput = nil
+		dialog := confirm.New([]confirm.Button{{Desc: "Yes", Id: t.Id}, {Desc: "No", Id: t.Id}}, fmt.Sprintf("Are you sure you want to scale %s to %d replicas", t.Id, t.Value))
+		v.activeDialog = &dialog
+		v.dialogAction = scaleAction
+		v.replicas = int32(t.Value)
+		return c, v, nil
+
+	case numberinput.Cancellation:
+		v.activeInput = nil
+		return c, v, nil
+
+	case confirm.ButtonPress:
+		v.activeDialog = nil
+
+		if t.Pressed.Desc != "Yes" {
+			return c, v, nil
+		}
+
+		dialogAction := v.dialogAction
+		replicas := v.replicas
+
+		return c, v, func() tea.Msg {
+			var err error
+
+			switch dialogAction {
+			case scaleAction:
+				_, err = v.k8sClient.ScaleStatefulSet(c.Namespace, t.Pressed.Id, replicas)
+			case restartAction:
+				_, err = v.k8sClient.RestartStatefulSet(c.Namespace, t.Pressed.Id)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			statefulSetList, err := v.k8sClient.ListStatefulSets(c.Namespace)
+			if err != nil {
+				return err
+			}
+			return k8smsg.NewListStatefulSetsMsg(statefulSetList)
+		}
+	}
+
+	var cmd tea.Cmd
+	if v.initialized {
+		v.statefulSetTable, cmd = v.statefulSetTable.Update(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+// tableHeight returns the number of lines available to the table, which is displayed below the short help, an empty line and the status bar.
+func (v View) tableHeight() int {
+	// The height is not known before the first resize.
+	if v.windowHeight == 0 {
+		return 0
+	}
+
+	return v.windowHeight - 4
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.ShowDeployments, v.keys.Refresh, v.keys.Scale, v.keys.Restart}))
+	builder.WriteString("\n\n")
+
+	headerLine := header.New(v.windowWidth-1, v.contextClient, header.Location{Namespace: c.Namespace, Path: []string{"statefulsets"}})
+	builder.WriteString(headerLine + "\n")
+
+	if v.activeInput != nil {
+		builder.WriteString(v.activeInput.View())
+		return builder.String()
+	}
+
+	if v.activeDialog != nil {
+		builder.WriteString(v.activeDialog.View())
+		return builder.String()
+	}
+
+	if v.loading {
+		return "Loading..."
+	} else if len(v.statefulSets) == 0 {
+		builder.WriteString(fmt.Sprintf("No statefulsets found in namespace %s", c.Namespace))
+	} else {
+		builder.WriteString(v.statefulSetTable.View())
+	}
+
+	return builder.String()
+}
+
+// CapturesText reports whether the view captures typed text, which it does while the statefulsets are searched or a number of replicas is entered.
+func (v View) CapturesText() bool {
+	return v.activeInput != nil || v.statefulSetTable.Searching()
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return func() tea.Msg {
+		statefulSetList, err := v.k8sClient.ListStatefulSets(c.Namespace)
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewListStatefulSetsMsg(statefulSetList)
+	}
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	return nil
+}
@@ -0,0 +1,205 @@
+package navigator
+
+import (
+	"fmt"
+
+	"kubeui/internal/app/common"
+	"kubeui/internal/app/configs/views/configmapinfo"
+	"kubeui/internal/app/configs/views/configmapselection"
+	"kubeui/internal/app/configs/views/secretinfo"
+	"kubeui/internal/app/configs/views/secretselection"
+	"kubeui/internal/app/cxs/views/contextselection"
+	"kubeui/internal/app/deployments/views/deploymentinfo"
+	"kubeui/internal/app/deployments/views/deploymentpods"
+	"kubeui/internal/app/deployments/views/deploymentselection"
+	"kubeui/internal/app/deployments/views/statefulsetselection"
+	"kubeui/internal/app/nodes/views/nodedrain"
+	"kubeui/internal/app/nodes/views/nodeinfo"
+	"kubeui/internal/app/nodes/views/nodepods"
+	"kubeui/internal/app/nodes/views/nodeselection"
+	"kubeui/internal/app/pods/views/errorinfo"
+	"kubeui/internal/app/pods/views/namespaceselection"
+	"kubeui/internal/app/pods/views/podinfo"
+	"kubeui/internal/app/pods/views/podselection"
+	"kubeui/internal/app/pods/views/portforwards"
+	"kubeui/internal/app/resources/views/resourceinfo"
+	"kubeui/internal/app/resources/views/resourceselection"
+	"kubeui/internal/app/resources/views/resourcetypeselection"
+	"kubeui/internal/app/services/views/ingressselection"
+	"kubeui/internal/app/services/views/serviceinfo"
+	"kubeui/internal/app/services/views/servicepods"
+	"kubeui/internal/app/services/views/serviceselection"
+	"kubeui/internal/pkg/k8s"
+	"kubeui/internal/pkg/k8s/k8scontext"
+	"kubeui/internal/pkg/k8s/portforward"
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Model defines the base Model of the application.
+// It combines the views of all other programs, and the user moves between them using a command palette.
+type Model struct {
+	windowHeight int
+	windowWidth  int
+
+	// The views and the connection to the cluster they use, which are replaced when switching context.
+	connection common.Connection
+}
+
+// NewModel creates a new model.
+func NewModel(contextClient k8scontext.Client, k8sService k8s.Service, newService k8s.ServiceFactory, portForwards *portforward.Manager) *Model {
+	connection := common.NewConnection(contextClient, k8sService, newService, portForwards, func(service k8s.Service) kubeui.Root {
+		return newRoot(program{contextClient: contextClient, k8sService: service, portForwards: portForwards})
+	})
+
+	return &Model{connection: connection}
+}
+
+// Update updates the model and optionally returns a command.
+// It is part of the bubbletea model interface.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Results of requests made by the views of a replaced root are ignored.
+	msg, ok := m.connection.Root.Unwrap(msg)
+	if !ok {
+		return m, nil
+	}
+
+	switch msgT := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.windowHeight = msgT.Height
+		m.windowWidth = msgT.Width
+
+	case k8smsg.ContextSwitchedMsg:
+		return m, m.connection.Connect(msgT.Name)
+
+	case common.ConnectionMsg:
+		var cmd tea.Cmd
+		m.connection, cmd = m.connection.Update(msgT, m.windowWidth, m.windowHeight)
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.connection.Root, cmd = m.connection.Root.UpdateRoot(msg)
+
+	return m, cmd
+}
+
+// program contains what is needed to create the views of the program.
+type program struct {
+	contextClient k8scontext.Client
+	k8sService    k8s.Service
+	portForwards  *portforward.Manager
+}
+
+// newRoot creates a kubeui.Root displaying the views of the program.
+func newRoot(p program) kubeui.Root {
+	return kubeui.NewRoot(kubeui.RootOptions{
+		Context: kubeui.Context{
+			Namespace: "default",
+		},
+		Start:    p.start,
+		NewView:  p.newView,
+		Commands: common.AllCommands,
+	})
+}
+
+// start uses the namespace of the current context, the user selects a namespace first if the context has no namespace.
+func (p program) start(c kubeui.Context) (kubeui.Context, tea.Cmd) {
+	currentContext, ok := p.contextClient.CurrentApiContext()
+
+	if !ok {
+		return c, kubeui.Error(fmt.Errorf("invalid context"))
+	}
+
+	if currentContext.Namespace != "" {
+		c.Namespace = currentContext.Namespace
+	}
+
+	if c.Namespace == "default" {
+		return c, kubeui.PushView("namespace_selection", true)
+	}
+
+	return c, kubeui.PushView("pod_selection", true)
+}
+
+// newView creates the view with the given id.
+func (p program) newView(id string, config kubeui.ViewConfig) kubeui.View {
+	switch id {
+	case "pod_selection":
+		return podselection.New(p.k8sService, p.contextClient, p.portForwards, config.WindowWidth, config.WindowHeight)
+	case "pod_info":
+		return podinfo.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "port_forwards":
+		return portforwards.New(p.portForwards, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "deployment_selection":
+		return deploymentselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "deployment_info":
+		return deploymentinfo.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "deployment_pods":
+		return deploymentpods.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "statefulset_selection":
+		return statefulsetselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "service_selection":
+		return serviceselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "service_info":
+		return serviceinfo.New(p.k8sService, p.contextClient, p.portForwards, config.WindowWidth, config.WindowHeight)
+	case "service_pods":
+		return servicepods.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "ingress_selection":
+		return ingressselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "configmap_selection":
+		return configmapselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "configmap_info":
+		return configmapinfo.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "secret_selection":
+		return secretselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "secret_info":
+		return secretinfo.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "node_selection":
+		return nodeselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "node_info":
+		return nodeinfo.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "node_drain":
+		return nodedrain.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "node_pods":
+		return nodepods.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "resource_type_selection":
+		return resourcetypeselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "resource_selection":
+		return resourceselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "resource_info":
+		return resourceinfo.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "context_selection":
+		return contextselection.New(p.contextClient, config.WindowWidth, config.WindowHeight)
+	case kubeui.ErrorViewId:
+		return errorinfo.New(config.Err.Error(), kubeui.ErrorDetails(config.Err), config.WindowWidth, config.WindowHeight)
+	}
+
+	returnViewId := namespaceReturnViewId(config.ParentId)
+	return namespaceselection.New(p.k8sService, p.contextClient, returnViewId, returnViewId == "pod_selection", config.WindowWidth, config.WindowHeight)
+}
+
+// namespaceReturnViewId returns the id of the view to return to from the namespace selection, which is the view it was opened from.
+// Views that do not list resources in a namespace return to the pod list instead.
+func namespaceReturnViewId(parentId string) string {
+	switch parentId {
+	case "", "context_selection", kubeui.ErrorViewId:
+		return "pod_selection"
+	}
+
+	return parentId
+}
+
+// View returns the view for the model.
+// It is part of the bubbletea model interface.
+func (m Model) View() string {
+	return m.connection.Root.View()
+}
+
+// Init returns an initial command.
+// It is part of the bubbletea model interface.
+func (m Model) Init() tea.Cmd {
+	return m.connection.Root.Init()
+}
@@ -0,0 +1,74 @@
+package nodes
+
+import (
+	"fmt"
+
+	"kubeui/internal/app/common"
+	"kubeui/internal/app/nodes/views/nodedrain"
+	"kubeui/internal/app/nodes/views/nodeinfo"
+	"kubeui/internal/app/nodes/views/nodepods"
+	"kubeui/internal/app/nodes/views/nodeselection"
+	"kubeui/internal/app/pods/views/errorinfo"
+	"kubeui/internal/app/pods/views/podinfo"
+	"kubeui/internal/pkg/k8s"
+	"kubeui/internal/pkg/k8s/k8scontext"
+	"kubeui/internal/pkg/kubeui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// program contains what is needed to create the views of the program.
+type program struct {
+	contextClient k8scontext.Client
+	k8sService    k8s.Service
+}
+
+// NewModel creates a new model.
+func NewModel(contextClient k8scontext.Client, k8sService k8s.Service) kubeui.Root {
+	p := program{contextClient: contextClient, k8sService: k8sService}
+
+	return kubeui.NewRoot(kubeui.RootOptions{
+		Context: kubeui.Context{
+			Namespace: "default",
+		},
+		Start:    p.start,
+		NewView:  p.newView,
+		Commands: common.Commands("node_selection"),
+	})
+}
+
+// start uses the namespace of the current context.
+func (p program) start(c kubeui.Context) (kubeui.Context, tea.Cmd) {
+	currentContext, ok := p.contextClient.CurrentApiContext()
+
+	if !ok {
+		return c, kubeui.Error(fmt.Errorf("invalid context"))
+	}
+
+	// Nodes are not namespaced, the namespace is only used when inspecting the pods on a node.
+	if currentContext.Namespace != "" {
+		c.Namespace = currentContext.Namespace
+	}
+
+	return c, kubeui.PushView("node_selection", true)
+}
+
+// newView creates the view with the given id.
+func (p program) newView(id string, config kubeui.ViewConfig) kubeui.View {
+	switch id {
+	case "node_selection":
+		return nodeselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "node_info":
+		return nodeinfo.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "node_drain":
+		return nodedrain.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "node_pods":
+		return nodepods.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "pod_info":
+		return podinfo.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case kubeui.ErrorViewId:
+		return errorinfo.New(config.Err.Error(), kubeui.ErrorDetails(config.Err), config.WindowWidth, config.WindowHeight)
+	}
+
+	return nodeselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+}
@@ -0,0 +1,402 @@
+package nodedrain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"kubeui/internal/pkg/component/confirm"
+	"kubeui/internal/pkg/component/numberinput"
+	"kubeui/internal/pkg/k8s/nodes"
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/styles"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+	"kubeui/internal/pkg/ui/table"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/life4/genesis/slices"
+	"k8s.io/utils/integer"
+)
+
+// keyMap defines the keys that are handled by this view.
+type keyMap struct {
+	kubeui.GlobalKeyMap
+	Cancel key.Binding
+}
+
+// newKeyMap defines the actual key bindings and creates a keyMap.
+func newKeyMap() *keyMap {
+	return &keyMap{
+		GlobalKeyMap: kubeui.NewGlobalKeyMap(),
+		Cancel: key.NewBinding(
+			key.WithKeys("ctrl+x"),
+			key.WithHelp("ctrl+x", "Cancel drain"),
+		),
+	}
+}
+
+func (v View) fullHelp() [][]key.Binding {
+	viewPortKeys := viewport.DefaultKeyMap()
+
+	return [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.ExitView, v.keys.Cancel},
+		{viewPortKeys.Up, viewPortKeys.Down, viewPortKeys.PageUp, viewPortKeys.PageDown},
+	}
+}
+
+// K8sClient represents the interface towards kubernetes needed by this view.
+type K8sClient interface {
+	DrainNode(ctx context.Context, name string, options nodes.DrainOptions) (<-chan nodes.DrainEvent, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// Ids of the buttons of the dialog used to confirm the drain.
+const (
+	drainButton             = "drain"
+	drainEmptyDirDataButton = "drain_empty_dir_data"
+	cancelButton            = "cancel"
+)
+
+// View is used to choose the options of a drain and to display the progress of the drain.
+type View struct {
+	keys *keyMap
+
+	windowWidth  int
+	windowHeight int
+
+	// Input used to choose the grace period, displayed before the drain is started.
+	activeInput *numberinput.Model
+	// Dialog used to confirm the drain.
+	activeDialog *confirm.Model
+	// The grace period to use if the dialog is confirmed, 0 uses the grace period of each pod.
+	gracePeriodSeconds int64
+
+	// Indicates whether the drain has been started.
+	started bool
+	// Indicates whether all pods have been handled or the drain has been cancelled.
+	finished bool
+	// Indicates whether the drain was cancelled by the user.
+	cancelled bool
+
+	// The latest status of each pod, in the order the pods were first reported.
+	pods []nodes.DrainEvent
+	// Events of the running drain.
+	events <-chan nodes.DrainEvent
+
+	// Viewport for scrolling the pods.
+	podsViewPort viewport.Model
+
+	// Context of the view, cancelled when the view is destroyed or the drain is cancelled.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	// Kubernetes client.
+	k8sClient K8sClient
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(k8sClient K8sClient, contextClient ContextClient, windowWidth, windowHeight int) View {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	input := numberinput.New("grace_period", "Grace period in seconds for each pod, 0 uses the grace period of the pod", 0, numberinput.Options{})
+
+	return View{
+		k8sClient:     k8sClient,
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          newKeyMap(),
+		activeInput:   &input,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	if msg.IsWindowResize() {
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+		v = v.updateViewPort(c)
+
+		return c, v, nil
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp {
+		v.showFullHelp = true
+		return c, v, nil
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Quit) {
+		return c, v, kubeui.Exit()
+	}
+
+	// Key presses are handled by the input or dialog while they are displayed.
+	if msg.IsKeyMsg() && v.activeInput != nil {
+		input, cmd := v.activeInput.Update(msg.TeaMsg)
+		v.activeInput = &input
+		return c, v, cmd
+	}
+
+	if msg.IsKeyMsg() && v.activeDialog != nil {
+		dialog, cmd := v.activeDialog.Update(msg.TeaMsg)
+		v.activeDialog = &dialog
+		return c, v, cmd
+	}
+
+	// Leaving the view destroys it, which cancels a drain that is still running.
+	// The node list is reinitialized since the drain cordons the node.
+	if msg.MatchesKeyBindings(v.keys.ExitView) {
+		return c, v, kubeui.PopView(v.started)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Cancel) && v.started && !v.finished {
+		v.cancelled = true
+		v.cancel()
+		return c, v, nil
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+	// When the user has chosen the grace period we ask for a confirmation before draining.
+	case numberinput.Submission:
+		v.activeInput = nil
+		v.gracePeriodSeconds = int64(t.Value)
+
+		dialog := confirm.New([]confirm.Button{
+			{Desc: "Yes", Id: drainButton},
+			{Desc: "Yes, delete emptyDir data", Id: drainEmptyDirDataButton},
+			{Desc: "No", Id: cancelButton},
+		}, fmt.Sprintf("Are you sure you want to drain %s", c.SelectedNode))
+		v.activeDialog = &dialog
+		return c, v, nil
+
+	case numberinput.Cancellation:
+		return c, v, kubeui.PopView(false)
+
+	case confirm.ButtonPress:
+		v.activeDialog = nil
+
+		if t.Pressed.Id == cancelButton {
+			return c, v, kubeui.PopView(false)
+		}
+
+		options := nodes.DrainOptions{DeleteEmptyDirData: t.Pressed.Id == drainEmptyDirDataButton}
+		if v.gracePeriodSeconds > 0 {
+			gracePeriodSeconds := v.gracePeriodSeconds
+			options.GracePeriodSeconds = &gracePeriodSeconds
+		}
+
+		v.started = true
+		v = v.updateViewPort(c)
+
+		return c, v, v.startDrain(c.SelectedNode, options)
+
+	case k8smsg.DrainStreamMsg:
+		if !v.started || v.events != nil {
+			return c, v, nil
+		}
+
+		v.events = t.Events
+		return c, v, k8smsg.NextDrainEvent(t.Events)
+
+	case k8smsg.DrainEventMsg:
+		if t.Events != v.events {
+			return c, v, nil
+		}
+
+		v.pods = updatePods(v.pods, t.Event)
+		v = v.updateViewPort(c)
+
+		return c, v, k8smsg.NextDrainEvent(t.Events)
+
+	case k8smsg.DrainClosedMsg:
+		if t.Events != v.events {
+			return c, v, nil
+		}
+
+		v.finished = true
+		v = v.updateViewPort(c)
+
+		return c, v, nil
+	}
+
+	var cmd tea.Cmd
+	if v.started {
+		v.podsViewPort, cmd = v.podsViewPort.Update(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+// startDrain creates a command that starts draining a node.
+func (v View) startDrain(name string, options nodes.DrainOptions) tea.Cmd {
+	ctx := v.ctx
+
+	return func() tea.Msg {
+		events, err := v.k8sClient.DrainNode(ctx, name, options)
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewDrainStreamMsg(events)
+	}
+}
+
+// updatePods replaces the status of the pod of the event, or adds the pod if it has not been reported before.
+func updatePods(pods []nodes.DrainEvent, event nodes.DrainEvent) []nodes.DrainEvent {
+	for i, pod := range pods {
+		if pod.Namespace == event.Namespace && pod.Name == event.Name {
+			pods[i] = event
+			return pods
+		}
+	}
+
+	return append(pods, event)
+}
+
+// updateViewPort resizes the viewport of the pods to fill the space below the header and renders the pods.
+func (v View) updateViewPort(c kubeui.Context) View {
+	if !v.started {
+		return v
+	}
+
+	v.podsViewPort.Width = v.windowWidth
+	v.podsViewPort.Height = integer.IntMax(v.windowHeight-lipgloss.Height(v.headerView(c)), 1)
+	v.podsViewPort.SetContent(table.RowsToString(drainColumnsAndRows(v.windowWidth, v.pods)))
+
+	return v
+}
+
+// drainColumnsAndRows creates the neccessary columns and rows in order to display the status of each pod of a drain.
+func drainColumnsAndRows(maxWidth int, pods []nodes.DrainEvent) ([]table.DataColumn, []table.DataRow) {
+	columns := []table.DataColumn{
+		{Desc: "Namespace", Width: 11},
+		{Desc: "Name", Width: 6},
+		{Desc: "Status", Width: 14},
+		{Desc: "Message", Width: 30},
+	}
+
+	rows := slices.Map(pods, func(p nodes.DrainEvent) table.DataRow {
+		columns[0].Width = integer.IntMax(columns[0].Width, len(p.Namespace)+2)
+		columns[1].Width = integer.IntMax(columns[1].Width, len(p.Name)+2)
+
+		remainingWidth := maxWidth - columns[0].Width - columns[1].Width - columns[2].Width
+		columns[3].Width = integer.IntMax(integer.IntMax(remainingWidth-1, len(p.Message)), 30)
+
+		status := string(p.Status)
+		if p.Status == nodes.Failed {
+			status = styles.ErrorMessage.Render(status)
+		}
+
+		return table.DataRow{
+			Values: []string{p.Namespace, p.Name, status, p.Message},
+		}
+	})
+
+	return columns, rows
+}
+
+// summary counts the pods in each status, as displayed above the pods.
+func (v View) summary() string {
+	counts := map[nodes.DrainStatus]int{}
+	for _, pod := range v.pods {
+		counts[pod.Status]++
+	}
+
+	state := "Draining"
+	switch {
+	case v.finished && v.cancelled:
+		state = "Cancelled"
+	case v.finished:
+		state = "Finished"
+	case v.cancelled:
+		state = "Cancelling"
+	}
+
+	return fmt.Sprintf("%s  Evicted: %d  Waiting: %d  Blocked by PDB: %d  Failed: %d  Skipped: %d",
+		state, counts[nodes.Evicted], counts[nodes.Waiting], counts[nodes.BlockedByPDB], counts[nodes.Failed], counts[nodes.Skipped])
+}
+
+// headerView renders everything above the pods.
+func (v View) headerView(c kubeui.Context) string {
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.ExitView, v.keys.Cancel}))
+	builder.WriteString("\n\n")
+
+	headerLine := header.New(v.windowWidth-1, v.contextClient, header.Location{Path: []string{"nodes", c.SelectedNode, "drain"}})
+	builder.WriteString(headerLine + "\n")
+
+	if v.started {
+		columns, _ := drainColumnsAndRows(v.windowWidth, v.pods)
+		builder.WriteString(v.summary() + "\n\n")
+		builder.WriteString(table.ColumnsToString(columns) + "\n")
+	}
+
+	return builder.String()
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(v.headerView(c))
+
+	switch {
+	case v.activeInput != nil:
+		builder.WriteString(v.activeInput.View())
+	case v.activeDialog != nil:
+		builder.WriteString(v.activeDialog.View())
+	default:
+		builder.WriteString(v.podsViewPort.View())
+	}
+
+	return builder.String()
+}
+
+// CapturesText reports whether the view captures typed text, which it does while the grace period is entered.
+func (v View) CapturesText() bool {
+	return v.activeInput != nil
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return v.activeInput.Init()
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+// It cancels the drain if it is still running.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	v.cancel()
+	return nil
+}
@@ -0,0 +1,112 @@
+package nodeinfo
+
+import (
+	"time"
+
+	"kubeui/internal/pkg/k8s"
+	"kubeui/internal/pkg/ui/table"
+
+	"github.com/life4/genesis/slices"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/utils/integer"
+)
+
+// nodeStatusColumnsAndRows creates the neccessary columns and row in order to display node status information.
+func nodeStatusColumnsAndRows(node v1.Node) ([]table.DataColumn, table.DataRow) {
+	nodeColumns := []table.DataColumn{
+		{Desc: "Name", Width: 6},
+		{Desc: "Status", Width: 8},
+		{Desc: "Roles", Width: 7},
+		{Desc: "Version", Width: 9},
+		{Desc: "Internal IP", Width: 13},
+		{Desc: "OS Image", Width: 10},
+		{Desc: "Age", Width: 3},
+	}
+
+	nodeFormat := k8s.NewListNodeFormat(node, 0, time.Now())
+	internalIP := nodeAddress(node, v1.NodeInternalIP)
+	osImage := node.Status.NodeInfo.OSImage
+
+	nodeColumns[0].Width = integer.IntMax(nodeColumns[0].Width, len(nodeFormat.Name)+2)
+	nodeColumns[1].Width = integer.IntMax(nodeColumns[1].Width, len(nodeFormat.Status)+2)
+	nodeColumns[2].Width = integer.IntMax(nodeColumns[2].Width, len(nodeFormat.Roles)+2)
+	nodeColumns[3].Width = integer.IntMax(nodeColumns[3].Width, len(nodeFormat.Version)+2)
+	nodeColumns[4].Width = integer.IntMax(nodeColumns[4].Width, len(internalIP)+2)
+	nodeColumns[5].Width = integer.IntMax(nodeColumns[5].Width, len(osImage)+2)
+	nodeColumns[6].Width = integer.IntMax(nodeColumns[6].Width, len(nodeFormat.Age))
+
+	nodeRow := table.DataRow{
+		Values: []string{nodeFormat.Name, nodeFormat.Status, nodeFormat.Roles, nodeFormat.Version, internalIP, osImage, nodeFormat.Age},
+	}
+
+	return nodeColumns, nodeRow
+}
+
+// nodeAddress returns the first address of the given type, or <none> if the node has no such address.
+func nodeAddress(node v1.Node, addressType v1.NodeAddressType) string {
+	for _, address := range node.Status.Addresses {
+		if address.Type == addressType {
+			return address.Address
+		}
+	}
+
+	return "<none>"
+}
+
+// conditionColumnsAndRows creates the neccessary columns and rows in order to display the conditions of a node.
+func conditionColumnsAndRows(maxWidth int, conditions []v1.NodeCondition) ([]table.DataColumn, []table.DataRow) {
+	conditionColumns := []table.DataColumn{
+		{Desc: "Type", Width: 6},
+		{Desc: "Status", Width: 8},
+		{Desc: "Reason", Width: 8},
+		{Desc: "Last Transition", Width: 17},
+		{Desc: "Message", Width: 30},
+	}
+
+	now := time.Now()
+
+	conditionRows := slices.Map(conditions, func(c v1.NodeCondition) table.DataRow {
+		lastTransition := duration.HumanDuration(now.Sub(c.LastTransitionTime.Time))
+
+		conditionColumns[0].Width = integer.IntMax(conditionColumns[0].Width, len(c.Type)+2)
+		conditionColumns[1].Width = integer.IntMax(conditionColumns[1].Width, len(c.Status)+2)
+		conditionColumns[2].Width = integer.IntMax(conditionColumns[2].Width, len(c.Reason)+2)
+		conditionColumns[3].Width = integer.IntMax(conditionColumns[3].Width, len(lastTransition)+2)
+
+		remainingWidth := maxWidth - slices.Reduce(conditionColumns[0:4], 0, func(c table.DataColumn, acc int) int {
+			return acc + c.Width
+		})
+
+		conditionColumns[4].Width = integer.IntMax(integer.IntMax(remainingWidth-1, len(c.Message)), 30)
+
+		return table.DataRow{
+			Values: []string{string(c.Type), string(c.Status), c.Reason, lastTransition, c.Message},
+		}
+	})
+
+	return conditionColumns, conditionRows
+}
+
+// taintColumnsAndRows creates the neccessary columns and rows in order to display the taints of a node.
+func taintColumnsAndRows(maxWidth int, taints []v1.Taint) ([]table.DataColumn, []table.DataRow) {
+	taintColumns := []table.DataColumn{
+		{Desc: "Key", Width: 5},
+		{Desc: "Value", Width: 7},
+		{Desc: "Effect", Width: 6},
+	}
+
+	taintRows := slices.Map(taints, func(t v1.Taint) table.DataRow {
+		taintColumns[0].Width = integer.IntMax(taintColumns[0].Width, len(t.Key)+2)
+		taintColumns[1].Width = integer.IntMax(taintColumns[1].Width, len(t.Value)+2)
+
+		remainingWidth := maxWidth - taintColumns[0].Width - taintColumns[1].Width
+		taintColumns[2].Width = integer.IntMax(remainingWidth-1, len(t.Effect))
+
+		return table.DataRow{
+			Values: []string{t.Key, t.Value, string(t.Effect)},
+		}
+	})
+
+	return taintColumns, taintRows
+}
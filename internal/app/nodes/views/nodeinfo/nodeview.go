@@ -0,0 +1,413 @@
+package nodeinfo
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeui/internal/pkg/k8s/nodes"
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+	"kubeui/internal/pkg/ui/k8stable"
+	"kubeui/internal/pkg/ui/selection"
+	"kubeui/internal/pkg/ui/table"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/utils/integer"
+)
+
+// keyMap defines the keys that are handled by this view.
+type keyMap struct {
+	kubeui.GlobalKeyMap
+	Left     key.Binding
+	Right    key.Binding
+	ShowPods key.Binding
+}
+
+// newKeyMap defines the actual key bindings and creates a keyMap.
+func newKeyMap() *keyMap {
+	return &keyMap{
+		GlobalKeyMap: kubeui.NewGlobalKeyMap(),
+		Left: key.NewBinding(
+			key.WithKeys("left"),
+			key.WithHelp("left", "Move cursor left one position"),
+		),
+		Right: key.NewBinding(
+			key.WithKeys("right"),
+			key.WithHelp("right", "Move cursor right one position"),
+		),
+		ShowPods: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("ctrl+p", "Show pods"),
+		),
+	}
+}
+
+func (v View) fullHelp() [][]key.Binding {
+	bindings := [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView, v.keys.ShowPods},
+	}
+
+	viewPortKeys := viewport.DefaultKeyMap()
+
+	bindings = append(bindings, []key.Binding{
+		v.keys.Left,
+		v.keys.Right,
+		viewPortKeys.Up,
+		viewPortKeys.Down,
+		viewPortKeys.PageUp,
+		viewPortKeys.PageDown,
+		viewPortKeys.HalfPageUp,
+		viewPortKeys.HalfPageDown,
+	})
+
+	return bindings
+}
+
+// K8sService represents the interface towards kubernetes needed by this view.
+type K8sService interface {
+	GetNode(name string) (*nodes.Node, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// View displays node information.
+type View struct {
+	keys *keyMap
+
+	tab  tab
+	tabs []string
+
+	// Indicates whether the node has been loaded or not.
+	initialized bool
+
+	// Viewports for scrolling content
+	conditionsViewPort viewport.Model
+	taintsViewPort     viewport.Model
+	labelsViewPort     viewport.Model
+	eventsViewPort     viewport.Model
+
+	windowWidth  int
+	windowHeight int
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	node *nodes.Node
+
+	// Kubernetes client.
+	k8sClient K8sService
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(k8sClient K8sService, contextClient ContextClient, windowWidth, windowHeight int) View {
+	return View{
+		k8sClient:     k8sClient,
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          newKeyMap(),
+		tabs:          []string{STATUS.String(), CONDITIONS.String(), TAINTS.String(), LABELS.String(), EVENTS.String()},
+	}
+}
+
+// tab defines the different tabs of the component.
+type tab int
+
+const (
+	// STATUS is used to display status information about the node.
+	STATUS tab = iota
+	// CONDITIONS is used to display the conditions of the node.
+	CONDITIONS
+	// TAINTS is used to display the taints of the node.
+	TAINTS
+	// LABELS is used to display the labels set for the node.
+	LABELS
+	// EVENTS is used to display the latest events for the node.
+	EVENTS
+)
+
+// String implements the stringer interface for tab.
+func (t tab) String() string {
+	switch t {
+	case STATUS:
+		return "STATUS"
+	case CONDITIONS:
+		return "CONDITIONS"
+	case TAINTS:
+		return "TAINTS"
+	case LABELS:
+		return "LABELS"
+	case EVENTS:
+		return "EVENTS"
+	}
+	return "UNKNOWN"
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	// Keys
+	switch {
+
+	case msg.IsWindowResize():
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+		v = v.updateViewportsAfterResize()
+		return c, v, nil
+
+	case msg.MatchesKeyBindings(v.keys.Quit):
+		return c, v, kubeui.Exit()
+
+	case msg.MatchesKeyBindings(v.keys.ExitView):
+		return c, v, kubeui.PopView(false)
+
+	case msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp:
+		v.showFullHelp = true
+		return c, v, nil
+
+	case msg.MatchesKeyBindings(v.keys.ShowPods):
+		return c, v, kubeui.PushView("node_pods", true)
+
+	case msg.MatchesKeyBindings(v.keys.Left):
+		v = v.moveTabLeft()
+		return c, v, nil
+	case msg.MatchesKeyBindings(v.keys.Right):
+		v = v.moveTabRight()
+		return c, v, nil
+	case msg.MatchesKeyBindings(v.keys.Refresh):
+		return c, v, v.Init(c)
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+	case k8smsg.GetNodeMsg:
+
+		if !v.initialized {
+			v.initialized = true
+		}
+
+		v.node = t.Node
+		v = v.updateViewportsAfterResize()
+
+		return c, v, nil
+	}
+
+	// Update viewports.
+	var cmd tea.Cmd
+	if v.initialized {
+		v, cmd = v.updateViewports(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+func (v View) updateViewportsAfterResize() View {
+	if v.node == nil {
+		return v
+	}
+
+	v.conditionsViewPort.Height = v.windowHeight - (lipgloss.Height(v.headerView(v.windowWidth, CONDITIONS)) + lipgloss.Height(footerView(v.windowWidth, v.conditionsViewPort)))
+	v.conditionsViewPort.Width = v.windowWidth
+
+	v.taintsViewPort.Height = v.windowHeight - (lipgloss.Height(v.headerView(v.windowWidth, TAINTS)) + lipgloss.Height(footerView(v.windowWidth, v.taintsViewPort)))
+	v.taintsViewPort.Width = v.windowWidth
+
+	v.labelsViewPort.Height = v.windowHeight - (lipgloss.Height(v.headerView(v.windowWidth, LABELS)) + lipgloss.Height(footerView(v.windowWidth, v.labelsViewPort)))
+	v.labelsViewPort.Width = v.windowWidth
+
+	v.eventsViewPort.Height = v.windowHeight - (lipgloss.Height(v.headerView(v.windowWidth, EVENTS)) + lipgloss.Height(footerView(v.windowWidth, v.eventsViewPort)))
+	v.eventsViewPort.Width = v.windowWidth
+
+	if v.conditionsViewPort.Height > 0 {
+		v.conditionsViewPort.SetContent(table.RowsToString(conditionColumnsAndRows(v.windowWidth, v.node.Node.Status.Conditions)))
+	}
+
+	if v.taintsViewPort.Height > 0 {
+		v.taintsViewPort.SetContent(table.RowsToString(taintColumnsAndRows(v.windowWidth, v.node.Node.Spec.Taints)))
+	}
+
+	if v.labelsViewPort.Height > 0 {
+		v.labelsViewPort.SetContent(table.RowsToString(table.StringMapColumnsAndRows(v.windowWidth, "Key", "Value", v.node.Node.Labels)))
+	}
+
+	if v.eventsViewPort.Height > 0 {
+		v.eventsViewPort.SetContent(table.RowsToString(k8stable.EventColumnsAndRows(v.windowWidth, v.node.Events)))
+	}
+
+	return v
+}
+
+// updateViewports updates the currently active viewport.
+func (v View) updateViewports(msg tea.Msg) (View, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch v.tab {
+	case CONDITIONS:
+		v.conditionsViewPort, cmd = v.conditionsViewPort.Update(msg)
+	case TAINTS:
+		v.taintsViewPort, cmd = v.taintsViewPort.Update(msg)
+	case LABELS:
+		v.labelsViewPort, cmd = v.labelsViewPort.Update(msg)
+	case EVENTS:
+		v.eventsViewPort, cmd = v.eventsViewPort.Update(msg)
+	}
+
+	return v, cmd
+}
+
+func (v View) moveTabLeft() View {
+	if v.tab > 0 {
+		v.tab--
+	} else {
+		v.tab = tab(len(v.tabs) - 1)
+	}
+
+	return v
+}
+
+func (v View) moveTabRight() View {
+	if v.tab < tab(len(v.tabs)-1) {
+		v.tab++
+	} else {
+		v.tab = 0
+	}
+
+	return v
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	builder := strings.Builder{}
+	header := v.headerView(v.windowWidth, v.tab)
+	builder.WriteString(header)
+
+	if v.node == nil {
+		return builder.String()
+	}
+
+	switch v.tab {
+	case STATUS:
+		columns, row := nodeStatusColumnsAndRows(v.node.Node)
+		builder.WriteString(table.RowsToString(columns, []table.DataRow{row}))
+		return builder.String()
+
+	case CONDITIONS:
+		footer := footerView(v.windowWidth, v.conditionsViewPort)
+		builder.WriteString(v.conditionsViewPort.View())
+		builder.WriteString(footer)
+
+	case TAINTS:
+		footer := footerView(v.windowWidth, v.taintsViewPort)
+		builder.WriteString(v.taintsViewPort.View())
+		builder.WriteString(footer)
+
+	case LABELS:
+		footer := footerView(v.windowWidth, v.labelsViewPort)
+		builder.WriteString(v.labelsViewPort.View())
+		builder.WriteString(footer)
+
+	case EVENTS:
+		footer := footerView(v.windowWidth, v.eventsViewPort)
+		builder.WriteString(v.eventsViewPort.View())
+		builder.WriteString(footer)
+	}
+
+	return builder.String()
+}
+
+func (v View) headerView(width int, forTab tab) string {
+	if v.node == nil {
+		return "Loading..."
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(width, []key.Binding{
+		v.keys.Help,
+		v.keys.Quit,
+		v.keys.Refresh,
+		v.keys.ShowPods,
+		v.keys.Left,
+		v.keys.Right,
+	}))
+
+	builder.WriteString("\n\n")
+
+	builder.WriteString(header.New(width-1, v.contextClient, header.Location{Path: []string{"nodes", v.node.Node.Name}}) + "\n")
+
+	builder.WriteString(selection.Tabs(int(forTab), width, v.tabs) + "\n\n")
+
+	builder.WriteString(tableHeaderView(width, forTab, *v.node))
+
+	return builder.String()
+}
+
+// tableHeaderView creates the table header view.
+// Producing table headers seperately from the rows allows us to let the content scroll past the headers without hiding them.
+func tableHeaderView(width int, t tab, node nodes.Node) string {
+	var columns []table.DataColumn
+	switch t {
+	case STATUS:
+		columns, _ = nodeStatusColumnsAndRows(node.Node)
+	case CONDITIONS:
+		columns, _ = conditionColumnsAndRows(width, node.Node.Status.Conditions)
+	case TAINTS:
+		columns, _ = taintColumnsAndRows(width, node.Node.Spec.Taints)
+	case LABELS:
+		columns, _ = table.StringMapColumnsAndRows(width, "Key", "Value", node.Node.Labels)
+	case EVENTS:
+		columns, _ = k8stable.EventColumnsAndRows(width, node.Events)
+	}
+
+	line := strings.Repeat("─", width)
+	return lipgloss.NewStyle().Width(width).Render(table.ColumnsToString(columns)) + "\n" + lipgloss.JoinHorizontal(lipgloss.Center, line) + "\n\n"
+}
+
+// footerView creates the footerView which contains information about how far the user has scrolled through the viewPort.
+func footerView(width int, viewPort viewport.Model) string {
+	info := fmt.Sprintf("%3.f%%", viewPort.ScrollPercent()*100)
+	line := strings.Repeat("─", integer.IntMax(0, width-lipgloss.Width(info)))
+	return "\x1b[0m" + "\n" + lipgloss.NewStyle().Width(width).Render(lipgloss.JoinHorizontal(lipgloss.Center, line, info))
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return func() tea.Msg {
+		node, err := v.k8sClient.GetNode(c.SelectedNode)
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewGetNodeMsg(node)
+	}
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	return nil
+}
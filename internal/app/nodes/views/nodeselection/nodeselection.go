@@ -0,0 +1,304 @@
+package nodeselection
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeui/internal/pkg/component/columntable"
+	"kubeui/internal/pkg/component/confirm"
+	"kubeui/internal/pkg/k8s/nodes"
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+	"kubeui/internal/pkg/ui/k8stable"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/life4/genesis/slices"
+	v1 "k8s.io/api/core/v1"
+)
+
+// keyMap defines the keys that are handled by this view.
+type keyMap struct {
+	kubeui.GlobalKeyMap
+	Cordon key.Binding
+	Drain  key.Binding
+}
+
+// newKeyMap defines the actual key bindings and creates a keyMap.
+func newKeyMap() *keyMap {
+	return &keyMap{
+		GlobalKeyMap: kubeui.NewGlobalKeyMap(),
+		Cordon: key.NewBinding(
+			key.WithKeys("ctrl+t"),
+			key.WithHelp("ctrl+t", "Cordon/uncordon node"),
+		),
+		Drain: key.NewBinding(
+			key.WithKeys("ctrl+d"),
+			key.WithHelp("ctrl+d", "Drain node"),
+		),
+	}
+}
+
+func (v View) fullHelp() [][]key.Binding {
+	bindings := [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.Refresh},
+	}
+
+	if v.nodeList != nil && len(v.nodeList.Items) > 0 {
+		bindings = append(bindings, append(v.nodeTable.KeyList(), v.keys.Cordon, v.keys.Drain))
+	}
+
+	return bindings
+}
+
+// K8sClient represents the interface towards kubernetes needed by this view.
+type K8sClient interface {
+	ListNodes() (*nodes.NodeList, error)
+	CordonNode(name string, unschedulable bool) (string, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// View is used to select a node.
+type View struct {
+	keys *keyMap
+
+	windowWidth  int
+	windowHeight int
+
+	// Nodes in the cluster.
+	nodeList *nodes.NodeList
+
+	// ColumnTable used to select a node.
+	nodeTable columntable.Model
+
+	// Dialog used to confirm cordoning or uncordoning a node.
+	activeDialog *confirm.Model
+	// Whether the node is made unschedulable if the active dialog is confirmed.
+	unschedulable bool
+
+	// Loading indicator
+	loading bool
+
+	// If the View has been initialized or not.
+	initialized bool
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	// Kubernetes client.
+	k8sClient K8sClient
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(k8sClient K8sClient, contextClient ContextClient, windowWidth, windowHeight int) View {
+	return View{
+		k8sClient:     k8sClient,
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          newKeyMap(),
+		loading:       true,
+	}
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	if msg.IsWindowResize() {
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+
+		var cmd tea.Cmd
+		if v.initialized {
+			v.nodeTable, cmd = v.nodeTable.Update(columntable.UpdateHeight{Height: v.tableHeight()})
+		}
+
+		return c, v, cmd
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp {
+		v.showFullHelp = true
+		return c, v, nil
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Quit) {
+		return c, v, kubeui.Exit()
+	}
+
+	// Key presses are handled by the dialog while it is displayed.
+	if msg.IsKeyMsg() && v.activeDialog != nil {
+		dialog, cmd := v.activeDialog.Update(msg.TeaMsg)
+		v.activeDialog = &dialog
+		return c, v, cmd
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Refresh) {
+		return c, v, v.Init(c)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Cordon) && v.initialized {
+		name, ok := v.nodeTable.HighlightedRow()
+		if !ok {
+			return c, v, nil
+		}
+
+		// The node is cordoned unless it is already unschedulable, in which case it is uncordoned.
+		v.unschedulable = true
+		if node, err := slices.Find(v.nodeList.Items, func(n v1.Node) bool { return n.Name == name }); err == nil {
+			v.unschedulable = !node.Spec.Unschedulable
+		}
+
+		operation := "cordon"
+		if !v.unschedulable {
+			operation = "uncordon"
+		}
+
+		dialog := confirm.New([]confirm.Button{{Desc: "Yes", Id: name}, {Desc: "No", Id: name}}, fmt.Sprintf("Are you sure you want to %s %s", operation, name))
+		v.activeDialog = &dialog
+		return c, v, nil
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Drain) && v.initialized {
+		name, ok := v.nodeTable.HighlightedRow()
+		if !ok {
+			return c, v, nil
+		}
+
+		c.SelectedNode = name
+		return c, v, kubeui.PushView("node_drain", true)
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+	case k8smsg.ListNodesMsg:
+		v.nodeList = t.NodeList
+		nodeColumns, nodeRows := k8stable.NodeColumnsAndRows(v.nodeList.Items, v.nodeList.PodCounts)
+		var cmd tea.Cmd
+
+		// The first time we receive a list of nodes then we create a new nodeTable.
+		// Otherwise we just update it.
+		if !v.initialized {
+			v.initialized = true
+			v.nodeTable = columntable.New(nodeColumns, nodeRows, columntable.PageSize(v.tableHeight()), "", false, columntable.Options{SingularItemName: "node", StartInSearchMode: true})
+		} else {
+			v.nodeTable, cmd = v.nodeTable.Update(columntable.UpdateRowsAndColumns{Rows: nodeRows, Columns: nodeColumns})
+		}
+
+		v.loading = false
+
+		return c, v, cmd
+
+	case columntable.Selection:
+		c.SelectedNode = t.Id
+		return c, v, kubeui.PushView("node_info", true)
+
+	case confirm.ButtonPress:
+		v.activeDialog = nil
+
+		if t.Pressed.Desc != "Yes" {
+			return c, v, nil
+		}
+
+		unschedulable := v.unschedulable
+
+		return c, v, func() tea.Msg {
+			if _, err := v.k8sClient.CordonNode(t.Pressed.Id, unschedulable); err != nil {
+				return err
+			}
+
+			nodeList, err := v.k8sClient.ListNodes()
+			if err != nil {
+				return err
+			}
+			return k8smsg.NewListNodesMsg(nodeList)
+		}
+	}
+
+	var cmd tea.Cmd
+	if v.initialized {
+		v.nodeTable, cmd = v.nodeTable.Update(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+// tableHeight returns the number of lines available to the table, which is displayed below the short help, an empty line and the status bar.
+func (v View) tableHeight() int {
+	// The height is not known before the first resize.
+	if v.windowHeight == 0 {
+		return 0
+	}
+
+	return v.windowHeight - 4
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.Cordon, v.keys.Drain}))
+	builder.WriteString("\n\n")
+
+	headerLine := header.New(v.windowWidth-1, v.contextClient, header.Location{Path: []string{"nodes"}})
+	builder.WriteString(headerLine + "\n")
+
+	if v.activeDialog != nil {
+		builder.WriteString(v.activeDialog.View())
+		return builder.String()
+	}
+
+	if v.loading {
+		return "Loading..."
+	} else if len(v.nodeList.Items) == 0 {
+		builder.WriteString("No nodes found")
+	} else {
+		builder.WriteString(v.nodeTable.View())
+	}
+
+	return builder.String()
+}
+
+// CapturesText reports whether the view captures typed text, which it does while the nodes are searched.
+func (v View) CapturesText() bool {
+	return v.nodeTable.Searching()
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return func() tea.Msg {
+		nodeList, err := v.k8sClient.ListNodes()
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewListNodesMsg(nodeList)
+	}
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	return nil
+}
@@ -3,170 +3,138 @@ package pods
 import (
 	"fmt"
 
+	"kubeui/internal/app/common"
+	"kubeui/internal/app/cxs/views/contextselection"
 	"kubeui/internal/app/pods/views/errorinfo"
 	"kubeui/internal/app/pods/views/namespaceselection"
 	"kubeui/internal/app/pods/views/podinfo"
 	"kubeui/internal/app/pods/views/podselection"
+	"kubeui/internal/app/pods/views/portforwards"
 	"kubeui/internal/pkg/k8s"
 	"kubeui/internal/pkg/k8s/k8scontext"
+	"kubeui/internal/pkg/k8s/portforward"
+	"kubeui/internal/pkg/k8smsg"
 	"kubeui/internal/pkg/kubeui"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-// Model defines the base Model of the application.
+// Model defines the base Model of the program.
+// It displays the views of the program, which are replaced by new views connected to another cluster when switching context.
 type Model struct {
 	windowHeight int
 	windowWidth  int
 
-	kubeuiContext kubeui.Context
-
-	// currentView is the currently displayed view.
-	currentView string
-	// previousView is the previously displayed view.
-	previousView string
-
-	initializing bool
-	errorMessage string
-
-	contextClient k8scontext.Client
-	k8sService    k8s.Service
-
-	views map[string]kubeui.View
+	// The views and the connection to the cluster they use, which are replaced when switching context.
+	connection common.Connection
 }
 
 // NewModel creates a new model.
-func NewModel(contextClient k8scontext.Client, k8sService k8s.Service) *Model {
-	return &Model{
-		kubeuiContext: kubeui.Context{
-			Namespace: "default",
-		},
-		contextClient: contextClient,
-		k8sService:    k8sService,
-		views:         map[string]kubeui.View{},
-		initializing:  true,
-	}
+func NewModel(contextClient k8scontext.Client, k8sService k8s.Service, newService k8s.ServiceFactory, portForwards *portforward.Manager) *Model {
+	connection := common.NewConnection(contextClient, k8sService, newService, portForwards, func(service k8s.Service) kubeui.Root {
+		return newRoot(program{contextClient: contextClient, k8sService: service, portForwards: portForwards})
+	})
+
+	return &Model{connection: connection}
 }
 
 // Update updates the model and optionally returns a command.
 // It is part of the bubbletea model interface.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	// Global Keypresses and app messages.
-	switch msgT := msg.(type) {
-	case Initialize:
-		currentContext, ok := m.contextClient.CurrentApiContext()
-
-		if !ok {
-			return m, kubeui.Error(fmt.Errorf("invalid context"))
-		}
-
-		if currentContext.Namespace != "" {
-			m.kubeuiContext.Namespace = currentContext.Namespace
-		}
-
-		if m.kubeuiContext.Namespace == "default" {
-			return m, kubeui.PushView("namespace_selection", true)
-		}
-
-		return m, kubeui.PushView("pod_selection", true)
+	// Results of requests made by the views of a replaced root are ignored.
+	msg, ok := m.connection.Root.Unwrap(msg)
+	if !ok {
+		return m, nil
+	}
 
+	switch msgT := msg.(type) {
 	case tea.WindowSizeMsg:
-
 		m.windowHeight = msgT.Height
 		m.windowWidth = msgT.Width
 
-		for k, v := range m.views {
-			_, v, _ := v.Update(m.kubeuiContext, kubeui.Msg{TeaMsg: msg})
-			m.views[k] = v
-		}
-		return m, nil
-	case error:
-		m.errorMessage = msgT.Error()
-		return m, kubeui.PushView("error_info", true)
-
-	case kubeui.PushViewMsg:
-		if m.initializing {
-			m.initializing = false
-		}
-
-		_, ok := m.views[msgT.Id]
-
-		if !ok || msgT.Initialize {
-			m.views[msgT.Id] = m.initializeView(msgT.Id)
-		}
+	case k8smsg.ContextSwitchedMsg:
+		return m, m.connection.Connect(msgT.Name)
 
-		// If this is the first view that was pushed then we set the previous view to the same as the new current view.
-		m.previousView = m.currentView
-		if m.previousView == "" {
-			m.previousView = msgT.Id
-		}
+	case common.ConnectionMsg:
+		var cmd tea.Cmd
+		m.connection, cmd = m.connection.Update(msgT, m.windowWidth, m.windowHeight)
+		return m, cmd
+	}
 
-		m.currentView = msgT.Id
+	var cmd tea.Cmd
+	m.connection.Root, cmd = m.connection.Root.UpdateRoot(msg)
 
-		if msgT.Initialize {
-			return m, m.views[msgT.Id].Init(m.kubeuiContext)
-		}
+	return m, cmd
+}
 
-		return m, nil
+// View returns the view for the model.
+// It is part of the bubbletea model interface.
+func (m Model) View() string {
+	return m.connection.Root.View()
+}
 
-	case kubeui.PopViewMsg:
+// Init returns an initial command.
+// It is part of the bubbletea model interface.
+func (m Model) Init() tea.Cmd {
+	return m.connection.Root.Init()
+}
 
-		_, ok := m.views[m.previousView]
+// program contains what is needed to create the views of the program.
+type program struct {
+	contextClient k8scontext.Client
+	k8sService    k8s.Service
 
-		if !ok {
-			return m, kubeui.Error(fmt.Errorf("program error, invalid view"))
-		}
+	// portForwards keeps track of the port forwards running in the background.
+	portForwards *portforward.Manager
+}
 
-		m.currentView = m.previousView
-		m.previousView = ""
+// newRoot creates a kubeui.Root displaying the views of the program.
+func newRoot(p program) kubeui.Root {
+	return kubeui.NewRoot(kubeui.RootOptions{
+		Context: kubeui.Context{
+			Namespace: "default",
+		},
+		Start:    p.start,
+		NewView:  p.newView,
+		Commands: common.Commands("pod_selection", "port_forwards", "namespace_selection", "context_selection"),
+	})
+}
 
-		if msgT.Initialize {
-			m.views[m.currentView] = m.initializeView(m.currentView)
-			return m, m.views[m.currentView].Init(m.kubeuiContext)
-		}
+// start uses the namespace of the current context, the user selects a namespace first if the context has no namespace.
+func (p program) start(c kubeui.Context) (kubeui.Context, tea.Cmd) {
+	currentContext, ok := p.contextClient.CurrentApiContext()
 
-		return m, nil
+	if !ok {
+		return c, kubeui.Error(fmt.Errorf("invalid context"))
 	}
 
-	c, v, cmd := m.views[m.currentView].Update(m.kubeuiContext, kubeui.Msg{TeaMsg: msg})
+	if currentContext.Namespace != "" {
+		c.Namespace = currentContext.Namespace
+	}
 
-	m.kubeuiContext = c
-	m.views[m.currentView] = v
+	if c.Namespace == "default" {
+		return c, kubeui.PushView("namespace_selection", true)
+	}
 
-	return m, cmd
+	return c, kubeui.PushView("pod_selection", true)
 }
 
-func (m Model) initializeView(viewId string) kubeui.View {
-	switch viewId {
+// newView creates the view with the given id.
+func (p program) newView(id string, config kubeui.ViewConfig) kubeui.View {
+	switch id {
 	case "pod_selection":
-		return podselection.New(m.k8sService, m.contextClient, m.windowWidth, m.windowHeight)
+		return podselection.New(p.k8sService, p.contextClient, p.portForwards, config.WindowWidth, config.WindowHeight)
+	case "port_forwards":
+		return portforwards.New(p.portForwards, p.contextClient, config.WindowWidth, config.WindowHeight)
 	case "namespace_selection":
-		return namespaceselection.New(m.k8sService, m.contextClient, m.windowWidth, m.windowHeight)
+		return namespaceselection.New(p.k8sService, p.contextClient, "pod_selection", true, config.WindowWidth, config.WindowHeight)
 	case "pod_info":
-		return podinfo.New(m.k8sService, m.windowWidth, m.windowHeight)
-	case "error_info":
-		return errorinfo.New(m.errorMessage, m.windowWidth, m.windowHeight)
+		return podinfo.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "context_selection":
+		return contextselection.New(p.contextClient, config.WindowWidth, config.WindowHeight)
+	case kubeui.ErrorViewId:
+		return errorinfo.New(config.Err.Error(), kubeui.ErrorDetails(config.Err), config.WindowWidth, config.WindowHeight)
 	}
 
-	return namespaceselection.New(m.k8sService, m.contextClient, m.windowWidth, m.windowHeight)
-}
-
-// View returns the view for the model.
-// It is part of the bubbletea model interface.
-func (m Model) View() string {
-	if m.initializing {
-		return "Initializing..."
-	}
-
-	return m.views[m.currentView].View(m.kubeuiContext)
-}
-
-type Initialize struct{}
-
-// Init returns an initial command.
-// It is part of the bubbletea model interface.
-func (m Model) Init() tea.Cmd {
-	return func() tea.Msg {
-		return Initialize{}
-	}
+	return namespaceselection.New(p.k8sService, p.contextClient, "pod_selection", true, config.WindowWidth, config.WindowHeight)
 }
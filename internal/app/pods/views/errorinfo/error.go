@@ -8,7 +8,10 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/utils/integer"
 )
 
 // keyMap defines the keys that are handled by this view.
@@ -29,13 +32,17 @@ func newKeyMap() *keyMap {
 }
 
 // New creates a new View.
-func New(message string, windowWidth, windowHeight int) View {
-	return View{
+// The details, such as a diff of changes that could not be applied, are displayed in a scrollable viewport below the message.
+func New(message, details string, windowWidth, windowHeight int) View {
+	v := View{
 		windowWidth:  windowWidth,
 		windowHeight: windowHeight,
 		keys:         newKeyMap(),
 		message:      message,
+		details:      details,
 	}
+
+	return v.updateViewportAfterResize()
 }
 
 // View displays an error and allows the user to quit the app.
@@ -45,6 +52,9 @@ type View struct {
 
 	keys    *keyMap
 	message string
+	details string
+
+	detailsViewPort viewport.Model
 }
 
 // Update handles new messages from the runtime.
@@ -59,6 +69,7 @@ func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.V
 
 		v.windowHeight = windowResizeMsg.Height
 		v.windowWidth = windowResizeMsg.Width
+		v = v.updateViewportAfterResize()
 
 		return c, v, nil
 	}
@@ -71,17 +82,53 @@ func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.V
 		return c, v, kubeui.PopView(false)
 	}
 
+	if v.details != "" {
+		var cmd tea.Cmd
+		v.detailsViewPort, cmd = v.detailsViewPort.Update(msg.TeaMsg)
+		return c, v, cmd
+	}
+
 	return c, v, nil
 }
 
+func (v View) updateViewportAfterResize() View {
+	if v.details == "" {
+		return v
+	}
+
+	v.detailsViewPort.Width = v.windowWidth
+	v.detailsViewPort.Height = integer.IntMax(0, v.windowHeight-lipgloss.Height(v.messageView())-1)
+	v.detailsViewPort.SetContent(lipgloss.NewStyle().Width(v.windowWidth).Render(v.details))
+
+	return v
+}
+
 // View renders the ui of the view.
 func (v View) View(c kubeui.Context) string {
+	builder := strings.Builder{}
+	builder.WriteString(v.messageView())
+
+	if v.details != "" {
+		builder.WriteString("\n")
+		builder.WriteString(v.detailsViewPort.View())
+	}
+
+	return builder.String()
+}
+
+// messageView renders the help and the error message, which are displayed above the details.
+func (v View) messageView() string {
 	builder := strings.Builder{}
 	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Quit, v.keys.Continue}))
 	builder.WriteString("\n\n")
 	builder.WriteString("An error occured\n\n")
 	builder.WriteString(styles.ErrorMessage.Render(strui.LineBreak(v.message, v.windowWidth)))
 
+	if v.details != "" {
+		builder.WriteString("\n\n")
+		builder.WriteString(strings.Repeat("─", v.windowWidth))
+	}
+
 	return builder.String()
 }
 
@@ -1,14 +1,13 @@
 package namespaceselection
 
 import (
-	"fmt"
 	"strings"
 
 	"kubeui/internal/pkg/component/searchtable"
 	"kubeui/internal/pkg/k8smsg"
 	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
 	"kubeui/internal/pkg/ui/help"
-	"kubeui/internal/pkg/ui/statusbar"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -19,12 +18,17 @@ import (
 // keyMap defines the keys that are handled by this view.
 type keyMap struct {
 	kubeui.GlobalKeyMap
+	AllNamespaces key.Binding
 }
 
 // newKeyMap defines the actual key bindings and creates a keyMap.
 func newKeyMap() *keyMap {
 	return &keyMap{
 		GlobalKeyMap: kubeui.NewGlobalKeyMap(),
+		AllNamespaces: key.NewBinding(
+			key.WithKeys("ctrl+a"),
+			key.WithHelp("ctrl+a", "Select all namespaces"),
+		),
 	}
 }
 
@@ -32,6 +36,11 @@ func (v View) fullHelp() [][]key.Binding {
 	bindings := [][]key.Binding{
 		{v.keys.Help, v.keys.Quit, v.keys.ExitView},
 	}
+
+	if v.allowMultiple {
+		bindings[0] = append(bindings[0], v.keys.AllNamespaces)
+	}
+
 	bindings = append(bindings, v.namespaceTable.KeyList())
 
 	return bindings
@@ -47,7 +56,7 @@ type K8sClient interface {
 
 // ContextClient represents the interface for working with kubernetes contexts that the view needs.
 type ContextClient interface {
-	CurrentContext() string
+	header.ContextClient
 	SwitchContext(ctx, namespace string) (err error)
 }
 
@@ -75,13 +84,24 @@ type View struct {
 
 	// KubeContext client.
 	contextClient ContextClient
+
+	// Id of the view that replaces this view when a namespace has been selected or the view is exited.
+	returnViewId string
+
+	// Indicates whether several namespaces, or all of them, can be selected.
+	allowMultiple bool
 }
 
 // New creates a new View.
-func New(k8sClient K8sClient, contextClient ContextClient, windowWidth, windowHeight int) View {
+// returnViewId is the id of the view that replaces this view when a namespace has been selected or when the view is exited,
+// if the view is already in the navigation stack then it is returned to.
+// If allowMultiple is set, then several namespaces can be selected by marking them, or all namespaces at once.
+func New(k8sClient K8sClient, contextClient ContextClient, returnViewId string, allowMultiple bool, windowWidth, windowHeight int) View {
 	return View{
 		k8sClient:     k8sClient,
 		contextClient: contextClient,
+		returnViewId:  returnViewId,
+		allowMultiple: allowMultiple,
 		windowWidth:   windowWidth,
 		windowHeight:  windowHeight,
 		keys:          newKeyMap(),
@@ -100,7 +120,12 @@ func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.V
 		v.windowHeight = windowResizeMsg.Height
 		v.windowWidth = windowResizeMsg.Width
 
-		return c, v, nil
+		var cmd tea.Cmd
+		if v.initialized {
+			v.namespaceTable, cmd = v.namespaceTable.Update(searchtable.UpdateHeight{Height: v.tableHeight()})
+		}
+
+		return c, v, cmd
 	}
 
 	if msg.IsKeyMsg() && v.showFullHelp {
@@ -118,8 +143,14 @@ func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.V
 	}
 
 	if msg.MatchesKeyBindings(v.keys.ExitView) {
-		// We don't reinitialize the pod selection view when exiting the view.
-		return c, v, kubeui.PushView("pod_selection", false)
+		// We don't reinitialize the return view when exiting the view.
+		return c, v, kubeui.ReplaceView(v.returnViewId, false)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.AllNamespaces) && v.allowMultiple {
+		c.AllNamespaces = true
+		c.Namespaces = nil
+		return c, v, kubeui.ReplaceView(v.returnViewId, true)
 	}
 
 	// Results
@@ -131,18 +162,31 @@ func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.V
 		})
 		v.namespaceTable = searchtable.New(
 			v.namespaces,
-			10,
+			searchtable.PageSize(v.tableHeight()),
 			c.Namespace,
 			false,
 			searchtable.Options{
 				SingularItemName:  "namespace",
 				StartInSearchMode: true,
+				AllowMarking:      v.allowMultiple,
 			},
 		)
 		v.initialized = true
 		return c, v, nil
 
 	case searchtable.Selection:
+		// When namespaces have been marked then the selected namespace is listed together with them.
+		// The kube context is left untouched, since it can only hold a single namespace.
+		if marked := v.namespaceTable.Marked(); len(marked) > 0 {
+			if !slices.Contains(marked, t.Value) {
+				marked = append(marked, t.Value)
+			}
+
+			c.Namespaces = marked
+			c.AllNamespaces = false
+			return c, v, kubeui.ReplaceView(v.returnViewId, true)
+		}
+
 		return c, v, func() tea.Msg {
 			err := v.contextClient.SwitchContext(v.contextClient.CurrentContext(), t.Value)
 			if err != nil {
@@ -153,8 +197,10 @@ func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.V
 
 	case selectedNamespaceMsg:
 		c.Namespace = string(t)
-		// If we have made a selection then we reinitialize the pod selection view to load the pods for that namespace.
-		return c, v, kubeui.PushView("pod_selection", true)
+		c.Namespaces = nil
+		c.AllNamespaces = false
+		// If we have made a selection then we reinitialize the return view to load the data for that namespace.
+		return c, v, kubeui.ReplaceView(v.returnViewId, true)
 
 	}
 
@@ -163,6 +209,16 @@ func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.V
 	return c, v, cmd
 }
 
+// tableHeight returns the number of lines available to the table, which is displayed below the short help, an empty line and the status bar.
+func (v View) tableHeight() int {
+	// The height is not known before the first resize.
+	if v.windowHeight == 0 {
+		return 0
+	}
+
+	return v.windowHeight - 4
+}
+
 // View renders the ui of the view.
 func (v View) View(c kubeui.Context) string {
 	if v.showFullHelp {
@@ -171,17 +227,27 @@ func (v View) View(c kubeui.Context) string {
 
 	builder := strings.Builder{}
 
-	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.ExitView}))
+	shortHelp := []key.Binding{v.keys.Help, v.keys.Quit, v.keys.ExitView}
+	if v.allowMultiple {
+		shortHelp = append(shortHelp, v.keys.AllNamespaces)
+	}
+
+	builder.WriteString(help.Short(v.windowWidth, shortHelp))
 	builder.WriteString("\n\n")
 
-	statusBar := statusbar.New(v.windowWidth-1, " ", fmt.Sprintf("Context: %s", v.contextClient.CurrentContext()))
-	builder.WriteString(statusBar + "\n")
+	headerLine := header.New(v.windowWidth-1, v.contextClient, header.Location{Path: []string{"namespaces"}})
+	builder.WriteString(headerLine + "\n")
 
 	builder.WriteString(v.namespaceTable.View())
 
 	return builder.String()
 }
 
+// CapturesText reports whether the view captures typed text, which it does while the namespaces are searched.
+func (v View) CapturesText() bool {
+	return v.namespaceTable.Searching()
+}
+
 // Init initializes the view.
 func (v View) Init(c kubeui.Context) tea.Cmd {
 	if v.initialized {
@@ -1,55 +1,19 @@
 package podinfo
 
 import (
+	"encoding/json"
+	"fmt"
+
+	"kubeui/internal/pkg/jsoncolor"
 	"kubeui/internal/pkg/k8s"
 	"kubeui/internal/pkg/ui/table"
-	"sort"
-	"time"
+	"kubeui/internal/pkg/yamlcolor"
 
-	"github.com/life4/genesis/maps"
-	"github.com/life4/genesis/slices"
+	"github.com/charmbracelet/lipgloss"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/utils/integer"
 )
 
-// eventColumnsAndRows creates the neccessary columns and row in order to display event information.
-func eventColumnsAndRows(maxWidth int, events []v1.Event) ([]table.DataColumn, []table.DataRow) {
-	eventColumns := []table.DataColumn{
-		{Desc: "Type", Width: 6},
-		{Desc: "Reason", Width: 8},
-		{Desc: "Age", Width: 5},
-		{Desc: "From", Width: 6},
-		{Desc: "Message", Width: 50},
-	}
-
-	eventRows := slices.Map(events, func(e v1.Event) table.DataRow {
-
-		eventFormat := k8s.NewListEventFormat(e, time.Now())
-
-		// Update widths of the name and status columns
-		eventColumns[0].Width = integer.IntMax(eventColumns[0].Width, len(eventFormat.Type)+2)
-		eventColumns[1].Width = integer.IntMax(eventColumns[1].Width, len(eventFormat.Reason)+2)
-		eventColumns[2].Width = integer.IntMax(eventColumns[2].Width, len(eventFormat.Age)+2)
-		eventColumns[3].Width = integer.IntMax(eventColumns[3].Width, len(eventFormat.From))
-
-		remainingWidth := maxWidth - slices.Reduce(eventColumns[0:5], 0, func(c table.DataColumn, acc int) int {
-			return acc + c.Width
-		})
-
-		eventColumns[4].Width = integer.IntMax(remainingWidth-1, len(eventFormat.Message))
-
-		if eventColumns[4].Width < 30 {
-			eventColumns[4].Width = 30
-		}
-
-		return table.DataRow{
-			Values: []string{eventFormat.Type, eventFormat.Reason, eventFormat.Age, eventFormat.From, eventFormat.Message},
-		}
-	})
-
-	return eventColumns, eventRows
-}
-
 // podStatusColumnsAndRows creates the neccessary columns and row in order to display pod status information.
 func podStatusColumnsAndRows(pod v1.Pod) ([]table.DataColumn, table.DataRow) {
 	podColumns := []table.DataColumn{
@@ -77,32 +41,39 @@ func podStatusColumnsAndRows(pod v1.Pod) ([]table.DataColumn, table.DataRow) {
 	return podColumns, podRow
 }
 
-// stringMapColumnsAndRows creates the neccessary columns and rows in order to display a map[string]string as a table.
-func stringMapColumnsAndRows(maxWidth int, col1 string, col2 string, data map[string]string) ([]table.DataColumn, []table.DataRow) {
+// podManifest renders the full pod manifest as highlighted yaml, or json if asJSON is set.
+// The managed fields of the pod are left out unless showManagedFields is set, since they are rarely of interest and very verbose.
+func podManifest(maxWidth int, pod v1.Pod, asJSON, showManagedFields bool) (string, error) {
+	// Objects fetched using the typed clients have their type information stripped.
+	pod.APIVersion = "v1"
+	pod.Kind = "Pod"
 
-	columns := []table.DataColumn{
-		{Desc: col1, Width: len(col1) + 2},
-		{Desc: col2, Width: len(col2)},
+	if !showManagedFields {
+		pod.ManagedFields = nil
 	}
 
-	keys := maps.Keys(data)
-	sort.Strings(keys)
-
-	rows := []table.DataRow{}
-
-	for _, key := range keys {
+	data, err := json.Marshal(pod)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal pod: %v", err)
+	}
 
-		value := data[key]
-		// Update widths of the columns
-		columns[0].Width = integer.IntMax(columns[0].Width, len(key)+2)
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return "", fmt.Errorf("failed to unmarshal pod: %v", err)
+	}
 
-		remainingWidth := maxWidth - columns[0].Width
-		columns[1].Width = integer.IntMax(remainingWidth-1, len(value))
+	var manifest []byte
+	if asJSON {
+		formatter := jsoncolor.NewFormatter()
+		formatter.Indent = 2
+		manifest, err = formatter.Marshal(obj)
+	} else {
+		manifest, err = yamlcolor.Marshal(obj)
+	}
 
-		rows = append(rows, table.DataRow{
-			Values: []string{key, value},
-		})
+	if err != nil {
+		return "", fmt.Errorf("failed to render pod manifest: %v", err)
 	}
 
-	return columns, rows
+	return lipgloss.NewStyle().Width(maxWidth).Render(string(manifest)), nil
 }
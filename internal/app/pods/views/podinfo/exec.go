@@ -0,0 +1,167 @@
+package podinfo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+
+	"kubeui/internal/pkg/k8s/pods"
+	"kubeui/internal/pkg/kubeui"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+// shellCommand starts a shell in the container, preferring bash if it is available.
+var shellCommand = []string{"sh", "-c", "command -v bash >/dev/null && exec bash || exec sh"}
+
+// execCommand implements tea.ExecCommand in order to run an interactive shell in a container while the program is suspended.
+type execCommand struct {
+	ctx       context.Context
+	k8sClient K8sService
+
+	namespace string
+	pod       string
+	container string
+
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// SetStdin is called by bubbletea with the input of the program.
+func (e *execCommand) SetStdin(r io.Reader) {
+	e.stdin = r
+}
+
+// SetStdout is called by bubbletea with the output of the program.
+func (e *execCommand) SetStdout(w io.Writer) {
+	e.stdout = w
+}
+
+// SetStderr is called by bubbletea with the error output of the program.
+func (e *execCommand) SetStderr(w io.Writer) {
+	e.stderr = w
+}
+
+// Run runs the shell until it exits.
+// If stdin is a terminal it is put into raw mode, so that key presses are sent to the shell as they are typed.
+func (e *execCommand) Run() error {
+	options := pods.ExecOptions{
+		Command: shellCommand,
+		Stdin:   e.stdin,
+		Stdout:  e.stdout,
+		Stderr:  e.stderr,
+	}
+
+	if f, ok := e.stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		state, err := term.MakeRaw(int(f.Fd()))
+		if err != nil {
+			return err
+		}
+		defer term.Restore(int(f.Fd()), state)
+
+		options.TTY = true
+
+		sizeQueue := newTerminalSizeQueue(int(f.Fd()))
+		defer sizeQueue.stop()
+		options.TerminalSizeQueue = sizeQueue
+	}
+
+	err := e.k8sClient.Exec(e.ctx, e.namespace, e.pod, e.container, options)
+
+	// The shell exiting with a non zero code, for example after a failed command, is not an error.
+	var codeExitErr exec.CodeExitError
+	if errors.As(err, &codeExitErr) {
+		return nil
+	}
+
+	return err
+}
+
+// terminalSizeQueue delivers the size of the terminal at the start of the session, followed by the new size every time the terminal is resized.
+// Resizes are detected the same way as kubectl does, by listening for the signal sent when the window changes size.
+type terminalSizeQueue struct {
+	fd      int
+	resizes chan remotecommand.TerminalSize
+	done    chan struct{}
+}
+
+// newTerminalSizeQueue creates a terminalSizeQueue for the terminal with the given file descriptor and starts monitoring its size.
+func newTerminalSizeQueue(fd int) *terminalSizeQueue {
+	q := &terminalSizeQueue{
+		fd:      fd,
+		resizes: make(chan remotecommand.TerminalSize, 1),
+		done:    make(chan struct{}),
+	}
+
+	go q.monitor()
+
+	return q
+}
+
+// monitor sends the current size of the terminal, and then a new size after each resize until the queue is stopped.
+func (q *terminalSizeQueue) monitor() {
+	signals := make(chan os.Signal, 1)
+	notifyResize(signals)
+	defer signal.Stop(signals)
+
+	var last remotecommand.TerminalSize
+
+	for {
+		if width, height, err := term.GetSize(q.fd); err == nil {
+			size := remotecommand.TerminalSize{Width: uint16(width), Height: uint16(height)}
+
+			if size != last {
+				last = size
+
+				select {
+				case q.resizes <- size:
+				case <-q.done:
+					return
+				}
+			}
+		}
+
+		select {
+		case <-signals:
+		case <-q.done:
+			return
+		}
+	}
+}
+
+// Next blocks until the terminal is resized and returns the new size, it returns nil after the queue has been stopped which ends the monitoring.
+func (q *terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-q.resizes:
+		return &size
+	case <-q.done:
+		return nil
+	}
+}
+
+// stop stops monitoring the size of the terminal.
+func (q *terminalSizeQueue) stop() {
+	close(q.done)
+}
+
+// execShell suspends the program and runs a shell in the selected container.
+// The program is resumed when the shell exits.
+func (v View) execShell(c kubeui.Context) tea.Cmd {
+	command := &execCommand{
+		ctx:       v.ctx,
+		k8sClient: v.k8sClient,
+		namespace: c.SelectedPodNamespace,
+		pod:       c.SelectedPod,
+		container: v.selectedContainer,
+	}
+
+	return tea.Exec(command, func(err error) tea.Msg {
+		return err
+	})
+}
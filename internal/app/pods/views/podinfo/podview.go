@@ -1,15 +1,19 @@
 package podinfo
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"kubeui/internal/pkg/editor"
 	"kubeui/internal/pkg/jsoncolor"
 	"kubeui/internal/pkg/k8s/pods"
 	"kubeui/internal/pkg/k8smsg"
 	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
 	"kubeui/internal/pkg/ui/help"
+	"kubeui/internal/pkg/ui/k8stable"
 	"kubeui/internal/pkg/ui/selection"
 	"kubeui/internal/pkg/ui/table"
 
@@ -17,15 +21,20 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/utils/integer"
 )
 
 // keyMap defines the keys that are handled by this view.
 type keyMap struct {
 	kubeui.GlobalKeyMap
-	Left         key.Binding
-	Right        key.Binding
-	NumberChoice key.Binding
+	Left                key.Binding
+	Right               key.Binding
+	NumberChoice        key.Binding
+	Exec                key.Binding
+	Edit                key.Binding
+	ToggleFormat        key.Binding
+	ToggleManagedFields key.Binding
 }
 
 // newKeyMap defines the actual key bindings and creates a keyMap.
@@ -44,12 +53,28 @@ func newKeyMap() *keyMap {
 			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
 			key.WithHelp("1,2,3,4,5,6,7,8,9", "Select container"),
 		),
+		Exec: key.NewBinding(
+			key.WithKeys("ctrl+e"),
+			key.WithHelp("ctrl+e", "Open a shell in the selected container"),
+		),
+		Edit: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("ctrl+o", "Edit the pod in $EDITOR"),
+		),
+		ToggleFormat: key.NewBinding(
+			key.WithKeys("ctrl+y"),
+			key.WithHelp("ctrl+y", "Toggle between yaml and json manifest"),
+		),
+		ToggleManagedFields: key.NewBinding(
+			key.WithKeys("ctrl+k"),
+			key.WithHelp("ctrl+k", "Show/hide managed fields in the manifest"),
+		),
 	}
 }
 
 func (v View) fullHelp() [][]key.Binding {
 	bindings := [][]key.Binding{
-		{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView},
+		{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView, v.keys.Exec, v.keys.Edit},
 	}
 
 	viewPortKeys := viewport.DefaultKeyMap()
@@ -59,6 +84,8 @@ func (v View) fullHelp() [][]key.Binding {
 		v.keys.Left,
 		v.keys.Right,
 		v.keys.NumberChoice,
+		v.keys.ToggleFormat,
+		v.keys.ToggleManagedFields,
 		viewPortKeys.Up,
 		viewPortKeys.Down,
 		viewPortKeys.PageUp,
@@ -70,9 +97,20 @@ func (v View) fullHelp() [][]key.Binding {
 	return bindings
 }
 
+// maxLogLines is the maximum number of log lines kept for the selected container.
+const maxLogLines = 5000
+
 // K8sService represents the interface towards kubernetes needed by this view.
 type K8sService interface {
 	GetPod(namespace, id string) (*pods.Pod, error)
+	StreamLogs(ctx context.Context, namespace, name, container string) (<-chan string, error)
+	Exec(ctx context.Context, namespace, name, container string, options pods.ExecOptions) error
+	UpdatePod(namespace string, pod *v1.Pod) (string, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
 }
 
 // View displays pod information.
@@ -94,6 +132,11 @@ type View struct {
 	labelsViewPort      viewport.Model
 	eventsViewPort      viewport.Model
 	logsViewPort        viewport.Model
+	manifestViewPort    viewport.Model
+
+	// Manifest display options, yaml without managed fields is shown by default.
+	manifestAsJSON    bool
+	showManagedFields bool
 
 	windowWidth  int
 	windowHeight int
@@ -103,18 +146,44 @@ type View struct {
 
 	pod *pods.Pod
 
+	// Context of the view, cancelled when the view is destroyed.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// Cancels the log stream of the selected container.
+	cancelLogStream context.CancelFunc
+	// Id of the latest request to start a log stream, only the stream started by that request is used.
+	logStreamId int
+	// Lines of the log stream of the selected container.
+	logStream <-chan string
+	// Indicates whether the log stream has been closed by the server.
+	logStreamClosed bool
+
+	// Raw and rendered log lines of the selected container.
+	logLines         []string
+	renderedLogLines []string
+
 	// Kubernetes client.
 	k8sClient K8sService
+
+	// KubeContext client.
+	contextClient ContextClient
 }
 
 // New creates a new View.
-func New(k8sClient K8sService, windowWidth, windowHeight int) View {
+func New(k8sClient K8sService, contextClient ContextClient, windowWidth, windowHeight int) View {
+	ctx, cancel := context.WithCancel(context.Background())
+
 	return View{
-		k8sClient:    k8sClient,
-		windowWidth:  windowWidth,
-		windowHeight: windowHeight,
-		keys:         newKeyMap(),
-		tabs:         []string{STATUS.String(), ANNOTATIONS.String(), LABELS.String(), EVENTS.String(), LOGS.String()},
+		k8sClient:       k8sClient,
+		contextClient:   contextClient,
+		windowWidth:     windowWidth,
+		windowHeight:    windowHeight,
+		keys:            newKeyMap(),
+		tabs:            []string{STATUS.String(), ANNOTATIONS.String(), LABELS.String(), EVENTS.String(), LOGS.String(), MANIFEST.String()},
+		ctx:             ctx,
+		cancel:          cancel,
+		cancelLogStream: func() {},
 	}
 }
 
@@ -132,6 +201,8 @@ const (
 	EVENTS
 	// LOGS is used to display the logs of the pod.
 	LOGS
+	// MANIFEST is used to display the full manifest of the pod.
+	MANIFEST
 )
 
 // String implements the stringer interface for tab.
@@ -147,6 +218,8 @@ func (t tab) String() string {
 		return "EVENTS"
 	case LOGS:
 		return "LOGS"
+	case MANIFEST:
+		return "MANIFEST"
 	}
 	return "UNKNOWN"
 }
@@ -191,28 +264,65 @@ func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.V
 		return c, v, nil
 	case msg.MatchesKeyBindings(v.keys.Refresh):
 
-		return c, v, func() tea.Msg {
-			pod, err := v.k8sClient.GetPod(c.Namespace, c.SelectedPod)
+		getPod := func() tea.Msg {
+			pod, err := v.k8sClient.GetPod(c.SelectedPodNamespace, c.SelectedPod)
 			if err != nil {
 				return err
 			}
 			return k8smsg.NewGetPodMsg(pod)
 		}
 
+		// If the log stream has been closed we also try to start it again.
+		if v.logStreamClosed {
+			var cmd tea.Cmd
+			v, cmd = v.startLogStream(c)
+			return c, v, tea.Batch(getPod, cmd)
+		}
+
+		return c, v, getPod
+
+	case msg.MatchesKeyBindings(v.keys.Exec) && v.selectedContainer != "":
+		return c, v, v.execShell(c)
+
+	case msg.MatchesKeyBindings(v.keys.Edit) && v.pod != nil:
+		pod := v.pod.Pod.DeepCopy()
+		// Objects fetched using the typed clients have their type information stripped.
+		pod.APIVersion = "v1"
+		pod.Kind = "Pod"
+		return c, v, editor.Edit(pod)
+
+	case msg.MatchesKeyBindings(v.keys.ToggleFormat) && v.tab == MANIFEST:
+		v.manifestAsJSON = !v.manifestAsJSON
+		v, err := v.updateManifest()
+		if err != nil {
+			return c, v, kubeui.Error(err)
+		}
+		v.manifestViewPort.GotoTop()
+		return c, v, nil
+
+	case msg.MatchesKeyBindings(v.keys.ToggleManagedFields) && v.tab == MANIFEST:
+		v.showManagedFields = !v.showManagedFields
+		v, err := v.updateManifest()
+		if err != nil {
+			return c, v, kubeui.Error(err)
+		}
+		return c, v, nil
+
 	case msg.MatchesKeyBindings(v.keys.NumberChoice) && v.tab == LOGS:
 
+		previousContainer := v.selectedContainer
+
 		v, err := v.selectContainer(msg)
 		if err != nil {
 			return c, v, kubeui.Error(err)
 		}
 
-		// If the selected container has logs then update the logview.
-		if _, ok := v.pod.Logs[v.selectedContainer]; ok {
-			v.logsViewPort.SetContent(strings.Join(jsoncolor.JSONLines(v.windowWidth, v.pod.Logs[v.selectedContainer]), "\n\n"))
-			v.logsViewPort.GotoBottom()
+		if v.selectedContainer == previousContainer {
+			return c, v, nil
 		}
 
-		return c, v, nil
+		v, cmd := v.startLogStream(c)
+		return c, v, cmd
 	}
 
 	// Results
@@ -226,13 +336,64 @@ func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.V
 		v.pod = t.Pod
 		v.containerNames = v.pod.ContainerNames()
 
-		// If we don't have a selected container
+		v = v.updateViewportsAfterResize()
+
+		// If we don't have a selected container then we select the first one and start streaming its logs.
 		if v.selectedContainer == "" && len(v.containerNames) > 0 {
 			v.selectedContainer = v.containerNames[0]
+			v, cmd := v.startLogStream(c)
+			return c, v, cmd
 		}
 
-		v = v.updateViewportsAfterResize()
+		return c, v, nil
+
+	case editor.EditedMsg:
+		if !t.Changed() {
+			return c, v, nil
+		}
+
+		return c, v, func() tea.Msg {
+			pod := &v1.Pod{}
+			if err := t.Unmarshal(pod); err != nil {
+				return err
+			}
+
+			if _, err := v.k8sClient.UpdatePod(c.SelectedPodNamespace, pod); err != nil {
+				return t.Error(err)
+			}
+
+			updatedPod, err := v.k8sClient.GetPod(c.SelectedPodNamespace, c.SelectedPod)
+			if err != nil {
+				return err
+			}
+
+			return k8smsg.NewGetPodMsg(updatedPod)
+		}
+
+	case k8smsg.LogStreamMsg:
+		// Ignore streams that have been replaced before they were started.
+		if t.Id != v.logStreamId || v.ctx.Err() != nil {
+			return c, v, nil
+		}
+
+		v.logStream = t.Lines
+		return c, v, k8smsg.NextLogLines(t.Lines)
+
+	case k8smsg.LogLinesMsg:
+		// Ignore lines from streams that have been replaced.
+		if t.Stream != v.logStream {
+			return c, v, nil
+		}
+
+		v = v.appendLogLines(t.Lines)
+		return c, v, k8smsg.NextLogLines(t.Stream)
+
+	case k8smsg.LogStreamClosedMsg:
+		if t.Stream != v.logStream {
+			return c, v, nil
+		}
 
+		v.logStreamClosed = true
 		return c, v, nil
 	}
 
@@ -245,7 +406,71 @@ func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.V
 	return c, v, cmd
 }
 
+// startLogStream stops the current log stream and starts streaming the logs of the selected container.
+func (v View) startLogStream(c kubeui.Context) (View, tea.Cmd) {
+	v.cancelLogStream()
+
+	v.logStreamId++
+	v.logStream = nil
+	v.logStreamClosed = false
+	v.logLines = []string{}
+	v.renderedLogLines = []string{}
+	v.logsViewPort.SetContent("")
+
+	if v.selectedContainer == "" {
+		return v, nil
+	}
+
+	ctx, cancel := context.WithCancel(v.ctx)
+	v.cancelLogStream = cancel
+
+	id := v.logStreamId
+	container := v.selectedContainer
+
+	return v, func() tea.Msg {
+		lines, err := v.k8sClient.StreamLogs(ctx, c.SelectedPodNamespace, c.SelectedPod, container)
+		if err != nil {
+			// The stream was cancelled while starting, which is not an error.
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		return k8smsg.NewLogStreamMsg(id, lines)
+	}
+}
+
+// appendLogLines adds lines to the logs viewport.
+// If the viewport is scrolled to the bottom it will follow the new lines, otherwise the scroll position is kept.
+func (v View) appendLogLines(lines []string) View {
+	follow := v.logsViewPort.AtBottom()
+
+	v.logLines = append(v.logLines, lines...)
+	v.renderedLogLines = append(v.renderedLogLines, jsoncolor.JSONLines(v.windowWidth, strings.Join(lines, "\n"))...)
+
+	if len(v.logLines) > maxLogLines {
+		v.logLines = v.logLines[len(v.logLines)-maxLogLines:]
+	}
+
+	if len(v.renderedLogLines) > maxLogLines {
+		v.renderedLogLines = v.renderedLogLines[len(v.renderedLogLines)-maxLogLines:]
+	}
+
+	v.logsViewPort.SetContent(strings.Join(v.renderedLogLines, "\n\n"))
+
+	if follow {
+		v.logsViewPort.GotoBottom()
+	}
+
+	return v
+}
+
 func (v View) updateViewportsAfterResize() View {
+	if v.pod == nil {
+		return v
+	}
+
 	v.annotationsViewPort.Height = v.windowHeight - (lipgloss.Height(v.headerView(v.windowWidth, ANNOTATIONS)) + lipgloss.Height(footerView(v.windowWidth, v.annotationsViewPort)))
 	v.annotationsViewPort.Width = v.windowWidth
 
@@ -258,26 +483,55 @@ func (v View) updateViewportsAfterResize() View {
 	v.logsViewPort.Height = v.windowHeight - (lipgloss.Height(v.headerView(v.windowWidth, LOGS)) + lipgloss.Height(footerView(v.windowWidth, v.logsViewPort)))
 	v.logsViewPort.Width = v.windowWidth
 
-	if _, ok := v.pod.Logs[v.selectedContainer]; ok && v.logsViewPort.Height > 0 {
-		v.logsViewPort.SetContent(strings.Join(jsoncolor.JSONLines(v.windowWidth, v.pod.Logs[v.selectedContainer]), "\n\n"))
-		v.logsViewPort.GotoBottom()
+	v.manifestViewPort.Height = v.windowHeight - (lipgloss.Height(v.headerView(v.windowWidth, MANIFEST)) + lipgloss.Height(footerView(v.windowWidth, v.manifestViewPort)))
+	v.manifestViewPort.Width = v.windowWidth
+
+	// Log lines are rendered to fit the width of the window, so a resize requires them to be rendered again.
+	if v.logsViewPort.Height > 0 {
+		follow := v.logsViewPort.AtBottom()
+		v.renderedLogLines = jsoncolor.JSONLines(v.windowWidth, strings.Join(v.logLines, "\n"))
+		v.logsViewPort.SetContent(strings.Join(v.renderedLogLines, "\n\n"))
+
+		if follow {
+			v.logsViewPort.GotoBottom()
+		}
 	}
 
 	if v.annotationsViewPort.Height > 0 {
-		v.annotationsViewPort.SetContent(table.RowsToString(stringMapColumnsAndRows(v.windowWidth, "Key", "Value", v.pod.Pod.Annotations)))
+		v.annotationsViewPort.SetContent(table.RowsToString(table.StringMapColumnsAndRows(v.windowWidth, "Key", "Value", v.pod.Pod.Annotations)))
 	}
 
 	if v.labelsViewPort.Height > 0 {
-		v.labelsViewPort.SetContent(table.RowsToString(stringMapColumnsAndRows(v.windowWidth, "Key", "Value", v.pod.Pod.Labels)))
+		v.labelsViewPort.SetContent(table.RowsToString(table.StringMapColumnsAndRows(v.windowWidth, "Key", "Value", v.pod.Pod.Labels)))
 	}
 
 	if v.eventsViewPort.Height > 0 {
-		v.eventsViewPort.SetContent(table.RowsToString(eventColumnsAndRows(v.windowWidth, v.pod.Events)))
+		v.eventsViewPort.SetContent(table.RowsToString(k8stable.EventColumnsAndRows(v.windowWidth, v.pod.Events)))
+	}
+
+	if v.manifestViewPort.Height > 0 {
+		// The manifest is rendered from a pod that has already been marshaled successfully, rendering it again is not expected to fail.
+		v, _ = v.updateManifest()
 	}
 
 	return v
 }
 
+// updateManifest renders the manifest of the pod using the current display options.
+func (v View) updateManifest() (View, error) {
+	if v.pod == nil {
+		return v, nil
+	}
+
+	manifest, err := podManifest(v.windowWidth, v.pod.Pod, v.manifestAsJSON, v.showManagedFields)
+	if err != nil {
+		return v, err
+	}
+
+	v.manifestViewPort.SetContent(manifest)
+	return v, nil
+}
+
 // updateViewports updates the currently active viewport.
 func (v View) updateViewports(msg tea.Msg) (View, tea.Cmd) {
 	var cmd tea.Cmd
@@ -291,6 +545,8 @@ func (v View) updateViewports(msg tea.Msg) (View, tea.Cmd) {
 		v.eventsViewPort, cmd = v.eventsViewPort.Update(msg)
 	case LOGS:
 		v.logsViewPort, cmd = v.logsViewPort.Update(msg)
+	case MANIFEST:
+		v.manifestViewPort, cmd = v.manifestViewPort.Update(msg)
 	}
 
 	return v, cmd
@@ -368,9 +624,14 @@ func (v View) View(c kubeui.Context) string {
 		builder.WriteString(footer)
 
 	case LOGS:
-		footer := footerView(v.windowWidth, v.logsViewPort)
+		footer := logsFooterView(v.windowWidth, v.logsViewPort, v.logStreamClosed)
 		builder.WriteString(v.logsViewPort.View())
 		builder.WriteString(footer)
+
+	case MANIFEST:
+		footer := footerView(v.windowWidth, v.manifestViewPort)
+		builder.WriteString(v.manifestViewPort.View())
+		builder.WriteString(footer)
 	}
 
 	return builder.String()
@@ -387,12 +648,16 @@ func (v View) headerView(width int, forTab tab) string {
 		v.keys.Help,
 		v.keys.Quit,
 		v.keys.Refresh,
+		v.keys.Exec,
+		v.keys.Edit,
 		v.keys.Left,
 		v.keys.Right,
 	}))
 
 	builder.WriteString("\n\n")
 
+	builder.WriteString(header.New(width-1, v.contextClient, header.Location{Namespace: v.pod.Pod.Namespace, Path: []string{"pods", v.pod.Pod.Name}}) + "\n")
+
 	builder.WriteString(selection.Tabs(int(forTab), width, v.tabs) + "\n\n")
 
 	if forTab == LOGS {
@@ -400,6 +665,15 @@ func (v View) headerView(width int, forTab tab) string {
 		builder.WriteString("\n")
 	}
 
+	if forTab == MANIFEST {
+		format := "YAML"
+		if v.manifestAsJSON {
+			format = "JSON"
+		}
+		builder.WriteString(selection.HorizontalList([]string{"YAML", "JSON"}, format, width))
+		builder.WriteString("\n")
+	}
+
 	builder.WriteString(tableHeaderView(width, forTab, *v.pod))
 
 	return builder.String()
@@ -413,12 +687,12 @@ func tableHeaderView(width int, t tab, pod pods.Pod) string {
 	case STATUS:
 		columns, _ = podStatusColumnsAndRows(pod.Pod)
 	case ANNOTATIONS:
-		columns, _ = stringMapColumnsAndRows(width, "Key", "Value", pod.Pod.Annotations)
+		columns, _ = table.StringMapColumnsAndRows(width, "Key", "Value", pod.Pod.Annotations)
 	case LABELS:
-		columns, _ = stringMapColumnsAndRows(width, "Key", "Value", pod.Pod.Labels)
+		columns, _ = table.StringMapColumnsAndRows(width, "Key", "Value", pod.Pod.Labels)
 	case EVENTS:
-		columns, _ = eventColumnsAndRows(width, pod.Events)
-	case LOGS:
+		columns, _ = k8stable.EventColumnsAndRows(width, pod.Events)
+	case LOGS, MANIFEST:
 		return strings.Repeat("─", width) + "\n"
 	}
 
@@ -433,10 +707,27 @@ func footerView(width int, viewPort viewport.Model) string {
 	return "\x1b[0m" + "\n" + lipgloss.NewStyle().Width(width).Render(lipgloss.JoinHorizontal(lipgloss.Center, line, info))
 }
 
+// logsFooterView creates the footer of the logs viewport.
+// Apart from how far the user has scrolled, it shows if new log lines are being followed or if the stream has ended.
+func logsFooterView(width int, viewPort viewport.Model, streamClosed bool) string {
+	status := "following"
+
+	switch {
+	case streamClosed:
+		status = "stream closed"
+	case !viewPort.AtBottom():
+		status = "paused"
+	}
+
+	info := fmt.Sprintf("%s %3.f%%", status, viewPort.ScrollPercent()*100)
+	line := strings.Repeat("─", integer.IntMax(0, width-lipgloss.Width(info)-1))
+	return "\x1b[0m" + "\n" + lipgloss.NewStyle().Width(width).Render(lipgloss.JoinHorizontal(lipgloss.Center, line, " ", info))
+}
+
 // Init initializes the view.
 func (v View) Init(c kubeui.Context) tea.Cmd {
 	return func() tea.Msg {
-		pod, err := v.k8sClient.GetPod(c.Namespace, c.SelectedPod)
+		pod, err := v.k8sClient.GetPod(c.SelectedPodNamespace, c.SelectedPod)
 		if err != nil {
 			return err
 		}
@@ -446,6 +737,8 @@ func (v View) Init(c kubeui.Context) tea.Cmd {
 }
 
 // Destroy is called before a view is removed as the active view in the application.
+// It stops the log stream of the view.
 func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	v.cancel()
 	return nil
 }
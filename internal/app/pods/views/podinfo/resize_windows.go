@@ -0,0 +1,11 @@
+//go:build windows
+
+package podinfo
+
+import (
+	"os"
+)
+
+// notifyResize does nothing, since there is no signal for terminal resizes on windows.
+// The size of the terminal at the start of the session is still used.
+func notifyResize(c chan<- os.Signal) {}
@@ -1,28 +1,37 @@
 package podselection
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"kubeui/internal/pkg/component/columntable"
 	"kubeui/internal/pkg/component/confirm"
-	"kubeui/internal/pkg/k8s"
+	"kubeui/internal/pkg/component/numberinput"
+	"kubeui/internal/pkg/component/queryinput"
+	"kubeui/internal/pkg/k8s/pods"
 	"kubeui/internal/pkg/k8smsg"
 	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/styles"
+	"kubeui/internal/pkg/ui/header"
 	"kubeui/internal/pkg/ui/help"
-	"kubeui/internal/pkg/ui/statusbar"
+	"kubeui/internal/pkg/ui/k8stable"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/life4/genesis/slices"
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/utils/integer"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // keyMap defines the keys that are handled by this view.
 type keyMap struct {
 	kubeui.GlobalKeyMap
-	SelectNamespace key.Binding
+	SelectNamespace  key.Binding
+	SelectContext    key.Binding
+	PortForward      key.Binding
+	ShowPortForwards key.Binding
+	Query            key.Binding
 }
 
 // newKeyMap defines the actual key bindings and creates a keyMap.
@@ -33,6 +42,22 @@ func newKeyMap() *keyMap {
 			key.WithKeys("ctrl+n"),
 			key.WithHelp("ctrl+n", "Select namespace"),
 		),
+		SelectContext: key.NewBinding(
+			key.WithKeys("ctrl+t"),
+			key.WithHelp("ctrl+t", "Switch context"),
+		),
+		PortForward: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("ctrl+p", "Port forward a pod"),
+		),
+		ShowPortForwards: key.NewBinding(
+			key.WithKeys("ctrl+g"),
+			key.WithHelp("ctrl+g", "Show port forwards"),
+		),
+		Query: key.NewBinding(
+			key.WithKeys("ctrl+l"),
+			key.WithHelp("ctrl+l", "Filter pods by label and field selectors"),
+		),
 	}
 }
 
@@ -41,10 +66,10 @@ func (v View) fullHelp() [][]key.Binding {
 		{v.keys.Help, v.keys.Quit, v.keys.Refresh},
 	}
 
-	bindings[0] = append(bindings[0], v.keys.SelectNamespace)
+	bindings[0] = append(bindings[0], v.keys.SelectNamespace, v.keys.SelectContext, v.keys.ShowPortForwards, v.keys.Query)
 
 	if len(v.pods) > 0 {
-		bindings = append(bindings, v.podTable.KeyList())
+		bindings = append(bindings, append(v.podTable.KeyList(), v.keys.PortForward))
 	}
 
 	return bindings
@@ -52,13 +77,19 @@ func (v View) fullHelp() [][]key.Binding {
 
 // K8sClient represents the interface towards kubernetes needed by this view.
 type K8sClient interface {
-	ListPods(namespace string) (*v1.PodList, error)
+	ListAndWatchPods(ctx context.Context, namespaces []string, options pods.ListOptions) (*v1.PodList, <-chan pods.WatchEvent, error)
 	DeletePod(namespace, name string) (string, error)
+	DeletePods(pods []types.NamespacedName) []pods.DeleteResult
+}
+
+// PortForwarder represents the interface used to start port forwards that run in the background.
+type PortForwarder interface {
+	Start(namespace, pod string, localPort, remotePort int) (string, error)
 }
 
 // ContextClient represents the interface for working with kubernetes contexts that the view needs.
 type ContextClient interface {
-	CurrentContext() string
+	header.ContextClient
 }
 
 // View is used to select a pod.
@@ -68,11 +99,26 @@ type View struct {
 	windowWidth  int
 	windowHeight int
 
-	// Pods in current namespace.
+	// Pods in the listed namespaces.
 	pods []v1.Pod
 
 	// Dialog used to confirm.
 	activeDialog *confirm.Model
+	// The ids of the rows of the marked pods that are about to be deleted, while the deletion is being confirmed.
+	bulkDeletion []string
+	// The results of the last bulk deletion, displayed until a key is pressed.
+	deletionResults []pods.DeleteResult
+
+	// Input used to choose the ports of a port forward.
+	activeInput *numberinput.Model
+	// The chosen remote port, zero while it is being chosen.
+	forwardRemotePort int
+
+	// Input used to enter the query.
+	activeQueryInput *queryinput.Model
+	// The query the pods are filtered by on the server, and the selectors parsed from it.
+	query       string
+	listOptions pods.ListOptions
 
 	// ColumnTable used to select a pod.
 	podTable columntable.Model
@@ -80,6 +126,17 @@ type View struct {
 	// Loading indicator
 	loading bool
 
+	// Context used for the pod watch, cancelled when the view is destroyed.
+	watchCtx    context.Context
+	cancelWatch context.CancelFunc
+
+	// Context of the currently running pod watch, which is replaced when the pods are listed again.
+	podWatchCtx    context.Context
+	cancelPodWatch context.CancelFunc
+
+	// Events of the currently running pod watch.
+	podEvents <-chan pods.WatchEvent
+
 	// If the View has been initialized or not.
 	initialized bool
 
@@ -91,17 +148,28 @@ type View struct {
 
 	// KubeContext client.
 	contextClient ContextClient
+
+	// Starts port forwards.
+	portForwarder PortForwarder
 }
 
 // New creates a new View.
-func New(k8sClient K8sClient, contextClient ContextClient, windowWidth, windowHeight int) View {
+func New(k8sClient K8sClient, contextClient ContextClient, portForwarder PortForwarder, windowWidth, windowHeight int) View {
+	watchCtx, cancelWatch := context.WithCancel(context.Background())
+	podWatchCtx, cancelPodWatch := context.WithCancel(watchCtx)
+
 	return View{
-		k8sClient:     k8sClient,
-		contextClient: contextClient,
-		windowWidth:   windowWidth,
-		windowHeight:  windowHeight,
-		keys:          newKeyMap(),
-		loading:       true,
+		k8sClient:      k8sClient,
+		contextClient:  contextClient,
+		portForwarder:  portForwarder,
+		windowWidth:    windowWidth,
+		windowHeight:   windowHeight,
+		keys:           newKeyMap(),
+		loading:        true,
+		watchCtx:       watchCtx,
+		cancelWatch:    cancelWatch,
+		podWatchCtx:    podWatchCtx,
+		cancelPodWatch: cancelPodWatch,
 	}
 }
 
@@ -112,6 +180,11 @@ func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.V
 		return c, v, nil
 	}
 
+	if msg.IsKeyMsg() && v.deletionResults != nil {
+		v.deletionResults = nil
+		return c, v, nil
+	}
+
 	if msg.IsWindowResize() {
 		windowResizeMsg, ok := msg.GetWindowResizeMsg()
 
@@ -122,7 +195,12 @@ func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.V
 		v.windowHeight = windowResizeMsg.Height
 		v.windowWidth = windowResizeMsg.Width
 
-		return c, v, nil
+		var cmd tea.Cmd
+		if v.initialized {
+			v.podTable, cmd = v.podTable.Update(columntable.UpdateHeight{Height: v.tableHeight()})
+		}
+
+		return c, v, cmd
 	}
 
 	if msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp {
@@ -134,71 +212,193 @@ func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.V
 		return c, v, kubeui.Exit()
 	}
 
+	// Key presses are handled by the input while it is displayed.
+	if msg.IsKeyMsg() && v.activeInput != nil {
+		input, cmd := v.activeInput.Update(msg.TeaMsg)
+		v.activeInput = &input
+		return c, v, cmd
+	}
+
+	if msg.IsKeyMsg() && v.activeQueryInput != nil {
+		input, cmd := v.activeQueryInput.Update(msg.TeaMsg)
+		v.activeQueryInput = &input
+		return c, v, cmd
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Query) && v.activeDialog == nil {
+		input := queryinput.New("query", "Filter pods by label and field selectors, for example app=web,status.phase=Running", v.query, queryinput.Options{
+			Placeholder: "app=web,status.phase=Running",
+			Validate: func(query string) error {
+				_, err := pods.ParseQuery(query)
+				return err
+			},
+		})
+		v.activeQueryInput = &input
+		return c, v, input.Init()
+	}
+
 	if msg.MatchesKeyBindings(v.keys.SelectNamespace) {
 		return c, v, kubeui.PushView("namespace_selection", true)
 	}
 
-	if msg.MatchesKeyBindings(v.keys.Refresh) {
-		return c, v, func() tea.Msg {
-			podList, err := v.k8sClient.ListPods(c.Namespace)
-			if err != nil {
-				return err
-			}
-			return k8smsg.NewListPodsMsg(podList)
+	if msg.MatchesKeyBindings(v.keys.SelectContext) {
+		return c, v, kubeui.PushView("context_selection", true)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.ShowPortForwards) {
+		return c, v, kubeui.PushView("port_forwards", true)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.PortForward) && v.initialized && v.activeDialog == nil {
+		id, ok := v.podTable.HighlightedRow()
+		if !ok {
+			return c, v, nil
 		}
+
+		_, name := k8stable.ParseRowId(id)
+		input := numberinput.New(id, fmt.Sprintf("Port of %s to forward", name), defaultRemotePort(v.pods, id), numberinput.Options{Min: 1, Max: 65535})
+		v.activeInput = &input
+		v.forwardRemotePort = 0
+		return c, v, input.Init()
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Refresh) {
+		v, cmd := v.restartPodWatch(c)
+		return c, v, cmd
 	}
 
 	// Results
 	switch t := msg.TeaMsg.(type) {
-	case k8smsg.ListPodsMsg:
-		v.pods = t.PodList.Items
-		podColumns, podRows := podTableContents(v.pods)
-		var cmd tea.Cmd
-
-		// The first time we receive a list of pods then we create a new podTable.
-		// Otherwise we just update it.
-		if !v.initialized {
-			v.initialized = true
-			v.podTable = columntable.New(podColumns, podRows, 10, "", true, columntable.Options{SingularItemName: "pod", StartInSearchMode: true})
-		} else {
-			v.podTable, cmd = v.podTable.Update(columntable.UpdateRowsAndColumns{Rows: podRows, Columns: podColumns})
+	case k8smsg.WatchPodsMsg:
+		v.podEvents = t.Events
+		v, cmd := v.setPods(t.PodList.Items, c.MultipleNamespaces())
+		return c, v, tea.Batch(cmd, k8smsg.NextPodWatchEvent(t.Events))
+
+	case k8smsg.PodWatchEventMsg:
+		// Ignore events from watches that have been replaced.
+		if t.Events != v.podEvents {
+			return c, v, nil
 		}
 
-		v.loading = false
+		v, cmd := v.setPods(applyWatchEvent(v.pods, t.Event), c.MultipleNamespaces())
+		return c, v, tea.Batch(cmd, k8smsg.NextPodWatchEvent(t.Events))
 
+	case k8smsg.PodWatchClosedMsg:
+		if t.Events != v.podEvents {
+			return c, v, nil
+		}
+
+		// The watch was closed by the server, so we list the pods again and start a new watch.
+		v.podEvents = nil
+		v, cmd := v.restartPodWatch(c)
 		return c, v, cmd
 
 	case columntable.Selection:
-		c.SelectedPod = t.Id
+		c.SelectedPodNamespace, c.SelectedPod = k8stable.ParseRowId(t.Id)
 		return c, v, kubeui.PushView("pod_info", true)
 
+	// The remote port is chosen first, followed by the local port after which the forward is started.
+	case numberinput.Submission:
+		if v.forwardRemotePort == 0 {
+			v.forwardRemotePort = t.Value
+			_, name := k8stable.ParseRowId(t.Id)
+			input := numberinput.New(t.Id, fmt.Sprintf("Local port to forward to port %d of %s", t.Value, name), t.Value, numberinput.Options{Min: 1, Max: 65535})
+			v.activeInput = &input
+			return c, v, input.Init()
+		}
+
+		remotePort := v.forwardRemotePort
+		v.activeInput = nil
+		v.forwardRemotePort = 0
+
+		namespace, name := k8stable.ParseRowId(t.Id)
+		_, err := v.portForwarder.Start(namespace, name, t.Value, remotePort)
+		if err != nil {
+			return c, v, kubeui.Error(err)
+		}
+
+		return c, v, kubeui.PushView("port_forwards", true)
+
+	case numberinput.Cancellation:
+		v.activeInput = nil
+		v.forwardRemotePort = 0
+		return c, v, nil
+
+	// The query has already been validated by the input, so parsing it is not expected to fail.
+	case queryinput.Submission:
+		v.activeQueryInput = nil
+
+		listOptions, err := pods.ParseQuery(t.Value)
+		if err != nil {
+			return c, v, kubeui.Error(err)
+		}
+
+		v.query = t.Value
+		v.listOptions = listOptions
+		v.loading = true
+
+		v, cmd := v.restartPodWatch(c)
+		return c, v, cmd
+
+	case queryinput.Cancellation:
+		v.activeQueryInput = nil
+		return c, v, nil
+
 	// When the user tries to delete a pod we create a new confirmation dialog which will
 	// display the dialog and handle the choice.
 	case columntable.Deletion:
-		dialog := confirm.New([]confirm.Button{{Desc: "Yes", Id: t.Id}, {Desc: "No", Id: t.Id}}, fmt.Sprintf("Are you sure you want to delete %s", t.Id))
+		namespace, name := k8stable.ParseRowId(t.Id)
+		dialog := confirm.New([]confirm.Button{{Desc: "Yes", Id: t.Id}, {Desc: "No", Id: t.Id}}, fmt.Sprintf("Are you sure you want to delete %s in namespace %s", name, namespace))
 		v.activeDialog = &dialog
 		return c, v, nil
 
+	case columntable.BulkDeletion:
+		question := fmt.Sprintf("Are you sure you want to delete the %d marked pods", len(t.Ids))
+		if len(t.Ids) == 1 {
+			_, name := k8stable.ParseRowId(t.Ids[0])
+			question = fmt.Sprintf("Are you sure you want to delete the marked pod %s", name)
+		}
+
+		dialog := confirm.New([]confirm.Button{{Desc: "Yes"}, {Desc: "No"}}, question)
+		v.activeDialog = &dialog
+		v.bulkDeletion = t.Ids
+		return c, v, nil
+
 	case confirm.ButtonPress:
 		v.activeDialog = nil
 
+		bulkDeletion := v.bulkDeletion
+		v.bulkDeletion = nil
+
 		if t.Pressed.Desc != "Yes" {
 			return c, v, nil
 		}
 
-		return c, v, func() tea.Msg {
-			_, err := v.k8sClient.DeletePod(c.Namespace, t.Pressed.Id)
-			if err != nil {
-				return err
-			}
+		if len(bulkDeletion) > 0 {
+			return c, v, v.deletePods(bulkDeletion)
+		}
 
-			podList, err := v.k8sClient.ListPods(c.Namespace)
+		// The pod watch picks up the deletion, so there is no need to list the pods again.
+		return c, v, func() tea.Msg {
+			namespace, name := k8stable.ParseRowId(t.Pressed.Id)
+			_, err := v.k8sClient.DeletePod(namespace, name)
 			if err != nil {
 				return err
 			}
-			return k8smsg.NewListPodsMsg(podList)
+			return nil
 		}
 
+	// The pods that could not be deleted stay marked, so that the deletion can be retried.
+	case k8smsg.PodsDeletedMsg:
+		v.deletionResults = t.Results
+
+		failed := slices.Map(slices.Filter(t.Results, func(r pods.DeleteResult) bool { return r.Err != nil }), func(r pods.DeleteResult) string {
+			return k8stable.RowId(r.Namespace, r.Name)
+		})
+
+		var cmd tea.Cmd
+		v.podTable, cmd = v.podTable.Update(columntable.UpdateMarked{Ids: failed})
+		return c, v, cmd
 	}
 
 	// If we have an active dialog.
@@ -216,33 +416,98 @@ func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.V
 	return c, v, cmd
 }
 
-// podTableContents creates the neccessary columns and rows for the columntable in order to display pod information.
-func podTableContents(pods []v1.Pod) ([]*columntable.Column, []*columntable.Row) {
-	podColumns := []*columntable.Column{
-		{Desc: "Name", Width: 4},
-		{Desc: "Ready", Width: 5},
-		{Desc: "Status", Width: 6},
-		{Desc: "Restarts", Width: 8},
-		{Desc: "Age", Width: 3},
-	}
-
-	podRows := slices.Map(pods, func(p v1.Pod) *columntable.Row {
-		podFormat := k8s.NewListPodFormat(p)
-
-		// Update widths of the name and status columns
-		podColumns[0].Width = integer.IntMax(podColumns[0].Width, len(p.Name))
-		podColumns[1].Width = integer.IntMax(podColumns[1].Width, len(podFormat.Ready))
-		podColumns[2].Width = integer.IntMax(podColumns[2].Width, len(podFormat.Status))
-		podColumns[3].Width = integer.IntMax(podColumns[3].Width, len(podFormat.Restarts))
-		podColumns[4].Width = integer.IntMax(podColumns[4].Width, len(podFormat.Age))
-
-		return &columntable.Row{
-			Id:     p.Name,
-			Values: []string{podFormat.Name, podFormat.Ready, podFormat.Status, podFormat.Restarts, podFormat.Age},
+// setPods replaces the pods of the view and updates the podTable accordingly.
+// The namespace of each pod is shown if pods in several namespaces are listed.
+func (v View) setPods(podList []v1.Pod, showNamespace bool) (View, tea.Cmd) {
+	v.pods = podList
+	podColumns, podRows := k8stable.PodColumnsAndRows(v.pods, showNamespace)
+	var cmd tea.Cmd
+
+	// The first time we receive a list of pods then we create a new podTable.
+	// Otherwise we just update it.
+	if !v.initialized {
+		v.initialized = true
+		v.podTable = columntable.New(podColumns, podRows, columntable.PageSize(v.tableHeight()), "", true, columntable.Options{SingularItemName: "pod", StartInSearchMode: true, AllowMarking: true})
+	} else {
+		v.podTable, cmd = v.podTable.Update(columntable.UpdateRowsAndColumns{Rows: podRows, Columns: podColumns})
+	}
+
+	v.loading = false
+
+	return v, cmd
+}
+
+// applyWatchEvent applies a watch event to a list of pods and returns the updated list.
+// Added and modified pods replace any existing pod with the same name and namespace, or are appended to the end of the list.
+func applyWatchEvent(podList []v1.Pod, event pods.WatchEvent) []v1.Pod {
+	if event.Type == pods.Deleted {
+		return slices.Filter(podList, func(p v1.Pod) bool {
+			return p.Name != event.Pod.Name || p.Namespace != event.Pod.Namespace
+		})
+	}
+
+	result := make([]v1.Pod, len(podList))
+	copy(result, podList)
+
+	for i, p := range result {
+		if p.Name == event.Pod.Name && p.Namespace == event.Pod.Namespace {
+			result[i] = event.Pod
+			return result
 		}
+	}
+
+	return append(result, event.Pod)
+}
+
+// deletePods deletes the pods of the given rows, the pod watch picks up the deletions.
+func (v View) deletePods(ids []string) tea.Cmd {
+	podNames := slices.Map(ids, func(id string) types.NamespacedName {
+		namespace, name := k8stable.ParseRowId(id)
+		return types.NamespacedName{Namespace: namespace, Name: name}
 	})
 
-	return podColumns, podRows
+	return func() tea.Msg {
+		return k8smsg.NewPodsDeletedMsg(v.k8sClient.DeletePods(podNames))
+	}
+}
+
+// listAndWatchPods lists the pods in the namespaces of the context and starts watching them for changes.
+func (v View) listAndWatchPods(c kubeui.Context) tea.Cmd {
+	ctx := v.podWatchCtx
+	namespaces := c.ListedNamespaces()
+	listOptions := v.listOptions
+
+	return func() tea.Msg {
+		// The watch has been replaced or the view has been destroyed, so there is no point in starting it.
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		podList, events, err := v.k8sClient.ListAndWatchPods(ctx, namespaces, listOptions)
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewWatchPodsMsg(podList, events)
+	}
+}
+
+// restartPodWatch stops the current pod watch and lists and watches the pods again.
+func (v View) restartPodWatch(c kubeui.Context) (View, tea.Cmd) {
+	v.cancelPodWatch()
+	v.podWatchCtx, v.cancelPodWatch = context.WithCancel(v.watchCtx)
+
+	return v, v.listAndWatchPods(c)
+}
+
+// tableHeight returns the number of lines available to the table, which is displayed below the short help, an empty line and the status bar.
+func (v View) tableHeight() int {
+	// The height is not known before the first resize.
+	if v.windowHeight == 0 {
+		return 0
+	}
+
+	return v.windowHeight - 4
 }
 
 // View renders the ui of the view.
@@ -253,7 +518,7 @@ func (v View) View(c kubeui.Context) string {
 
 	builder := strings.Builder{}
 
-	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.SelectNamespace, v.keys.Refresh}))
+	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.SelectNamespace, v.keys.Refresh, v.keys.PortForward, v.keys.Query}))
 	builder.WriteString("\n\n")
 
 	if v.activeDialog != nil {
@@ -261,13 +526,33 @@ func (v View) View(c kubeui.Context) string {
 		return builder.String()
 	}
 
-	podViewStatusBar := statusbar.New(v.windowWidth-1, " ", fmt.Sprintf("Context: %s  Namespace: %s", v.contextClient.CurrentContext(), c.Namespace))
-	builder.WriteString(podViewStatusBar + "\n")
+	if v.activeInput != nil {
+		builder.WriteString(v.activeInput.View())
+		return builder.String()
+	}
+
+	if v.activeQueryInput != nil {
+		builder.WriteString(v.activeQueryInput.View())
+		return builder.String()
+	}
+
+	location := header.Location{Namespace: header.Namespaces(c), Path: []string{"pods"}}
+	if v.query != "" {
+		location.Details = append(location.Details, fmt.Sprintf("Query: %s", v.query))
+	}
+
+	builder.WriteString(header.New(v.windowWidth-1, v.contextClient, location) + "\n")
 
 	if v.loading {
 		return "Loading..."
+	} else if v.deletionResults != nil {
+		builder.WriteString(deletionSummary(v.deletionResults))
+	} else if len(v.pods) == 0 && v.query != "" {
+		builder.WriteString(fmt.Sprintf("No pods matching %s found", v.query))
+	} else if len(v.pods) == 0 && c.AllNamespaces {
+		builder.WriteString("No pods found in any namespace")
 	} else if len(v.pods) == 0 {
-		builder.WriteString(fmt.Sprintf("No pods found in namespace %s", c.Namespace))
+		builder.WriteString(fmt.Sprintf("No pods found in namespace %s", strings.Join(c.ListedNamespaces(), ", ")))
 	} else {
 		builder.WriteString(v.podTable.View())
 	}
@@ -275,19 +560,54 @@ func (v View) View(c kubeui.Context) string {
 	return builder.String()
 }
 
-// Init initializes the view.
-func (v View) Init(c kubeui.Context) tea.Cmd {
-	return func() tea.Msg {
-		podList, err := v.k8sClient.ListPods(c.Namespace)
-		if err != nil {
-			return err
+// deletionSummary describes the outcome of deleting each pod of a bulk deletion.
+func deletionSummary(results []pods.DeleteResult) string {
+	deleted := len(slices.Filter(results, func(r pods.DeleteResult) bool { return r.Err == nil }))
+
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("Deleted %d of %d pods, press any key to continue\n\n", deleted, len(results)))
+
+	for _, result := range results {
+		if result.Err != nil {
+			builder.WriteString(styles.ErrorMessage.Render(fmt.Sprintf("✗ %s in namespace %s: %v", result.Name, result.Namespace, result.Err)) + "\n")
+		} else {
+			builder.WriteString(fmt.Sprintf("✓ %s in namespace %s\n", result.Name, result.Namespace))
 		}
+	}
+
+	return builder.String()
+}
+
+// defaultRemotePort returns the first port declared by the containers of a pod, or 80 if no ports are declared.
+// The pod is identified by the id of its row.
+func defaultRemotePort(podList []v1.Pod, id string) int {
+	namespace, name := k8stable.ParseRowId(id)
+	pod, err := slices.Find(podList, func(p v1.Pod) bool { return p.Name == name && p.Namespace == namespace })
+	if err != nil {
+		return 80
+	}
 
-		return k8smsg.NewListPodsMsg(podList)
+	for _, container := range pod.Spec.Containers {
+		if len(container.Ports) > 0 {
+			return int(container.Ports[0].ContainerPort)
+		}
 	}
+
+	return 80
+}
+
+// CapturesText reports whether the view captures typed text, which it does while the pods are searched or a port or query is entered.
+func (v View) CapturesText() bool {
+	return v.activeInput != nil || v.activeQueryInput != nil || v.podTable.Searching()
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return v.listAndWatchPods(c)
 }
 
 // Destroy is called before a view is removed as the active view in the application.
 func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	v.cancelWatch()
 	return nil
 }
@@ -0,0 +1,300 @@
+package portforwards
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"kubeui/internal/pkg/component/columntable"
+	"kubeui/internal/pkg/component/confirm"
+	"kubeui/internal/pkg/k8s/portforward"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/life4/genesis/slices"
+	"k8s.io/utils/integer"
+)
+
+// refreshInterval is how often the status of the forwards is refreshed.
+const refreshInterval = time.Second
+
+func (v View) fullHelp() [][]key.Binding {
+	bindings := [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.ExitView},
+	}
+
+	if len(v.forwards) > 0 {
+		bindings = append(bindings, v.forwardTable.KeyList())
+	}
+
+	return bindings
+}
+
+// Manager represents the interface used to list and stop port forwards.
+type Manager interface {
+	List() []portforward.Forward
+	Stop(id string) error
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// forwardsMsg contains the current forwards, it is sent periodically while the view is displayed.
+type forwardsMsg struct {
+	// The context of the view that requested the forwards.
+	ctx      context.Context
+	forwards []portforward.Forward
+}
+
+// View lists the port forwards running in the background and allows them to be stopped.
+type View struct {
+	keys kubeui.GlobalKeyMap
+
+	windowWidth  int
+	windowHeight int
+
+	forwards []portforward.Forward
+
+	// Dialog used to confirm.
+	activeDialog *confirm.Model
+
+	// ColumnTable used to select a forward.
+	forwardTable columntable.Model
+
+	// If the View has been initialized or not.
+	initialized bool
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	// Context of the view, cancelled when the view is destroyed.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	manager Manager
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(manager Manager, contextClient ContextClient, windowWidth, windowHeight int) View {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return View{
+		manager:       manager,
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          kubeui.NewGlobalKeyMap(),
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	if msg.IsWindowResize() {
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+
+		var cmd tea.Cmd
+		if v.initialized {
+			v.forwardTable, cmd = v.forwardTable.Update(columntable.UpdateHeight{Height: v.tableHeight()})
+		}
+
+		return c, v, cmd
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp {
+		v.showFullHelp = true
+		return c, v, nil
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Quit) {
+		return c, v, kubeui.Exit()
+	}
+
+	if msg.MatchesKeyBindings(v.keys.ExitView) && v.activeDialog == nil {
+		// Forwards keep running in the background when leaving the view.
+		return c, v, kubeui.PopView(false)
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+	case forwardsMsg:
+		// Ignore forwards requested by views that have been replaced.
+		if t.ctx != v.ctx {
+			return c, v, nil
+		}
+
+		v.forwards = t.forwards
+		forwardColumns, forwardRows := forwardColumnsAndRows(v.forwards)
+		var cmd tea.Cmd
+
+		if !v.initialized {
+			v.initialized = true
+			v.forwardTable = columntable.New(forwardColumns, forwardRows, columntable.PageSize(v.tableHeight()), "", true, columntable.Options{SingularItemName: "port forward"})
+		} else {
+			v.forwardTable, cmd = v.forwardTable.Update(columntable.UpdateRowsAndColumns{Rows: forwardRows, Columns: forwardColumns})
+		}
+
+		return c, v, tea.Batch(cmd, v.listForwards(refreshInterval))
+
+	case columntable.Deletion:
+		dialog := confirm.New([]confirm.Button{{Desc: "Yes", Id: t.Id}, {Desc: "No", Id: t.Id}}, fmt.Sprintf("Are you sure you want to stop the port forward on %s", t.Id))
+		v.activeDialog = &dialog
+		return c, v, nil
+
+	case confirm.ButtonPress:
+		v.activeDialog = nil
+
+		if t.Pressed.Desc != "Yes" {
+			return c, v, nil
+		}
+
+		if err := v.manager.Stop(t.Pressed.Id); err != nil {
+			return c, v, kubeui.Error(err)
+		}
+
+		return c, v, v.listForwards(0)
+	}
+
+	// If we have an active dialog.
+	if v.activeDialog != nil {
+		dialog, cmd := v.activeDialog.Update(msg.TeaMsg)
+		v.activeDialog = &dialog
+		return c, v, cmd
+	}
+
+	var cmd tea.Cmd
+	if v.initialized {
+		v.forwardTable, cmd = v.forwardTable.Update(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+// listForwards lists the forwards after the given delay.
+// Nothing is listed once the view has been destroyed, which stops the periodic refresh.
+func (v View) listForwards(delay time.Duration) tea.Cmd {
+	ctx := v.ctx
+
+	return func() tea.Msg {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+
+		return forwardsMsg{ctx: ctx, forwards: v.manager.List()}
+	}
+}
+
+// forwardColumnsAndRows creates the neccessary columns and rows for a columntable in order to display port forwards.
+func forwardColumnsAndRows(forwards []portforward.Forward) ([]*columntable.Column, []*columntable.Row) {
+	forwardColumns := []*columntable.Column{
+		{Desc: "Local", Width: 5},
+		{Desc: "Pod", Width: 3},
+		{Desc: "Namespace", Width: 9},
+		{Desc: "Port", Width: 4, Compare: columntable.CompareNumbers},
+		{Desc: "Status", Width: 6},
+		{Desc: "Error", Width: 5},
+	}
+
+	forwardRows := slices.Map(forwards, func(f portforward.Forward) *columntable.Row {
+		remotePort := strconv.Itoa(f.RemotePort)
+		errMessage := ""
+		if f.Err != nil {
+			errMessage = f.Err.Error()
+		}
+
+		forwardColumns[0].Width = integer.IntMax(forwardColumns[0].Width, len(f.Id))
+		forwardColumns[1].Width = integer.IntMax(forwardColumns[1].Width, len(f.Pod))
+		forwardColumns[2].Width = integer.IntMax(forwardColumns[2].Width, len(f.Namespace))
+		forwardColumns[3].Width = integer.IntMax(forwardColumns[3].Width, len(remotePort))
+		forwardColumns[4].Width = integer.IntMax(forwardColumns[4].Width, len(f.Status))
+		forwardColumns[5].Width = integer.IntMax(forwardColumns[5].Width, len(errMessage))
+
+		return &columntable.Row{
+			Id:     f.Id,
+			Values: []string{f.Id, f.Pod, f.Namespace, remotePort, string(f.Status), errMessage},
+		}
+	})
+
+	return forwardColumns, forwardRows
+}
+
+// tableHeight returns the number of lines available to the table, which is displayed below the short help, an empty line and the header.
+func (v View) tableHeight() int {
+	// The height is not known before the first resize.
+	if v.windowHeight == 0 {
+		return 0
+	}
+
+	return v.windowHeight - 4
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.ExitView}))
+	builder.WriteString("\n\n")
+
+	builder.WriteString(header.New(v.windowWidth-1, v.contextClient, header.Location{Path: []string{"port forwards"}}) + "\n")
+
+	if v.activeDialog != nil {
+		builder.WriteString(v.activeDialog.View())
+		return builder.String()
+	}
+
+	if !v.initialized {
+		return "Loading..."
+	} else if len(v.forwards) == 0 {
+		builder.WriteString("No port forwards are running, start one from the pod list")
+	} else {
+		builder.WriteString(v.forwardTable.View())
+	}
+
+	return builder.String()
+}
+
+// CapturesText reports whether the view captures typed text, which it does while the port forwards are searched.
+func (v View) CapturesText() bool {
+	return v.forwardTable.Searching()
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return v.listForwards(0)
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+// It stops the periodic refresh of the forwards, the forwards themselves keep running.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	v.cancel()
+	return nil
+}
@@ -0,0 +1,79 @@
+package resources
+
+import (
+	"fmt"
+
+	"kubeui/internal/app/common"
+	"kubeui/internal/app/pods/views/errorinfo"
+	"kubeui/internal/app/pods/views/namespaceselection"
+	"kubeui/internal/app/resources/views/resourceinfo"
+	"kubeui/internal/app/resources/views/resourceselection"
+	"kubeui/internal/app/resources/views/resourcetypeselection"
+	"kubeui/internal/pkg/k8s"
+	"kubeui/internal/pkg/k8s/k8scontext"
+	"kubeui/internal/pkg/kubeui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// program contains what is needed to create the views of the program.
+type program struct {
+	contextClient k8scontext.Client
+	k8sService    k8s.Service
+}
+
+// NewModel creates a new model.
+func NewModel(contextClient k8scontext.Client, k8sService k8s.Service) kubeui.Root {
+	p := program{contextClient: contextClient, k8sService: k8sService}
+
+	return kubeui.NewRoot(kubeui.RootOptions{
+		Context: kubeui.Context{
+			Namespace: "default",
+		},
+		Start:    p.start,
+		NewView:  p.newView,
+		Commands: common.Commands("resource_type_selection", "namespace_selection"),
+	})
+}
+
+// start uses the namespace of the current context.
+func (p program) start(c kubeui.Context) (kubeui.Context, tea.Cmd) {
+	currentContext, ok := p.contextClient.CurrentApiContext()
+
+	if !ok {
+		return c, kubeui.Error(fmt.Errorf("invalid context"))
+	}
+
+	if currentContext.Namespace != "" {
+		c.Namespace = currentContext.Namespace
+	}
+
+	return c, kubeui.PushView("resource_type_selection", true)
+}
+
+// newView creates the view with the given id.
+func (p program) newView(id string, config kubeui.ViewConfig) kubeui.View {
+	switch id {
+	case "resource_type_selection":
+		return resourcetypeselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "resource_selection":
+		return resourceselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "resource_info":
+		return resourceinfo.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "namespace_selection":
+		return namespaceselection.New(p.k8sService, p.contextClient, namespaceReturnViewId(config.ParentId), false, config.WindowWidth, config.WindowHeight)
+	case kubeui.ErrorViewId:
+		return errorinfo.New(config.Err.Error(), kubeui.ErrorDetails(config.Err), config.WindowWidth, config.WindowHeight)
+	}
+
+	return resourcetypeselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+}
+
+// namespaceReturnViewId returns the id of the view to return to from the namespace selection, which is the view it was opened from.
+func namespaceReturnViewId(parentId string) string {
+	if parentId == "" {
+		return "resource_type_selection"
+	}
+
+	return parentId
+}
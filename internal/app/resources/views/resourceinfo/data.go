@@ -0,0 +1,54 @@
+package resourceinfo
+
+import (
+	"fmt"
+
+	"kubeui/internal/pkg/yamlcolor"
+
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// maskedValue replaces the values of secrets that have not been revealed.
+const maskedValue = "••••••••"
+
+// renderManifest renders the manifest of a resource as yaml with syntax highlighting.
+// The values of secrets are masked unless reveal is set, and the managed fields are left out unless showManagedFields is set,
+// since they are rarely of interest and very verbose.
+func renderManifest(maxWidth int, object *unstructured.Unstructured, reveal, showManagedFields bool) (string, error) {
+	object = object.DeepCopy()
+
+	if !showManagedFields {
+		unstructured.RemoveNestedField(object.Object, "metadata", "managedFields")
+	}
+
+	if isSecret(object) && !reveal {
+		maskSecretValues(object)
+	}
+
+	manifest, err := yamlcolor.Marshal(object.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to render resource manifest: %v", err)
+	}
+
+	return lipgloss.NewStyle().Width(maxWidth).Render(string(manifest)), nil
+}
+
+// isSecret reports whether an object is a core secret, whose values must be masked until they are revealed.
+func isSecret(object *unstructured.Unstructured) bool {
+	return object.GetAPIVersion() == "v1" && object.GetKind() == "Secret"
+}
+
+// maskSecretValues replaces the values of the data and stringData fields of a secret, leaving the keys visible.
+func maskSecretValues(object *unstructured.Unstructured) {
+	for _, field := range []string{"data", "stringData"} {
+		values, ok := object.Object[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for key := range values {
+			values[key] = maskedValue
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package resourceinfo
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeui/internal/pkg/k8s/resources"
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/styles"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/integer"
+)
+
+// keyMap defines the keys that are handled by this view.
+type keyMap struct {
+	kubeui.GlobalKeyMap
+	Reveal              key.Binding
+	ToggleManagedFields key.Binding
+}
+
+// newKeyMap defines the actual key bindings and creates a keyMap.
+func newKeyMap() *keyMap {
+	return &keyMap{
+		GlobalKeyMap: kubeui.NewGlobalKeyMap(),
+		Reveal: key.NewBinding(
+			key.WithKeys("ctrl+v"),
+			key.WithHelp("ctrl+v", "Reveal/hide secret values"),
+		),
+		ToggleManagedFields: key.NewBinding(
+			key.WithKeys("ctrl+k"),
+			key.WithHelp("ctrl+k", "Show/hide managed fields"),
+		),
+	}
+}
+
+func (v View) fullHelp() [][]key.Binding {
+	viewPortKeys := viewport.DefaultKeyMap()
+
+	return [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView, v.keys.Reveal, v.keys.ToggleManagedFields},
+		{
+			viewPortKeys.Up,
+			viewPortKeys.Down,
+			viewPortKeys.PageUp,
+			viewPortKeys.PageDown,
+			viewPortKeys.HalfPageUp,
+			viewPortKeys.HalfPageDown,
+		},
+	}
+}
+
+// K8sService represents the interface towards kubernetes needed by this view.
+type K8sService interface {
+	GetResource(resource resources.Resource, namespace, name string) (*unstructured.Unstructured, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// View displays the manifest of a resource of any type.
+type View struct {
+	keys *keyMap
+
+	// Viewport for scrolling the manifest.
+	manifestViewPort viewport.Model
+
+	windowWidth  int
+	windowHeight int
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	object *unstructured.Unstructured
+
+	// The values of secrets are masked until they are revealed by the user.
+	reveal bool
+
+	// Show the managed fields in the manifest or not.
+	showManagedFields bool
+
+	// Kubernetes client.
+	k8sClient K8sService
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(k8sClient K8sService, contextClient ContextClient, windowWidth, windowHeight int) View {
+	return View{
+		k8sClient:     k8sClient,
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          newKeyMap(),
+	}
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	// Keys
+	switch {
+
+	case msg.IsWindowResize():
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+		v = v.updateViewPort(c)
+		return c, v, nil
+
+	case msg.MatchesKeyBindings(v.keys.Quit):
+		return c, v, kubeui.Exit()
+
+	case msg.MatchesKeyBindings(v.keys.ExitView):
+		return c, v, kubeui.PopView(false)
+
+	case msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp:
+		v.showFullHelp = true
+		return c, v, nil
+
+	case msg.MatchesKeyBindings(v.keys.Refresh):
+		return c, v, v.Init(c)
+
+	case msg.MatchesKeyBindings(v.keys.Reveal):
+		v.reveal = !v.reveal
+		v = v.updateViewPort(c)
+		return c, v, nil
+
+	case msg.MatchesKeyBindings(v.keys.ToggleManagedFields):
+		v.showManagedFields = !v.showManagedFields
+		v = v.updateViewPort(c)
+		return c, v, nil
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+	case k8smsg.GetResourceMsg:
+		v.object = t.Object
+		v = v.updateViewPort(c)
+
+		return c, v, nil
+	}
+
+	var cmd tea.Cmd
+	if v.object != nil {
+		v.manifestViewPort, cmd = v.manifestViewPort.Update(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+// updateViewPort resizes the viewport to fill the space below the header and renders the manifest.
+func (v View) updateViewPort(c kubeui.Context) View {
+	if v.object == nil {
+		return v
+	}
+
+	v.manifestViewPort.Width = v.windowWidth
+	v.manifestViewPort.Height = integer.IntMax(v.windowHeight-(lipgloss.Height(v.headerView(c))+lipgloss.Height(footerView(v.windowWidth, v.manifestViewPort))), 1)
+
+	manifest, err := renderManifest(v.windowWidth, v.object, v.reveal, v.showManagedFields)
+	if err != nil {
+		manifest = styles.ErrorMessage.Render(err.Error())
+	}
+
+	v.manifestViewPort.SetContent(manifest)
+
+	return v
+}
+
+// headerView renders the help and status bar displayed above the manifest.
+func (v View) headerView(c kubeui.Context) string {
+	builder := strings.Builder{}
+
+	keys := []key.Binding{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView}
+	if v.object != nil && isSecret(v.object) {
+		keys = append(keys, v.keys.Reveal)
+	}
+
+	builder.WriteString(help.Short(v.windowWidth, keys))
+	builder.WriteString("\n\n")
+
+	headerLine := header.New(v.windowWidth-1, v.contextClient, location(c))
+	builder.WriteString(headerLine + "\n")
+
+	return builder.String()
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	if v.object == nil {
+		return "Loading..."
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(v.headerView(c))
+
+	builder.WriteString(v.manifestViewPort.View())
+	builder.WriteString(footerView(v.windowWidth, v.manifestViewPort))
+
+	return builder.String()
+}
+
+// location returns the location of the resource, the namespace is left out for resources that are not namespaced.
+func location(c kubeui.Context) header.Location {
+	return header.Location{Namespace: c.SelectedResourceNamespace, Path: []string{c.SelectedResourceType.Name(), c.SelectedResource}}
+}
+
+// footerView creates the footerView which contains information about how far the user has scrolled through the viewPort.
+func footerView(width int, viewPort viewport.Model) string {
+	info := fmt.Sprintf("%3.f%%", viewPort.ScrollPercent()*100)
+	line := strings.Repeat("─", integer.IntMax(0, width-lipgloss.Width(info)))
+	return "\x1b[0m" + "\n" + lipgloss.NewStyle().Width(width).Render(lipgloss.JoinHorizontal(lipgloss.Center, line, info))
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return func() tea.Msg {
+		object, err := v.k8sClient.GetResource(c.SelectedResourceType, c.SelectedResourceNamespace, c.SelectedResource)
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewGetResourceMsg(object)
+	}
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	return nil
+}
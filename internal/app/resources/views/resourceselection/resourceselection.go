@@ -0,0 +1,244 @@
+package resourceselection
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeui/internal/pkg/component/columntable"
+	"kubeui/internal/pkg/k8s/resources"
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+	"kubeui/internal/pkg/ui/k8stable"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyMap defines the keys that are handled by this view.
+type keyMap struct {
+	kubeui.GlobalKeyMap
+	SelectNamespace key.Binding
+}
+
+// newKeyMap defines the actual key bindings and creates a keyMap.
+func newKeyMap() *keyMap {
+	return &keyMap{
+		GlobalKeyMap: kubeui.NewGlobalKeyMap(),
+		SelectNamespace: key.NewBinding(
+			key.WithKeys("ctrl+n"),
+			key.WithHelp("ctrl+n", "Select namespace"),
+		),
+	}
+}
+
+func (v View) fullHelp() [][]key.Binding {
+	bindings := [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView, v.keys.SelectNamespace},
+	}
+
+	if v.table != nil && len(v.table.Rows) > 0 {
+		bindings = append(bindings, v.resourceTable.KeyList())
+	}
+
+	return bindings
+}
+
+// K8sClient represents the interface towards kubernetes needed by this view.
+type K8sClient interface {
+	ListResources(resource resources.Resource, namespace string) (*resources.Table, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// View is used to select a resource of the selected type.
+type View struct {
+	keys *keyMap
+
+	windowWidth  int
+	windowHeight int
+
+	// Resources of the selected type in the current namespace.
+	table *resources.Table
+
+	// ColumnTable used to select a resource.
+	resourceTable columntable.Model
+
+	// Loading indicator
+	loading bool
+
+	// If the View has been initialized or not.
+	initialized bool
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	// Kubernetes client.
+	k8sClient K8sClient
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(k8sClient K8sClient, contextClient ContextClient, windowWidth, windowHeight int) View {
+	return View{
+		k8sClient:     k8sClient,
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          newKeyMap(),
+		loading:       true,
+	}
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	if msg.IsWindowResize() {
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+
+		var cmd tea.Cmd
+		if v.initialized {
+			v.resourceTable, cmd = v.resourceTable.Update(columntable.UpdateHeight{Height: v.tableHeight()})
+		}
+
+		return c, v, cmd
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp {
+		v.showFullHelp = true
+		return c, v, nil
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Quit) {
+		return c, v, kubeui.Exit()
+	}
+
+	if msg.MatchesKeyBindings(v.keys.SelectNamespace) {
+		return c, v, kubeui.PushView("namespace_selection", true)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.ExitView) {
+		return c, v, kubeui.PopView(false)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Refresh) {
+		return c, v, v.Init(c)
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+	case k8smsg.ListResourcesMsg:
+		v.table = t.Table
+		resourceColumns, resourceRows := k8stable.ResourceColumnsAndRows(v.table, false)
+		var cmd tea.Cmd
+
+		// The first time we receive a table of resources then we create a new resourceTable.
+		// Otherwise we just update it.
+		if !v.initialized {
+			v.initialized = true
+			v.resourceTable = columntable.New(resourceColumns, resourceRows, columntable.PageSize(v.tableHeight()), "", false, columntable.Options{SingularItemName: strings.ToLower(c.SelectedResourceType.Kind), StartInSearchMode: true})
+		} else {
+			v.resourceTable, cmd = v.resourceTable.Update(columntable.UpdateRowsAndColumns{Rows: resourceRows, Columns: resourceColumns})
+		}
+
+		v.loading = false
+
+		return c, v, cmd
+
+	case columntable.Selection:
+		c.SelectedResourceNamespace, c.SelectedResource = k8stable.ParseRowId(t.Id)
+		return c, v, kubeui.PushView("resource_info", true)
+	}
+
+	var cmd tea.Cmd
+	if v.initialized {
+		v.resourceTable, cmd = v.resourceTable.Update(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+// tableHeight returns the number of lines available to the table, which is displayed below the short help, an empty line and the status bar.
+func (v View) tableHeight() int {
+	// The height is not known before the first resize.
+	if v.windowHeight == 0 {
+		return 0
+	}
+
+	return v.windowHeight - 4
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.ExitView, v.keys.SelectNamespace, v.keys.Refresh}))
+	builder.WriteString("\n\n")
+
+	headerLine := header.New(v.windowWidth-1, v.contextClient, location(c))
+	builder.WriteString(headerLine + "\n")
+
+	if v.loading {
+		return "Loading..."
+	} else if len(v.table.Rows) == 0 && c.SelectedResourceType.Namespaced {
+		builder.WriteString(fmt.Sprintf("No %s found in namespace %s", c.SelectedResourceType.Name(), c.Namespace))
+	} else if len(v.table.Rows) == 0 {
+		builder.WriteString(fmt.Sprintf("No %s found", c.SelectedResourceType.Name()))
+	} else {
+		builder.WriteString(v.resourceTable.View())
+	}
+
+	return builder.String()
+}
+
+// location returns the location of the listed resources, the namespace is left out for resources that are not namespaced.
+func location(c kubeui.Context) header.Location {
+	if !c.SelectedResourceType.Namespaced {
+		return header.Location{Path: []string{c.SelectedResourceType.Name()}}
+	}
+
+	return header.Location{Namespace: c.Namespace, Path: []string{c.SelectedResourceType.Name()}}
+}
+
+// CapturesText reports whether the view captures typed text, which it does while the resources are searched.
+func (v View) CapturesText() bool {
+	return v.resourceTable.Searching()
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return func() tea.Msg {
+		table, err := v.k8sClient.ListResources(c.SelectedResourceType, c.Namespace)
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewListResourcesMsg(table)
+	}
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	return nil
+}
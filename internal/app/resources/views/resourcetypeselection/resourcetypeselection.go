@@ -0,0 +1,231 @@
+package resourcetypeselection
+
+import (
+	"strings"
+
+	"kubeui/internal/pkg/component/searchtable"
+	"kubeui/internal/pkg/k8s/resources"
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/life4/genesis/slices"
+)
+
+// keyMap defines the keys that are handled by this view.
+type keyMap struct {
+	kubeui.GlobalKeyMap
+	SelectNamespace key.Binding
+}
+
+// newKeyMap defines the actual key bindings and creates a keyMap.
+func newKeyMap() *keyMap {
+	return &keyMap{
+		GlobalKeyMap: kubeui.NewGlobalKeyMap(),
+		SelectNamespace: key.NewBinding(
+			key.WithKeys("ctrl+n"),
+			key.WithHelp("ctrl+n", "Select namespace"),
+		),
+	}
+}
+
+func (v View) fullHelp() [][]key.Binding {
+	bindings := [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.SelectNamespace},
+	}
+
+	if v.initialized {
+		bindings = append(bindings, v.resourceTable.KeyList())
+	}
+
+	return bindings
+}
+
+// K8sClient represents the interface towards kubernetes needed by this view.
+type K8sClient interface {
+	ListResourceTypes() ([]resources.Resource, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// View allows the user to select a type of resource, including custom resources.
+type View struct {
+	windowHeight int
+	windowWidth  int
+
+	keys *keyMap
+
+	// Types of resources served by the cluster.
+	resourceTypes []resources.Resource
+
+	// SearchTable used to select a type of resource.
+	resourceTable searchtable.Model
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	// If the View has been initialized or not.
+	initialized bool
+
+	// Kubernetes client.
+	k8sClient K8sClient
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(k8sClient K8sClient, contextClient ContextClient, windowWidth, windowHeight int) View {
+	return View{
+		k8sClient:     k8sClient,
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          newKeyMap(),
+	}
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsWindowResize() {
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+
+		var cmd tea.Cmd
+		if v.initialized {
+			v.resourceTable, cmd = v.resourceTable.Update(searchtable.UpdateHeight{Height: v.tableHeight()})
+		}
+
+		return c, v, cmd
+	}
+
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp {
+		v.showFullHelp = true
+		return c, v, nil
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Quit) {
+		return c, v, kubeui.Exit()
+	}
+
+	if msg.MatchesKeyBindings(v.keys.SelectNamespace) {
+		return c, v, kubeui.PushView("namespace_selection", true)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Refresh) {
+		v.initialized = false
+		return c, v, v.Init(c)
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+
+	case k8smsg.ListResourceTypesMsg:
+		v.resourceTypes = t.Resources
+		v.resourceTable = searchtable.New(
+			slices.Map(v.resourceTypes, func(r resources.Resource) string { return r.Name() }),
+			searchtable.PageSize(v.tableHeight()),
+			c.SelectedResourceType.Name(),
+			false,
+			searchtable.Options{
+				SingularItemName:  "resource type",
+				StartInSearchMode: true,
+			},
+		)
+		v.initialized = true
+		return c, v, nil
+
+	case searchtable.Selection:
+		for _, resourceType := range v.resourceTypes {
+			if resourceType.Name() == t.Value {
+				c.SelectedResourceType = resourceType
+				return c, v, kubeui.PushView("resource_selection", true)
+			}
+		}
+
+		return c, v, nil
+	}
+
+	var cmd tea.Cmd
+	if v.initialized {
+		v.resourceTable, cmd = v.resourceTable.Update(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+// tableHeight returns the number of lines available to the table, which is displayed below the short help, an empty line and the status bar.
+func (v View) tableHeight() int {
+	// The height is not known before the first resize.
+	if v.windowHeight == 0 {
+		return 0
+	}
+
+	return v.windowHeight - 4
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	if !v.initialized {
+		return "Loading..."
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.SelectNamespace, v.keys.Refresh}))
+	builder.WriteString("\n\n")
+
+	headerLine := header.New(v.windowWidth-1, v.contextClient, header.Location{Namespace: c.Namespace, Path: []string{"resources"}})
+	builder.WriteString(headerLine + "\n")
+
+	builder.WriteString(v.resourceTable.View())
+
+	return builder.String()
+}
+
+// CapturesText reports whether the view captures typed text, which it does while the resource types are searched.
+func (v View) CapturesText() bool {
+	return v.resourceTable.Searching()
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	if v.initialized {
+		return nil
+	}
+
+	return func() tea.Msg {
+		resourceTypes, err := v.k8sClient.ListResourceTypes()
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewListResourceTypesMsg(resourceTypes)
+	}
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	return nil
+}
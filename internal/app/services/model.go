@@ -0,0 +1,87 @@
+package services
+
+import (
+	"fmt"
+
+	"kubeui/internal/app/common"
+	"kubeui/internal/app/pods/views/errorinfo"
+	"kubeui/internal/app/pods/views/namespaceselection"
+	"kubeui/internal/app/pods/views/podinfo"
+	"kubeui/internal/app/pods/views/portforwards"
+	"kubeui/internal/app/services/views/ingressselection"
+	"kubeui/internal/app/services/views/serviceinfo"
+	"kubeui/internal/app/services/views/servicepods"
+	"kubeui/internal/app/services/views/serviceselection"
+	"kubeui/internal/pkg/k8s"
+	"kubeui/internal/pkg/k8s/k8scontext"
+	"kubeui/internal/pkg/k8s/portforward"
+	"kubeui/internal/pkg/kubeui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// program contains what is needed to create the views of the program.
+type program struct {
+	contextClient k8scontext.Client
+	k8sService    k8s.Service
+
+	// portForwards keeps track of the port forwards running in the background.
+	portForwards *portforward.Manager
+}
+
+// NewModel creates a new model.
+func NewModel(contextClient k8scontext.Client, k8sService k8s.Service, portForwards *portforward.Manager) kubeui.Root {
+	p := program{contextClient: contextClient, k8sService: k8sService, portForwards: portForwards}
+
+	return kubeui.NewRoot(kubeui.RootOptions{
+		Context: kubeui.Context{
+			Namespace: "default",
+		},
+		Start:    p.start,
+		NewView:  p.newView,
+		Commands: common.Commands("service_selection", "ingress_selection", "port_forwards", "namespace_selection"),
+	})
+}
+
+// start uses the namespace of the current context, the user selects a namespace first if the context has no namespace.
+func (p program) start(c kubeui.Context) (kubeui.Context, tea.Cmd) {
+	currentContext, ok := p.contextClient.CurrentApiContext()
+
+	if !ok {
+		return c, kubeui.Error(fmt.Errorf("invalid context"))
+	}
+
+	if currentContext.Namespace != "" {
+		c.Namespace = currentContext.Namespace
+	}
+
+	if c.Namespace == "default" {
+		return c, kubeui.PushView("namespace_selection", true)
+	}
+
+	return c, kubeui.PushView("service_selection", true)
+}
+
+// newView creates the view with the given id.
+func (p program) newView(id string, config kubeui.ViewConfig) kubeui.View {
+	switch id {
+	case "service_selection":
+		return serviceselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "service_info":
+		return serviceinfo.New(p.k8sService, p.contextClient, p.portForwards, config.WindowWidth, config.WindowHeight)
+	case "port_forwards":
+		return portforwards.New(p.portForwards, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "service_pods":
+		return servicepods.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "ingress_selection":
+		return ingressselection.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case "namespace_selection":
+		return namespaceselection.New(p.k8sService, p.contextClient, "service_selection", false, config.WindowWidth, config.WindowHeight)
+	case "pod_info":
+		return podinfo.New(p.k8sService, p.contextClient, config.WindowWidth, config.WindowHeight)
+	case kubeui.ErrorViewId:
+		return errorinfo.New(config.Err.Error(), kubeui.ErrorDetails(config.Err), config.WindowWidth, config.WindowHeight)
+	}
+
+	return namespaceselection.New(p.k8sService, p.contextClient, "service_selection", false, config.WindowWidth, config.WindowHeight)
+}
@@ -0,0 +1,241 @@
+package ingressselection
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"kubeui/internal/pkg/component/columntable"
+	"kubeui/internal/pkg/k8s/ingresses"
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+	"kubeui/internal/pkg/ui/k8stable"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyMap defines the keys that are handled by this view.
+type keyMap struct {
+	kubeui.GlobalKeyMap
+	ShowServices key.Binding
+}
+
+// newKeyMap defines the actual key bindings and creates a keyMap.
+func newKeyMap() *keyMap {
+	return &keyMap{
+		GlobalKeyMap: kubeui.NewGlobalKeyMap(),
+		ShowServices: key.NewBinding(
+			key.WithKeys("ctrl+w"),
+			key.WithHelp("ctrl+w", "Show services"),
+		),
+	}
+}
+
+func (v View) fullHelp() [][]key.Binding {
+	bindings := [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView, v.keys.ShowServices},
+	}
+
+	if len(v.routes) > 0 {
+		bindings = append(bindings, v.routeTable.KeyList())
+	}
+
+	return bindings
+}
+
+// K8sClient represents the interface towards kubernetes needed by this view.
+type K8sClient interface {
+	ListIngressRoutes(namespace string) ([]ingresses.Route, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// View lists the routes of the ingresses in a namespace, a route can be selected to inspect its backing service.
+type View struct {
+	keys *keyMap
+
+	windowWidth  int
+	windowHeight int
+
+	// Routes of the ingresses in current namespace.
+	routes []ingresses.Route
+
+	// ColumnTable used to select a route.
+	routeTable columntable.Model
+
+	// Loading indicator
+	loading bool
+
+	// If the View has been initialized or not.
+	initialized bool
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	// Kubernetes client.
+	k8sClient K8sClient
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(k8sClient K8sClient, contextClient ContextClient, windowWidth, windowHeight int) View {
+	return View{
+		k8sClient:     k8sClient,
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          newKeyMap(),
+		loading:       true,
+	}
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	if msg.IsWindowResize() {
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+
+		var cmd tea.Cmd
+		if v.initialized {
+			v.routeTable, cmd = v.routeTable.Update(columntable.UpdateHeight{Height: v.tableHeight()})
+		}
+
+		return c, v, cmd
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp {
+		v.showFullHelp = true
+		return c, v, nil
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Quit) {
+		return c, v, kubeui.Exit()
+	}
+
+	if msg.MatchesKeyBindings(v.keys.ExitView) {
+		return c, v, kubeui.PopView(false)
+	}
+
+	// The view is returned to if it is already in the navigation stack.
+	if msg.MatchesKeyBindings(v.keys.ShowServices) {
+		return c, v, kubeui.PushView("service_selection", false)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Refresh) {
+		return c, v, v.Init(c)
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+	case k8smsg.ListIngressRoutesMsg:
+		v.routes = t.Routes
+		routeColumns, routeRows := k8stable.IngressRouteColumnsAndRows(v.routes)
+		var cmd tea.Cmd
+
+		// The first time we receive a list of routes then we create a new routeTable.
+		// Otherwise we just update it.
+		if !v.initialized {
+			v.initialized = true
+			v.routeTable = columntable.New(routeColumns, routeRows, columntable.PageSize(v.tableHeight()), "", false, columntable.Options{SingularItemName: "route", StartInSearchMode: true})
+		} else {
+			v.routeTable, cmd = v.routeTable.Update(columntable.UpdateRowsAndColumns{Rows: routeRows, Columns: routeColumns})
+		}
+
+		v.loading = false
+
+		return c, v, cmd
+
+	case columntable.Selection:
+		// Only routes with a service as backend can be inspected.
+		i, err := strconv.Atoi(t.Id)
+		if err != nil || i >= len(v.routes) || v.routes[i].Service == "" {
+			return c, v, nil
+		}
+
+		c.SelectedService = v.routes[i].Service
+		return c, v, kubeui.PushView("service_info", true)
+	}
+
+	var cmd tea.Cmd
+	if v.initialized {
+		v.routeTable, cmd = v.routeTable.Update(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+// tableHeight returns the number of lines available to the table, which is displayed below the short help, an empty line and the status bar.
+func (v View) tableHeight() int {
+	// The height is not known before the first resize.
+	if v.windowHeight == 0 {
+		return 0
+	}
+
+	return v.windowHeight - 4
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.ShowServices, v.keys.Refresh}))
+	builder.WriteString("\n\n")
+
+	headerLine := header.New(v.windowWidth-1, v.contextClient, header.Location{Namespace: c.Namespace, Path: []string{"ingresses"}})
+	builder.WriteString(headerLine + "\n")
+
+	if v.loading {
+		return "Loading..."
+	} else if len(v.routes) == 0 {
+		builder.WriteString(fmt.Sprintf("No ingresses found in namespace %s", c.Namespace))
+	} else {
+		builder.WriteString(v.routeTable.View())
+	}
+
+	return builder.String()
+}
+
+// CapturesText reports whether the view captures typed text, which it does while the routes are searched.
+func (v View) CapturesText() bool {
+	return v.routeTable.Searching()
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return func() tea.Msg {
+		routes, err := v.k8sClient.ListIngressRoutes(c.Namespace)
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewListIngressRoutesMsg(routes)
+	}
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	return nil
+}
@@ -0,0 +1,126 @@
+package serviceinfo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"kubeui/internal/pkg/k8s"
+	"kubeui/internal/pkg/k8s/services"
+	"kubeui/internal/pkg/ui/table"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/life4/genesis/slices"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/integer"
+)
+
+// serviceStatusColumnsAndRows creates the neccessary columns and row in order to display service status information.
+func serviceStatusColumnsAndRows(service v1.Service) ([]table.DataColumn, table.DataRow) {
+	serviceColumns := []table.DataColumn{
+		{Desc: "Name", Width: 6},
+		{Desc: "Type", Width: 6},
+		{Desc: "Cluster IP", Width: 12},
+		{Desc: "External IP", Width: 13},
+		{Desc: "Ports", Width: 7},
+		{Desc: "Selector", Width: 10},
+		{Desc: "Age", Width: 3},
+	}
+
+	serviceFormat := k8s.NewListServiceFormat(service, time.Now())
+
+	values := []string{serviceFormat.Name, serviceFormat.Type, serviceFormat.ClusterIP, serviceFormat.ExternalIPs, serviceFormat.Ports, serviceFormat.Selector, serviceFormat.Age}
+
+	for i, value := range values {
+		padding := 2
+		if i == len(values)-1 {
+			padding = 0
+		}
+
+		serviceColumns[i].Width = integer.IntMax(serviceColumns[i].Width, len(value)+padding)
+	}
+
+	return serviceColumns, table.DataRow{Values: values}
+}
+
+// endpointColumnsAndRows creates the neccessary columns and rows in order to display the endpoints of a service.
+func endpointColumnsAndRows(maxWidth int, endpoints []services.Endpoint) ([]table.DataColumn, []table.DataRow) {
+	endpointColumns := []table.DataColumn{
+		{Desc: "Pod", Width: 5},
+		{Desc: "Status", Width: 8},
+		{Desc: "Addresses", Width: 11},
+		{Desc: "Node", Width: 6},
+		{Desc: "Ports", Width: 20},
+	}
+
+	endpointRows := slices.Map(endpoints, func(e services.Endpoint) table.DataRow {
+		pod := e.PodName
+		if pod == "" {
+			pod = "<none>"
+		}
+
+		addresses := strings.Join(e.Addresses, ",")
+		ports := strings.Join(e.Ports, ",")
+
+		endpointColumns[0].Width = integer.IntMax(endpointColumns[0].Width, len(pod)+2)
+		endpointColumns[1].Width = integer.IntMax(endpointColumns[1].Width, len(e.Status())+2)
+		endpointColumns[2].Width = integer.IntMax(endpointColumns[2].Width, len(addresses)+2)
+		endpointColumns[3].Width = integer.IntMax(endpointColumns[3].Width, len(e.NodeName)+2)
+
+		remainingWidth := maxWidth - slices.Reduce(endpointColumns[0:4], 0, func(c table.DataColumn, acc int) int {
+			return acc + c.Width
+		})
+
+		endpointColumns[4].Width = integer.IntMax(integer.IntMax(remainingWidth-1, len(ports)), 20)
+
+		return table.DataRow{
+			Values: []string{pod, e.Status(), addresses, e.NodeName, ports},
+		}
+	})
+
+	return endpointColumns, endpointRows
+}
+
+// endpointsContent renders the endpoints of a service, or an explanation of why the service has no endpoints.
+func endpointsContent(maxWidth int, service *services.Service) string {
+	endpoints := services.Endpoints(service.EndpointSlices)
+
+	if len(endpoints) > 0 {
+		return table.RowsToString(endpointColumnsAndRows(maxWidth, endpoints))
+	}
+
+	return lipgloss.NewStyle().Width(maxWidth).Render(noEndpointsReason(service.Service))
+}
+
+// endpointsSummary summarizes the state of the endpoints of a service, with a hint about the likely cause if none of them are ready.
+func endpointsSummary(service *services.Service) string {
+	endpoints := services.Endpoints(service.EndpointSlices)
+
+	if len(endpoints) == 0 {
+		return "Endpoints: <none>\n" + noEndpointsReason(service.Service)
+	}
+
+	ready := len(slices.Filter(endpoints, func(e services.Endpoint) bool { return e.Status() == "Ready" }))
+	terminating := len(slices.Filter(endpoints, func(e services.Endpoint) bool { return e.Status() == "Terminating" }))
+	notReady := len(endpoints) - ready - terminating
+
+	summary := fmt.Sprintf("Endpoints: %d ready, %d not ready, %d terminating", ready, notReady, terminating)
+
+	if ready == 0 {
+		summary += "\nNone of the endpoints are ready, so no traffic is sent to them. Check the readiness of the backing pods."
+	}
+
+	return summary
+}
+
+// noEndpointsReason explains why a service has no endpoints.
+func noEndpointsReason(service v1.Service) string {
+	switch {
+	case service.Spec.Type == v1.ServiceTypeExternalName:
+		return fmt.Sprintf("Services of type ExternalName have no endpoints, the service name resolves to %s.", service.Spec.ExternalName)
+	case len(service.Spec.Selector) == 0:
+		return "The service has no selector, so its endpoints are not managed by kubernetes and have to be created separately."
+	}
+
+	return fmt.Sprintf("No pods matching the selector %s have been assigned an address in namespace %s.", k8s.NewListServiceFormat(service, time.Now()).Selector, service.Namespace)
+}
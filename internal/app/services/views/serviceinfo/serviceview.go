@@ -0,0 +1,483 @@
+package serviceinfo
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeui/internal/pkg/component/numberinput"
+	"kubeui/internal/pkg/k8s/services"
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+	"kubeui/internal/pkg/ui/k8stable"
+	"kubeui/internal/pkg/ui/selection"
+	"kubeui/internal/pkg/ui/table"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/utils/integer"
+)
+
+// keyMap defines the keys that are handled by this view.
+type keyMap struct {
+	kubeui.GlobalKeyMap
+	Left             key.Binding
+	Right            key.Binding
+	ShowPods         key.Binding
+	PortForward      key.Binding
+	ShowPortForwards key.Binding
+}
+
+// newKeyMap defines the actual key bindings and creates a keyMap.
+func newKeyMap() *keyMap {
+	return &keyMap{
+		GlobalKeyMap: kubeui.NewGlobalKeyMap(),
+		Left: key.NewBinding(
+			key.WithKeys("left"),
+			key.WithHelp("left", "Move cursor left one position"),
+		),
+		Right: key.NewBinding(
+			key.WithKeys("right"),
+			key.WithHelp("right", "Move cursor right one position"),
+		),
+		ShowPods: key.NewBinding(
+			key.WithKeys("ctrl+w"),
+			key.WithHelp("ctrl+w", "Show backing pods"),
+		),
+		PortForward: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("ctrl+p", "Port forward the service"),
+		),
+		ShowPortForwards: key.NewBinding(
+			key.WithKeys("ctrl+g"),
+			key.WithHelp("ctrl+g", "Show port forwards"),
+		),
+	}
+}
+
+func (v View) fullHelp() [][]key.Binding {
+	bindings := [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView, v.keys.ShowPods, v.keys.PortForward, v.keys.ShowPortForwards},
+	}
+
+	viewPortKeys := viewport.DefaultKeyMap()
+
+	bindings = append(bindings, []key.Binding{
+		v.keys.Left,
+		v.keys.Right,
+		viewPortKeys.Up,
+		viewPortKeys.Down,
+		viewPortKeys.PageUp,
+		viewPortKeys.PageDown,
+		viewPortKeys.HalfPageUp,
+		viewPortKeys.HalfPageDown,
+	})
+
+	return bindings
+}
+
+// K8sService represents the interface towards kubernetes needed by this view.
+type K8sService interface {
+	GetService(namespace, name string) (*services.Service, error)
+}
+
+// PortForwarder represents the interface used to start port forwards that run in the background.
+type PortForwarder interface {
+	Start(namespace, pod string, localPort, remotePort int) (string, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// View displays service information, including the endpoints backing the service.
+type View struct {
+	keys *keyMap
+
+	tab  tab
+	tabs []string
+
+	// Indicates whether the service has been loaded or not.
+	initialized bool
+
+	// Viewports for scrolling content
+	endpointsViewPort viewport.Model
+	labelsViewPort    viewport.Model
+	eventsViewPort    viewport.Model
+
+	windowWidth  int
+	windowHeight int
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	service *services.Service
+
+	// Input used to choose the ports of a port forward.
+	activeInput *numberinput.Model
+	// The pod that the chosen service port is forwarded to, nil while the service port is being chosen.
+	forwardTarget *services.ForwardTarget
+
+	// Kubernetes client.
+	k8sClient K8sService
+
+	// KubeContext client.
+	contextClient ContextClient
+
+	// Starts port forwards to the pods backing the service.
+	portForwarder PortForwarder
+}
+
+// New creates a new View.
+func New(k8sClient K8sService, contextClient ContextClient, portForwarder PortForwarder, windowWidth, windowHeight int) View {
+	return View{
+		k8sClient:     k8sClient,
+		contextClient: contextClient,
+		portForwarder: portForwarder,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          newKeyMap(),
+		tabs:          []string{STATUS.String(), ENDPOINTS.String(), LABELS.String(), EVENTS.String()},
+	}
+}
+
+// tab defines the different tabs of the component.
+type tab int
+
+const (
+	// STATUS is used to display status information about the service.
+	STATUS tab = iota
+	// ENDPOINTS is used to display the endpoints backing the service.
+	ENDPOINTS
+	// LABELS is used to display the labels set for the service.
+	LABELS
+	// EVENTS is used to display the latest events for the service.
+	EVENTS
+)
+
+// String implements the stringer interface for tab.
+func (t tab) String() string {
+	switch t {
+	case STATUS:
+		return "STATUS"
+	case ENDPOINTS:
+		return "ENDPOINTS"
+	case LABELS:
+		return "LABELS"
+	case EVENTS:
+		return "EVENTS"
+	}
+	return "UNKNOWN"
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	// Keys
+	switch {
+
+	case msg.IsWindowResize():
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+		v = v.updateViewportsAfterResize()
+		return c, v, nil
+
+	case msg.MatchesKeyBindings(v.keys.Quit):
+		return c, v, kubeui.Exit()
+
+	// Key presses are handled by the input while it is displayed.
+	case msg.IsKeyMsg() && v.activeInput != nil:
+		input, cmd := v.activeInput.Update(msg.TeaMsg)
+		v.activeInput = &input
+		return c, v, cmd
+
+	case msg.MatchesKeyBindings(v.keys.ExitView):
+		return c, v, kubeui.PopView(false)
+
+	case msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp:
+		v.showFullHelp = true
+		return c, v, nil
+
+	case msg.MatchesKeyBindings(v.keys.ShowPods):
+		return c, v, kubeui.PushView("service_pods", true)
+
+	case msg.MatchesKeyBindings(v.keys.ShowPortForwards):
+		return c, v, kubeui.PushView("port_forwards", true)
+
+	case msg.MatchesKeyBindings(v.keys.PortForward) && v.service != nil:
+		input := numberinput.New(v.service.Service.Name, fmt.Sprintf("Port of service %s to forward", v.service.Service.Name), defaultServicePort(v.service), numberinput.Options{Min: 1, Max: 65535})
+		v.activeInput = &input
+		v.forwardTarget = nil
+		return c, v, input.Init()
+
+	case msg.MatchesKeyBindings(v.keys.Left):
+		v = v.moveTabLeft()
+		return c, v, nil
+	case msg.MatchesKeyBindings(v.keys.Right):
+		v = v.moveTabRight()
+		return c, v, nil
+	case msg.MatchesKeyBindings(v.keys.Refresh):
+		return c, v, v.Init(c)
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+	case k8smsg.GetServiceMsg:
+
+		if !v.initialized {
+			v.initialized = true
+		}
+
+		v.service = t.Service
+		v = v.updateViewportsAfterResize()
+
+		return c, v, nil
+
+	// The service port is chosen first and resolved to a backing pod, followed by the local port after which the forward is started.
+	case numberinput.Submission:
+		if v.forwardTarget == nil {
+			target, err := services.FindForwardTarget(*v.service, t.Value)
+			if err != nil {
+				v.activeInput = nil
+				return c, v, kubeui.Error(err)
+			}
+
+			v.forwardTarget = &target
+			input := numberinput.New(t.Id, fmt.Sprintf("Local port to forward to port %d of service %s", t.Value, t.Id), t.Value, numberinput.Options{Min: 1, Max: 65535})
+			v.activeInput = &input
+			return c, v, input.Init()
+		}
+
+		target := *v.forwardTarget
+		v.activeInput = nil
+		v.forwardTarget = nil
+
+		_, err := v.portForwarder.Start(target.Namespace, target.Pod, t.Value, target.Port)
+		if err != nil {
+			return c, v, kubeui.Error(err)
+		}
+
+		return c, v, kubeui.PushView("port_forwards", true)
+
+	case numberinput.Cancellation:
+		v.activeInput = nil
+		v.forwardTarget = nil
+		return c, v, nil
+	}
+
+	// Update viewports.
+	var cmd tea.Cmd
+	if v.initialized {
+		v, cmd = v.updateViewports(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+func (v View) updateViewportsAfterResize() View {
+	if v.service == nil {
+		return v
+	}
+
+	v.endpointsViewPort.Height = v.windowHeight - (lipgloss.Height(v.headerView(v.windowWidth, ENDPOINTS)) + lipgloss.Height(footerView(v.windowWidth, v.endpointsViewPort)))
+	v.endpointsViewPort.Width = v.windowWidth
+
+	v.labelsViewPort.Height = v.windowHeight - (lipgloss.Height(v.headerView(v.windowWidth, LABELS)) + lipgloss.Height(footerView(v.windowWidth, v.labelsViewPort)))
+	v.labelsViewPort.Width = v.windowWidth
+
+	v.eventsViewPort.Height = v.windowHeight - (lipgloss.Height(v.headerView(v.windowWidth, EVENTS)) + lipgloss.Height(footerView(v.windowWidth, v.eventsViewPort)))
+	v.eventsViewPort.Width = v.windowWidth
+
+	if v.endpointsViewPort.Height > 0 {
+		v.endpointsViewPort.SetContent(endpointsContent(v.windowWidth, v.service))
+	}
+
+	if v.labelsViewPort.Height > 0 {
+		v.labelsViewPort.SetContent(table.RowsToString(table.StringMapColumnsAndRows(v.windowWidth, "Key", "Value", v.service.Service.Labels)))
+	}
+
+	if v.eventsViewPort.Height > 0 {
+		v.eventsViewPort.SetContent(table.RowsToString(k8stable.EventColumnsAndRows(v.windowWidth, v.service.Events)))
+	}
+
+	return v
+}
+
+// updateViewports updates the currently active viewport.
+func (v View) updateViewports(msg tea.Msg) (View, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch v.tab {
+	case ENDPOINTS:
+		v.endpointsViewPort, cmd = v.endpointsViewPort.Update(msg)
+	case LABELS:
+		v.labelsViewPort, cmd = v.labelsViewPort.Update(msg)
+	case EVENTS:
+		v.eventsViewPort, cmd = v.eventsViewPort.Update(msg)
+	}
+
+	return v, cmd
+}
+
+func (v View) moveTabLeft() View {
+	if v.tab > 0 {
+		v.tab--
+	} else {
+		v.tab = tab(len(v.tabs) - 1)
+	}
+
+	return v
+}
+
+func (v View) moveTabRight() View {
+	if v.tab < tab(len(v.tabs)-1) {
+		v.tab++
+	} else {
+		v.tab = 0
+	}
+
+	return v
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	if v.activeInput != nil {
+		return help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit}) + "\n\n" + v.activeInput.View()
+	}
+
+	builder := strings.Builder{}
+	header := v.headerView(v.windowWidth, v.tab)
+	builder.WriteString(header)
+
+	if v.service == nil {
+		return builder.String()
+	}
+
+	switch v.tab {
+	case STATUS:
+		columns, row := serviceStatusColumnsAndRows(v.service.Service)
+		builder.WriteString(table.RowsToString(columns, []table.DataRow{row}))
+		builder.WriteString("\n\n" + endpointsSummary(v.service))
+		return builder.String()
+
+	case ENDPOINTS:
+		footer := footerView(v.windowWidth, v.endpointsViewPort)
+		builder.WriteString(v.endpointsViewPort.View())
+		builder.WriteString(footer)
+
+	case LABELS:
+		footer := footerView(v.windowWidth, v.labelsViewPort)
+		builder.WriteString(v.labelsViewPort.View())
+		builder.WriteString(footer)
+
+	case EVENTS:
+		footer := footerView(v.windowWidth, v.eventsViewPort)
+		builder.WriteString(v.eventsViewPort.View())
+		builder.WriteString(footer)
+	}
+
+	return builder.String()
+}
+
+func (v View) headerView(width int, forTab tab) string {
+	if v.service == nil {
+		return "Loading..."
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(width, []key.Binding{
+		v.keys.Help,
+		v.keys.Quit,
+		v.keys.Refresh,
+		v.keys.ShowPods,
+		v.keys.PortForward,
+		v.keys.Left,
+		v.keys.Right,
+	}))
+
+	builder.WriteString("\n\n")
+
+	builder.WriteString(header.New(width-1, v.contextClient, header.Location{Namespace: v.service.Service.Namespace, Path: []string{"services", v.service.Service.Name}}) + "\n")
+
+	builder.WriteString(selection.Tabs(int(forTab), width, v.tabs) + "\n\n")
+
+	builder.WriteString(tableHeaderView(width, forTab, *v.service))
+
+	return builder.String()
+}
+
+// tableHeaderView creates the table header view.
+// Producing table headers seperately from the rows allows us to let the content scroll past the headers without hiding them.
+func tableHeaderView(width int, t tab, service services.Service) string {
+	var columns []table.DataColumn
+	switch t {
+	case STATUS:
+		columns, _ = serviceStatusColumnsAndRows(service.Service)
+	case ENDPOINTS:
+		columns, _ = endpointColumnsAndRows(width, services.Endpoints(service.EndpointSlices))
+	case LABELS:
+		columns, _ = table.StringMapColumnsAndRows(width, "Key", "Value", service.Service.Labels)
+	case EVENTS:
+		columns, _ = k8stable.EventColumnsAndRows(width, service.Events)
+	}
+
+	line := strings.Repeat("─", width)
+	return lipgloss.NewStyle().Width(width).Render(table.ColumnsToString(columns)) + "\n" + lipgloss.JoinHorizontal(lipgloss.Center, line) + "\n\n"
+}
+
+// footerView creates the footerView which contains information about how far the user has scrolled through the viewPort.
+func footerView(width int, viewPort viewport.Model) string {
+	info := fmt.Sprintf("%3.f%%", viewPort.ScrollPercent()*100)
+	line := strings.Repeat("─", integer.IntMax(0, width-lipgloss.Width(info)))
+	return "\x1b[0m" + "\n" + lipgloss.NewStyle().Width(width).Render(lipgloss.JoinHorizontal(lipgloss.Center, line, info))
+}
+
+// defaultServicePort returns the first port of a service, or 80 if the service has no ports.
+func defaultServicePort(service *services.Service) int {
+	if len(service.Service.Spec.Ports) == 0 {
+		return 80
+	}
+
+	return int(service.Service.Spec.Ports[0].Port)
+}
+
+// CapturesText reports whether the view captures typed text, which it does while a port is entered.
+func (v View) CapturesText() bool {
+	return v.activeInput != nil
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return func() tea.Msg {
+		service, err := v.k8sClient.GetService(c.Namespace, c.SelectedService)
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewGetServiceMsg(service)
+	}
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	return nil
+}
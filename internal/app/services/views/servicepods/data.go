@@ -0,0 +1,51 @@
+package servicepods
+
+import (
+	"strings"
+
+	"kubeui/internal/pkg/component/columntable"
+	"kubeui/internal/pkg/k8s/services"
+	"kubeui/internal/pkg/ui/k8stable"
+
+	"github.com/life4/genesis/slices"
+	"k8s.io/utils/integer"
+)
+
+// endpointColumnsAndRows creates the neccessary columns and rows for a columntable in order to display the endpoints of a service.
+// Rows of endpoints that are backed by a pod have the namespace and name of the pod as id,
+// the other rows have the addresses of the endpoint as id, which does not contain a namespace.
+func endpointColumnsAndRows(endpoints []services.Endpoint) ([]*columntable.Column, []*columntable.Row) {
+	endpointColumns := []*columntable.Column{
+		{Desc: "Pod", Width: 3},
+		{Desc: "Status", Width: 6},
+		{Desc: "Addresses", Width: 9},
+		{Desc: "Ports", Width: 5},
+		{Desc: "Node", Width: 4},
+		{Desc: "Endpoint Slice", Width: 14},
+	}
+
+	endpointRows := slices.Map(endpoints, func(e services.Endpoint) *columntable.Row {
+		pod := e.PodName
+		if pod == "" {
+			pod = "<none>"
+		}
+
+		values := []string{pod, e.Status(), strings.Join(e.Addresses, ","), strings.Join(e.Ports, ","), e.NodeName, e.Slice}
+
+		for i, value := range values {
+			endpointColumns[i].Width = integer.IntMax(endpointColumns[i].Width, len(value))
+		}
+
+		id := strings.Join(e.Addresses, ",")
+		if e.PodName != "" {
+			id = k8stable.RowId(e.PodNamespace, e.PodName)
+		}
+
+		return &columntable.Row{
+			Id:     id,
+			Values: values,
+		}
+	})
+
+	return endpointColumns, endpointRows
+}
@@ -0,0 +1,219 @@
+package servicepods
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeui/internal/pkg/component/columntable"
+	"kubeui/internal/pkg/k8s/services"
+	"kubeui/internal/pkg/k8smsg"
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+	"kubeui/internal/pkg/ui/help"
+	"kubeui/internal/pkg/ui/k8stable"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (v View) fullHelp() [][]key.Binding {
+	bindings := [][]key.Binding{
+		{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView},
+	}
+
+	if len(v.endpoints) > 0 {
+		bindings = append(bindings, v.endpointTable.KeyList())
+	}
+
+	return bindings
+}
+
+// K8sClient represents the interface towards kubernetes needed by this view.
+type K8sClient interface {
+	GetService(namespace, name string) (*services.Service, error)
+}
+
+// ContextClient represents the interface for working with kubernetes contexts that the view needs.
+type ContextClient interface {
+	header.ContextClient
+}
+
+// View is used to select one of the pods backing a service.
+type View struct {
+	keys kubeui.GlobalKeyMap
+
+	windowWidth  int
+	windowHeight int
+
+	// Endpoints of the selected service.
+	endpoints []services.Endpoint
+
+	// ColumnTable used to select the pod of an endpoint.
+	endpointTable columntable.Model
+
+	// Loading indicator
+	loading bool
+
+	// If the View has been initialized or not.
+	initialized bool
+
+	// Show full help view or not.
+	showFullHelp bool
+
+	// Kubernetes client.
+	k8sClient K8sClient
+
+	// KubeContext client.
+	contextClient ContextClient
+}
+
+// New creates a new View.
+func New(k8sClient K8sClient, contextClient ContextClient, windowWidth, windowHeight int) View {
+	return View{
+		k8sClient:     k8sClient,
+		contextClient: contextClient,
+		windowWidth:   windowWidth,
+		windowHeight:  windowHeight,
+		keys:          kubeui.NewGlobalKeyMap(),
+		loading:       true,
+	}
+}
+
+// Update handles new messages from the runtime.
+func (v View) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	if msg.IsKeyMsg() && v.showFullHelp {
+		v.showFullHelp = false
+		return c, v, nil
+	}
+
+	if msg.IsWindowResize() {
+		windowResizeMsg, ok := msg.GetWindowResizeMsg()
+
+		if !ok {
+			return c, v, nil
+		}
+
+		v.windowHeight = windowResizeMsg.Height
+		v.windowWidth = windowResizeMsg.Width
+
+		var cmd tea.Cmd
+		if v.initialized {
+			v.endpointTable, cmd = v.endpointTable.Update(columntable.UpdateHeight{Height: v.tableHeight()})
+		}
+
+		return c, v, cmd
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Help) && !v.showFullHelp {
+		v.showFullHelp = true
+		return c, v, nil
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Quit) {
+		return c, v, kubeui.Exit()
+	}
+
+	if msg.MatchesKeyBindings(v.keys.ExitView) {
+		// The service has not changed, so we don't reinitialize it when going back.
+		return c, v, kubeui.PopView(false)
+	}
+
+	if msg.MatchesKeyBindings(v.keys.Refresh) {
+		return c, v, v.Init(c)
+	}
+
+	// Results
+	switch t := msg.TeaMsg.(type) {
+	case k8smsg.GetServiceMsg:
+		v.endpoints = services.Endpoints(t.Service.EndpointSlices)
+		endpointColumns, endpointRows := endpointColumnsAndRows(v.endpoints)
+		var cmd tea.Cmd
+
+		// The first time we receive the endpoints then we create a new endpointTable.
+		// Otherwise we just update it.
+		if !v.initialized {
+			v.initialized = true
+			v.endpointTable = columntable.New(endpointColumns, endpointRows, columntable.PageSize(v.tableHeight()), "", false, columntable.Options{SingularItemName: "pod"})
+		} else {
+			v.endpointTable, cmd = v.endpointTable.Update(columntable.UpdateRowsAndColumns{Rows: endpointRows, Columns: endpointColumns})
+		}
+
+		v.loading = false
+
+		return c, v, cmd
+
+	case columntable.Selection:
+		// Endpoints that are not backed by a pod can not be inspected.
+		namespace, name := k8stable.ParseRowId(t.Id)
+		if namespace == "" {
+			return c, v, nil
+		}
+
+		c.SelectedPodNamespace, c.SelectedPod = namespace, name
+		return c, v, kubeui.PushView("pod_info", true)
+	}
+
+	var cmd tea.Cmd
+	if v.initialized {
+		v.endpointTable, cmd = v.endpointTable.Update(msg.TeaMsg)
+	}
+
+	return c, v, cmd
+}
+
+// tableHeight returns the number of lines available to the table, which is displayed below the short help, an empty line and the status bar.
+func (v View) tableHeight() int {
+	// The height is not known before the first resize.
+	if v.windowHeight == 0 {
+		return 0
+	}
+
+	return v.windowHeight - 4
+}
+
+// View renders the ui of the view.
+func (v View) View(c kubeui.Context) string {
+	if v.showFullHelp {
+		return help.Full(v.windowWidth, v.fullHelp())
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(help.Short(v.windowWidth, []key.Binding{v.keys.Help, v.keys.Quit, v.keys.Refresh, v.keys.ExitView}))
+	builder.WriteString("\n\n")
+
+	headerLine := header.New(v.windowWidth-1, v.contextClient, header.Location{Namespace: c.Namespace, Path: []string{"services", c.SelectedService, "pods"}})
+	builder.WriteString(headerLine + "\n")
+
+	if v.loading {
+		return "Loading..."
+	} else if len(v.endpoints) == 0 {
+		builder.WriteString(fmt.Sprintf("Service %s has no endpoints", c.SelectedService))
+	} else {
+		builder.WriteString(v.endpointTable.View())
+	}
+
+	return builder.String()
+}
+
+// CapturesText reports whether the view captures typed text, which it does while the pods are searched.
+func (v View) CapturesText() bool {
+	return v.endpointTable.Searching()
+}
+
+// Init initializes the view.
+func (v View) Init(c kubeui.Context) tea.Cmd {
+	return func() tea.Msg {
+		service, err := v.k8sClient.GetService(c.Namespace, c.SelectedService)
+		if err != nil {
+			return err
+		}
+
+		return k8smsg.NewGetServiceMsg(service)
+	}
+}
+
+// Destroy is called before a view is removed as the active view in the application.
+func (v View) Destroy(c kubeui.Context) tea.Cmd {
+	return nil
+}
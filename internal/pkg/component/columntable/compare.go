@@ -0,0 +1,127 @@
+package columntable
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// CompareFunc compares two values of a column, returning a negative number if a sorts before b,
+// a positive number if a sorts after b and zero if they are equal.
+type CompareFunc func(a, b string) int
+
+// CompareStrings compares values lexically, it is used for columns that have no CompareFunc.
+func CompareStrings(a, b string) int {
+	return strings.Compare(a, b)
+}
+
+// CompareNumbers compares values by the number they start with, such as "3" in "3 (5m ago)" or "1" in "1/2".
+// Values that do not start with a number sort before those that do.
+func CompareNumbers(a, b string) int {
+	numberA, okA := leadingNumber(a)
+	numberB, okB := leadingNumber(b)
+
+	switch {
+	case !okA || !okB:
+		return compareBools(okA, okB)
+	case numberA < numberB:
+		return -1
+	case numberA > numberB:
+		return 1
+	}
+
+	return 0
+}
+
+// CompareDurations compares values formatted as human readable durations, such as "45s", "5m10s", "3d" or "2y30d".
+// Values that are not durations sort before those that are.
+func CompareDurations(a, b string) int {
+	durationA, okA := parseHumanDuration(a)
+	durationB, okB := parseHumanDuration(b)
+
+	switch {
+	case !okA || !okB:
+		return compareBools(okA, okB)
+	case durationA < durationB:
+		return -1
+	case durationA > durationB:
+		return 1
+	}
+
+	return 0
+}
+
+// compareBools sorts false before true.
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	}
+
+	return 1
+}
+
+// leadingNumber parses the digits at the start of a value.
+func leadingNumber(value string) (int, bool) {
+	end := strings.IndexFunc(value, func(r rune) bool { return !unicode.IsDigit(r) })
+	if end < 0 {
+		end = len(value)
+	}
+
+	number, err := strconv.Atoi(value[:end])
+	if err != nil {
+		return 0, false
+	}
+
+	return number, true
+}
+
+// durationUnits maps the units used by human readable durations to their length.
+var durationUnits = map[rune]time.Duration{
+	'y': 365 * 24 * time.Hour,
+	'd': 24 * time.Hour,
+	'h': time.Hour,
+	'm': time.Minute,
+	's': time.Second,
+}
+
+// parseHumanDuration parses durations as formatted by duration.HumanDuration from apimachinery.
+// Such durations are rounded and can not be parsed by time.ParseDuration since they may contain days and years.
+func parseHumanDuration(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	var total time.Duration
+	number := ""
+
+	for _, r := range value {
+		if unicode.IsDigit(r) {
+			number += string(r)
+			continue
+		}
+
+		unit, ok := durationUnits[r]
+		if !ok || number == "" {
+			return 0, false
+		}
+
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, false
+		}
+
+		total += time.Duration(n) * unit
+		number = ""
+	}
+
+	// Every number has to be followed by a unit.
+	if number != "" {
+		return 0, false
+	}
+
+	return total, true
+}
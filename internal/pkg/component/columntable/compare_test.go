@@ -0,0 +1,54 @@
+package columntable_test
+
+import (
+	"testing"
+
+	"kubeui/internal/pkg/component/columntable"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareNumbers(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{"smaller number", "2", "10", -1},
+		{"larger number", "10", "2", 1},
+		{"equal numbers", "3", "3", 0},
+		{"restarts with last restart", "3 (5m ago)", "12 (1h ago)", -1},
+		{"ready count", "1/2", "2/2", -1},
+		{"not a number sorts first", "<none>", "0", -1},
+		{"neither a number", "<none>", "-", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, columntable.CompareNumbers(tt.a, tt.b))
+		})
+	}
+}
+
+func TestCompareDurations(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{"seconds and minutes", "45s", "2m", -1},
+		{"minutes and seconds combined", "5m10s", "5m", 1},
+		{"hours and days", "23h", "2d", -1},
+		{"years and days", "2y30d", "400d", 1},
+		{"equal durations", "3d", "72h", 0},
+		{"invalid sorts first", "<unknown>", "1s", -1},
+		{"number without unit is invalid", "10", "1s", -1},
+		{"empty is invalid", "", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, columntable.CompareDurations(tt.a, tt.b))
+		})
+	}
+}
@@ -3,31 +3,40 @@ package columntable
 import (
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 
+	"kubeui/internal/pkg/fuzzy"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/life4/genesis/slices"
+	"k8s.io/utils/integer"
 )
 
 var (
 	selectedPageStyle   = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "235", Dark: "252"})
 	unSelectedPageStyle = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "250", Dark: "238"})
 	highlightedStyle    = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "200", Dark: "200"})
+	matchedStyle        = lipgloss.NewStyle().Bold(true).Underline(true)
 )
 
 // KeyMap defines the key bindings for the SearchTable.
 type KeyMap struct {
-	Search     key.Binding
-	ExitSearch key.Binding
-	Up         key.Binding
-	Down       key.Binding
-	Left       key.Binding
-	Right      key.Binding
-	Enter      key.Binding
-	Delete     key.Binding
+	Search      key.Binding
+	ExitSearch  key.Binding
+	Up          key.Binding
+	Down        key.Binding
+	Left        key.Binding
+	Right       key.Binding
+	Enter       key.Binding
+	Delete      key.Binding
+	Sort        key.Binding
+	Mark        key.Binding
+	MarkAll     key.Binding
+	InvertMarks key.Binding
 }
 
 // Selection represents the act of selecting a row.
@@ -40,6 +49,11 @@ type Deletion struct {
 	Id string
 }
 
+// BulkDeletion represents the act of deleting all marked rows, it is used instead of Deletion when rows are marked.
+type BulkDeletion struct {
+	Ids []string
+}
+
 // UpdateRowsAndColumns updates the columns and rows of the table.
 type UpdateRowsAndColumns struct {
 	Columns []*Column
@@ -51,6 +65,16 @@ type UpdateHighlighted struct {
 	RowId string
 }
 
+// UpdateHeight sets the number of lines the table may use, the number of rows per page is adjusted to fit.
+type UpdateHeight struct {
+	Height int
+}
+
+// UpdateMarked replaces the marked rows with the rows with the given ids.
+type UpdateMarked struct {
+	Ids []string
+}
+
 // newKeyMap creates a new KeyMap.
 func newKeyMap(itemName string) *KeyMap {
 
@@ -101,6 +125,22 @@ func newKeyMap(itemName string) *KeyMap {
 			key.WithKeys("delete"),
 			key.WithHelp("delete", deletePhrase),
 		),
+		Sort: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("ctrl+o", "Cycle the sort column and direction"),
+		),
+		Mark: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "Mark or unmark a row, deleting deletes all marked rows"),
+		),
+		MarkAll: key.NewBinding(
+			key.WithKeys("ctrl+a"),
+			key.WithHelp("ctrl+a", "Mark all rows matching the search"),
+		),
+		InvertMarks: key.NewBinding(
+			key.WithKeys("ctrl+x"),
+			key.WithHelp("ctrl+x", "Invert the marks of the rows matching the search"),
+		),
 	}
 }
 
@@ -108,6 +148,8 @@ func newKeyMap(itemName string) *KeyMap {
 type Column struct {
 	Desc  string
 	Width int
+	// Used to compare the values of the column when the table is sorted by it, CompareStrings is used if it is nil.
+	Compare CompareFunc
 }
 
 // Row defined a row of the table.
@@ -123,6 +165,9 @@ type Options struct {
 
 	// If true, then the search field will be active to start with.
 	StartInSearchMode bool
+
+	// If true, then rows can be marked in order to delete several rows at once.
+	AllowMarking bool
 }
 
 // Model defines a component use to display data in tabular format.
@@ -133,18 +178,27 @@ type Model struct {
 	highlighted string
 
 	allowDelete      bool
+	allowMarking     bool
+	marked           []string
 	currentRowsSlice []*Row
-	currentPage      int
-	pageSize         int
-	numPages         int
-	numRows          int
+	// The indexes of the runes matching the search value, for each value of the rows in currentRowsSlice.
+	currentMatches [][][]int
+	currentPage    int
+	pageSize       int
+	numPages       int
+	numRows        int
 
 	columns []*Column
 	rows    []*Row
 
 	numFilteredRows int
-	searchField     textinput.Model
-	searchMode      bool
+	// The rows matching the search value on all pages.
+	filteredRows []*Row
+	searchField  textinput.Model
+	searchMode   bool
+
+	// Zero if the rows are displayed in the order they were given, see sortColumn for other values.
+	sortState int
 }
 
 // Returns a list of keybindings to be used in help text.
@@ -157,15 +211,95 @@ func (st Model) KeyList() []key.Binding {
 		st.keys.Left,
 		st.keys.Right,
 		st.keys.Enter,
+		st.keys.Sort,
 	}
 
 	if st.allowDelete {
 		keyList = append(keyList, st.keys.Delete)
 	}
 
+	if st.allowMarking {
+		keyList = append(keyList, st.keys.Mark, st.keys.MarkAll, st.keys.InvertMarks)
+	}
+
 	return keyList
 }
 
+// sortColumn returns the index of the column the rows are sorted by and whether they are sorted in descending order.
+// Each column is sorted in ascending and then descending order, so the sort state cycles through two states per column.
+// The last return value is false if the rows are not sorted.
+func (ct Model) sortColumn() (int, bool, bool) {
+	if ct.sortState <= 0 || ct.sortState > 2*len(ct.columns) {
+		return 0, false, false
+	}
+
+	return (ct.sortState - 1) / 2, ct.sortState%2 == 0, true
+}
+
+// sortRows returns the rows sorted by the current sort column, the original order is kept for rows with equal values.
+func (ct Model) sortRows(rows []*Row) []*Row {
+	column, descending, ok := ct.sortColumn()
+	if !ok {
+		return rows
+	}
+
+	compare := ct.columns[column].Compare
+	if compare == nil {
+		compare = CompareStrings
+	}
+
+	value := func(row *Row) string {
+		if column < len(row.Values) {
+			return row.Values[column]
+		}
+		return ""
+	}
+
+	sorted := make([]*Row, len(rows))
+	copy(sorted, rows)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		result := compare(value(sorted[i]), value(sorted[j]))
+		if descending {
+			return result > 0
+		}
+		return result < 0
+	})
+
+	return sorted
+}
+
+// Searching reports whether the table is in search mode, where typed text is entered into the search field.
+func (ct Model) Searching() bool {
+	return ct.searchMode
+}
+
+// HighlightedRow returns the id of the row that the cursor is currently on.
+// The second return value is false if there are no rows to highlight.
+func (ct Model) HighlightedRow() (string, bool) {
+	if ct.cursor < 0 || ct.cursor >= len(ct.currentRowsSlice) {
+		return "", false
+	}
+
+	return ct.currentRowsSlice[ct.cursor].Id, true
+}
+
+// chromeHeight is the number of lines used by the search field, the column header and the paginator.
+const chromeHeight = 8
+
+// defaultPageSize is the number of rows per page used when the available height is not known.
+const defaultPageSize = 10
+
+// PageSize returns the number of rows per page that fit in the given number of lines, at least one row is always displayed.
+// A height of zero means that the height is not known yet.
+func PageSize(height int) int {
+	if height == 0 {
+		return defaultPageSize
+	}
+
+	return integer.IntMax(height-chromeHeight, 1)
+}
+
 // calcSlice calculates the indexes to use to get a page out of a slice.
 func calcSlice(length, currentPage, pageSize int) (int, int) {
 	if pageSize == 0 {
@@ -200,7 +334,9 @@ func New(columns []*Column, rows []*Row, pageSize int, previousChoice string, al
 		keys: newKeyMap(options.SingularItemName),
 
 		currentRowsSlice: rows[sliceStart:sliceEnd],
+		currentMatches:   make([][][]int, sliceEnd-sliceStart),
 		allowDelete:      allowDelete,
+		allowMarking:     options.AllowMarking,
 		highlighted:      previousChoice,
 		pageSize:         pageSize,
 		numPages:         numPages,
@@ -210,6 +346,7 @@ func New(columns []*Column, rows []*Row, pageSize int, previousChoice string, al
 		searchMode:       options.StartInSearchMode,
 		columns:          columns,
 		rows:             rows,
+		filteredRows:     rows,
 	}
 }
 
@@ -219,9 +356,37 @@ func (ct Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	var cmd tea.Cmd
 
-	if ct.searchMode {
+	// When the order of the rows changes the cursor is moved along with the row it is on.
+	cursorRowId, hasCursor := ct.HighlightedRow()
+	keepCursor := false
+
+	// Sorting and marking works both in search and select mode, since the keys are not used for searching.
+	keyMsg, isKeyMsg := msg.(tea.KeyMsg)
+
+	switch {
+	case isKeyMsg && key.Matches(keyMsg, ct.keys.Sort):
+		ct.sortState = (ct.sortState + 1) % (2*len(ct.columns) + 1)
+		keepCursor = true
+	case isKeyMsg && ct.allowMarking && key.Matches(keyMsg, ct.keys.Mark):
+		if len(ct.currentRowsSlice) > 0 {
+			ct.marked = toggleMarked(ct.marked, ct.currentRowsSlice[ct.cursor].Id)
+		}
+		return ct, nil
+	case isKeyMsg && ct.allowMarking && key.Matches(keyMsg, ct.keys.MarkAll):
+		for _, row := range ct.filteredRows {
+			if !ct.IsMarked(row.Id) {
+				ct.marked = toggleMarked(ct.marked, row.Id)
+			}
+		}
+		return ct, nil
+	case isKeyMsg && ct.allowMarking && key.Matches(keyMsg, ct.keys.InvertMarks):
+		for _, row := range ct.filteredRows {
+			ct.marked = toggleMarked(ct.marked, row.Id)
+		}
+		return ct, nil
+	case ct.searchMode:
 		ct, cmd = updateInSearchMode(ct, msg)
-	} else {
+	default:
 		ct, cmd = updateInselectMode(ct, msg)
 		if cmd != nil {
 			return ct, cmd
@@ -232,29 +397,54 @@ func (ct Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	case UpdateRowsAndColumns:
 		ct.rows = m.Rows
 		ct.columns = m.Columns
+		ct.marked = keepExistingMarks(ct.marked, ct.rows)
+		keepCursor = true
 	case UpdateHighlighted:
 		ct.highlighted = m.RowId
+	case UpdateMarked:
+		ct.marked = keepExistingMarks(m.Ids, ct.rows)
+	case UpdateHeight:
+		ct.pageSize = PageSize(m.Height)
+		keepCursor = true
 	}
 
-	// Filter rows based on the search value.
-	filteredRows := []*Row{}
+	// Filter rows based on the search value, the best matches are displayed first unless the rows are sorted by a column.
+	sortedRows := ct.sortRows(ct.rows)
+	columnNames := slices.Map(ct.columns, func(c *Column) string { return c.Desc })
+	results := fuzzy.Filter(ct.searchField.Value(), columnNames, slices.Map(sortedRows, func(r *Row) []string { return r.Values }))
 
-	for _, row := range ct.rows {
-		if strings.Contains(row.Id, ct.searchField.Value()) {
-			filteredRows = append(filteredRows, row)
-		}
+	if _, _, sorted := ct.sortColumn(); !sorted {
+		fuzzy.SortByScore(results)
 	}
 
+	filteredRows := slices.Map(results, func(r fuzzy.Result) *Row { return sortedRows[r.Index] })
+	filteredMatches := slices.Map(results, func(r fuzzy.Result) [][]int { return r.Indexes })
+	ct.filteredRows = filteredRows
+
 	// If we have a search result that is different than the last result we reset the page.
 	if numFilteredItems := len(filteredRows); numFilteredItems != ct.numFilteredRows {
 		ct.numFilteredRows = numFilteredItems
 		ct.currentPage = 0
-		ct.numPages = int(math.Ceil(float64(ct.numFilteredRows) / float64(ct.pageSize)))
+	}
+
+	// The number of pages also changes when the page size changes.
+	ct.numPages = int(math.Ceil(float64(ct.numFilteredRows) / float64(ct.pageSize)))
+
+	// Move to the page and position of the row that the cursor was on, if it is still displayed.
+	if keepCursor && hasCursor && ct.pageSize > 0 {
+		for i, row := range filteredRows {
+			if row.Id == cursorRowId {
+				ct.currentPage = i / ct.pageSize
+				ct.cursor = i % ct.pageSize
+				break
+			}
+		}
 	}
 
 	// Calculate which items should be displayed based on the current page and the pageSize.
 	sliceStart, sliceEnd := calcSlice(ct.numFilteredRows, ct.currentPage, ct.pageSize)
 	ct.currentRowsSlice = filteredRows[sliceStart:sliceEnd]
+	ct.currentMatches = filteredMatches[sliceStart:sliceEnd]
 
 	// If the selection on the previous page was at a higher index than the current pages total items
 	// then we reset it to avoid having a missing cursor.
@@ -268,6 +458,52 @@ func (ct Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 }
 
+// Marked returns the ids of the marked rows in the order they were marked.
+func (ct Model) Marked() []string {
+	marked := make([]string, len(ct.marked))
+	copy(marked, ct.marked)
+	return marked
+}
+
+// IsMarked returns true if the row with the given id is marked.
+func (ct Model) IsMarked(id string) bool {
+	for _, m := range ct.marked {
+		if m == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toggleMarked marks a row that is not marked and unmarks a row that is.
+// A new slice is returned, since the model is copied on each update.
+func toggleMarked(marked []string, id string) []string {
+	result := make([]string, 0, len(marked)+1)
+
+	for _, m := range marked {
+		if m != id {
+			result = append(result, m)
+		}
+	}
+
+	if len(result) == len(marked) {
+		result = append(result, id)
+	}
+
+	return result
+}
+
+// keepExistingMarks returns the marks of the rows that exist, removing marks of rows that are gone.
+func keepExistingMarks(marked []string, rows []*Row) []string {
+	ids := map[string]bool{}
+	for _, row := range rows {
+		ids[row.Id] = true
+	}
+
+	return slices.Filter(marked, func(id string) bool { return ids[id] })
+}
+
 // updateInselectMode updates the column table when in select mode.
 func updateInselectMode(ct Model, msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -282,16 +518,27 @@ func updateInselectMode(ct Model, msg tea.Msg) (Model, tea.Cmd) {
 			return ct, nil
 		// The "up" and "k" keys move the cursor up
 		case key.Matches(msg, ct.keys.Up):
-			if ct.cursor <= 0 {
+			switch {
+			case ct.cursor > 0:
+				ct.cursor--
+			// Moving up from the first row of a page scrolls to the last row of the previous page.
+			case ct.currentPage > 0:
+				ct.currentPage--
+				ct.cursor = ct.pageSize - 1
+			default:
 				ct.searchMode = true
 				return ct, nil
 			}
-			ct.cursor--
 
 		// The "down" and "j" keys move the cursor down
 		case key.Matches(msg, ct.keys.Down):
-			if ct.cursor < len(ct.currentRowsSlice)-1 {
+			switch {
+			case ct.cursor < len(ct.currentRowsSlice)-1:
 				ct.cursor++
+			// Moving down from the last row of a page scrolls to the first row of the next page.
+			case ct.currentPage < ct.numPages-1:
+				ct.currentPage++
+				ct.cursor = 0
 			}
 
 		case key.Matches(msg, ct.keys.Left):
@@ -304,15 +551,29 @@ func updateInselectMode(ct Model, msg tea.Msg) (Model, tea.Cmd) {
 				ct.currentPage++
 			}
 
+		// The table may have been emptied by an update of its rows, in which case there is nothing to select or delete.
 		case key.Matches(msg, ct.keys.Enter):
-			row := ct.currentRowsSlice[ct.cursor]
+			id, ok := ct.HighlightedRow()
+			if !ok {
+				return ct, nil
+			}
+
 			return ct, func() tea.Msg {
-				return Selection{Id: row.Id}
+				return Selection{Id: id}
+			}
+		case key.Matches(msg, ct.keys.Delete) && ct.allowDelete && len(ct.marked) > 0:
+			ids := ct.Marked()
+			return ct, func() tea.Msg {
+				return BulkDeletion{Ids: ids}
 			}
 		case key.Matches(msg, ct.keys.Delete) && ct.allowDelete:
-			row := ct.currentRowsSlice[ct.cursor]
+			id, ok := ct.HighlightedRow()
+			if !ok {
+				return ct, nil
+			}
+
 			return ct, func() tea.Msg {
-				return Deletion{Id: row.Id}
+				return Deletion{Id: id}
 			}
 		}
 	}
@@ -351,9 +612,24 @@ func (ct Model) View() string {
 
 	var selectBuilder strings.Builder
 
-	columnsData := slices.Map(ct.columns, func(c *Column) string {
-		return lipgloss.NewStyle().Width(c.Width + 2).Render(fmt.Sprintf("  %s", c.Desc))
-	})
+	sortColumn, descending, sorted := ct.sortColumn()
+
+	columnsData := []string{}
+	for i, c := range ct.columns {
+		// The sort indicator is placed in the padding in front of the column description.
+		indicator := "  "
+		if sorted && i == sortColumn && descending {
+			indicator = "▼ "
+		} else if sorted && i == sortColumn {
+			indicator = "▲ "
+		}
+
+		columnsData = append(columnsData, lipgloss.NewStyle().Width(c.Width+2).Render(indicator+c.Desc))
+	}
+	// The header is indented to align with the marks of the rows.
+	if ct.allowMarking {
+		mainBuilder.WriteString("  ")
+	}
 	mainBuilder.WriteString(lipgloss.JoinHorizontal(lipgloss.Left, columnsData...) + "\n\n")
 
 	// Iterate over the rows in the current page and print them out.
@@ -363,19 +639,35 @@ func (ct Model) View() string {
 		if ct.cursor == i {
 			cursor = ">" // cursor!
 		}
+		// Marked rows are prefixed, the others are padded to keep the rows aligned.
+		if ct.allowMarking {
+			mark := " "
+			if ct.IsMarked(row.Id) {
+				mark = "+"
+			}
+			cursor = fmt.Sprintf("%s %s", cursor, mark)
+		}
+
+		valueStyle := lipgloss.NewStyle()
+		if row.Id == ct.highlighted {
+			valueStyle = highlightedStyle
+		}
 
 		rowData := []string{}
 
-		for i, value := range row.Values {
-			rowData = append(rowData, lipgloss.NewStyle().Width(ct.columns[i].Width+2).Render(value))
+		for j, value := range row.Values {
+			// The values are styled individually in order to highlight the runes matching the search value.
+			var matches []int
+			if j < len(ct.currentMatches[i]) {
+				matches = ct.currentMatches[i][j]
+			}
+
+			value = fuzzy.Highlight(value, matches, matchedStyle, valueStyle)
+			rowData = append(rowData, lipgloss.NewStyle().Width(ct.columns[j].Width+2).Render(value))
 		}
 
 		// Render the row
-		if row.Id == ct.highlighted {
-			selectBuilder.WriteString(fmt.Sprintf("%s %s\n", cursor, highlightedStyle.Render(lipgloss.JoinHorizontal(lipgloss.Left, rowData...))))
-		} else {
-			selectBuilder.WriteString(fmt.Sprintf("%s %s\n", cursor, lipgloss.JoinHorizontal(lipgloss.Left, rowData...)))
-		}
+		selectBuilder.WriteString(fmt.Sprintf("%s %s\n", cursor, lipgloss.JoinHorizontal(lipgloss.Left, rowData...)))
 
 	}
 
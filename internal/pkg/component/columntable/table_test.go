@@ -0,0 +1,265 @@
+package columntable_test
+
+import (
+	"strings"
+	"testing"
+
+	"kubeui/internal/pkg/component/columntable"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+var sortKey = tea.KeyMsg{Type: tea.KeyCtrlO}
+
+func testColumnsAndRows() ([]*columntable.Column, []*columntable.Row) {
+	columns := []*columntable.Column{
+		{Desc: "Name", Width: 7},
+		{Desc: "Restarts", Width: 8, Compare: columntable.CompareNumbers},
+	}
+
+	rows := []*columntable.Row{
+		{Id: "bravo", Values: []string{"bravo", "10"}},
+		{Id: "charlie", Values: []string{"charlie", "2"}},
+		{Id: "alpha", Values: []string{"alpha", "1"}},
+	}
+
+	return columns, rows
+}
+
+// displayedOrder returns the ids of the test rows in the order they are displayed.
+func displayedOrder(view string) []string {
+	ids := []string{"alpha", "bravo", "charlie"}
+
+	order := []string{}
+	for _, line := range strings.Split(view, "\n") {
+		for _, id := range ids {
+			if strings.Contains(line, id) {
+				order = append(order, id)
+			}
+		}
+	}
+
+	return order
+}
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		name              string
+		presses           int
+		startInSearchMode bool
+		wantOrder         []string
+		wantHeader        string
+	}{
+		{"unsorted", 0, false, []string{"bravo", "charlie", "alpha"}, "  Name"},
+		{"name ascending", 1, false, []string{"alpha", "bravo", "charlie"}, "▲ Name"},
+		{"name descending", 2, false, []string{"charlie", "bravo", "alpha"}, "▼ Name"},
+		{"restarts ascending", 3, false, []string{"alpha", "charlie", "bravo"}, "▲ Restarts"},
+		{"restarts descending", 4, false, []string{"bravo", "charlie", "alpha"}, "▼ Restarts"},
+		{"back to unsorted", 5, false, []string{"bravo", "charlie", "alpha"}, "  Name"},
+		{"in search mode", 1, true, []string{"alpha", "bravo", "charlie"}, "▲ Name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, rows := testColumnsAndRows()
+			table := columntable.New(columns, rows, 10, "", false, columntable.Options{StartInSearchMode: tt.startInSearchMode})
+
+			for i := 0; i < tt.presses; i++ {
+				table, _ = table.Update(sortKey)
+			}
+
+			view := table.View()
+			assert.Equal(t, tt.wantOrder, displayedOrder(view))
+			assert.Contains(t, view, tt.wantHeader)
+		})
+	}
+}
+
+func TestSortKeepsCursorOnRow(t *testing.T) {
+	columns, rows := testColumnsAndRows()
+
+	// With a single row per page the cursor has to change page to follow the row.
+	table := columntable.New(columns, rows, 1, "", false, columntable.Options{})
+
+	id, ok := table.HighlightedRow()
+	assert.True(t, ok)
+	assert.Equal(t, "bravo", id)
+
+	table, _ = table.Update(sortKey)
+	id, _ = table.HighlightedRow()
+	assert.Equal(t, "bravo", id)
+	assert.Equal(t, []string{"bravo"}, displayedOrder(table.View()))
+
+	// The sorting is kept when the rows are updated and the cursor stays on the same row.
+	updatedRows := append([]*columntable.Row{{Id: "alpaca", Values: []string{"alpaca", "0"}}}, rows...)
+	table, _ = table.Update(columntable.UpdateRowsAndColumns{Columns: columns, Rows: updatedRows})
+	id, _ = table.HighlightedRow()
+	assert.Equal(t, "bravo", id)
+	assert.Contains(t, table.View(), "▲ Name")
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		sortPresses int
+		wantOrder   []string
+	}{
+		{"empty", "", 0, []string{"bravo", "charlie", "alpha"}},
+		{"fuzzy", "crl", 0, []string{"charlie"}},
+		{"ignores case", "ALP", 0, []string{"alpha"}},
+		{"other columns", "10", 0, []string{"bravo"}},
+		{"targeted column", "restarts:1", 0, []string{"bravo", "alpha"}},
+		{"best match first", "a", 0, []string{"alpha", "bravo", "charlie"}},
+		{"sorting overrides ranking", "a", 2, []string{"charlie", "bravo", "alpha"}},
+		{"no match", "delta", 0, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, rows := testColumnsAndRows()
+			table := columntable.New(columns, rows, 10, "", false, columntable.Options{StartInSearchMode: true})
+
+			for i := 0; i < tt.sortPresses; i++ {
+				table, _ = table.Update(sortKey)
+			}
+
+			table, _ = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.query)})
+
+			assert.Equal(t, tt.wantOrder, displayedOrder(table.View()))
+		})
+	}
+}
+
+func TestMarking(t *testing.T) {
+	columns, rows := testColumnsAndRows()
+	table := columntable.New(columns, rows, 10, "", true, columntable.Options{AllowMarking: true})
+
+	// The cursor starts on the first row.
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyTab})
+	assert.Equal(t, []string{"bravo"}, table.Marked())
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
+	assert.Equal(t, []string{"charlie", "alpha"}, table.Marked())
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+	assert.Equal(t, []string{"charlie", "alpha", "bravo"}, table.Marked())
+
+	table, cmd := table.Update(tea.KeyMsg{Type: tea.KeyDelete})
+	assert.Equal(t, columntable.BulkDeletion{Ids: []string{"charlie", "alpha", "bravo"}}, cmd())
+
+	// Marks of rows that are removed are dropped.
+	table, _ = table.Update(columntable.UpdateRowsAndColumns{Columns: columns, Rows: rows[:2]})
+	assert.Equal(t, []string{"charlie", "bravo"}, table.Marked())
+
+	table, _ = table.Update(columntable.UpdateMarked{Ids: []string{"charlie", "alpha"}})
+	assert.Equal(t, []string{"charlie"}, table.Marked())
+
+	table, _ = table.Update(columntable.UpdateMarked{})
+	table, cmd = table.Update(tea.KeyMsg{Type: tea.KeyDelete})
+	assert.Equal(t, columntable.Deletion{Id: "bravo"}, cmd())
+}
+
+func TestMarkingOnlyAffectsSearchedRows(t *testing.T) {
+	columns, rows := testColumnsAndRows()
+	table := columntable.New(columns, rows, 10, "", true, columntable.Options{AllowMarking: true, StartInSearchMode: true})
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+	assert.Equal(t, []string{"bravo", "charlie"}, table.Marked())
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
+	assert.Empty(t, table.Marked())
+}
+
+func TestMarkingNotAllowed(t *testing.T) {
+	columns, rows := testColumnsAndRows()
+	table := columntable.New(columns, rows, 10, "", true, columntable.Options{})
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyTab})
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+	assert.Empty(t, table.Marked())
+}
+
+func TestPageSize(t *testing.T) {
+	tests := []struct {
+		name   string
+		height int
+		want   int
+	}{
+		{"unknown height", 0, 10},
+		{"room for rows", 20, 12},
+		{"too small", 5, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, columntable.PageSize(tt.height))
+		})
+	}
+}
+
+func TestPageSizeFitsHeight(t *testing.T) {
+	columns, rows := testColumnsAndRows()
+	height := 11
+	table := columntable.New(columns, rows, columntable.PageSize(height), "", false, columntable.Options{})
+
+	assert.Equal(t, height, len(strings.Split(table.View(), "\n")))
+}
+
+func TestScrolling(t *testing.T) {
+	columns, rows := testColumnsAndRows()
+	table := columntable.New(columns, rows, 2, "", false, columntable.Options{})
+
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	up := tea.KeyMsg{Type: tea.KeyUp}
+
+	// Moving past the last row of a page continues on the next page.
+	wantDown := []string{"charlie", "alpha", "alpha"}
+	for _, want := range wantDown {
+		table, _ = table.Update(down)
+		id, _ := table.HighlightedRow()
+		assert.Equal(t, want, id)
+	}
+
+	wantUp := []string{"charlie", "bravo"}
+	for _, want := range wantUp {
+		table, _ = table.Update(up)
+		id, _ := table.HighlightedRow()
+		assert.Equal(t, want, id)
+	}
+}
+
+func TestUpdateHeightKeepsCursorOnRow(t *testing.T) {
+	columns, rows := testColumnsAndRows()
+	table := columntable.New(columns, rows, 10, "", false, columntable.Options{})
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyDown})
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	// With a single row per page the cursor row is the only one displayed.
+	table, _ = table.Update(columntable.UpdateHeight{Height: 9})
+	id, _ := table.HighlightedRow()
+	assert.Equal(t, "alpha", id)
+	assert.Equal(t, []string{"alpha"}, displayedOrder(table.View()))
+
+	table, _ = table.Update(columntable.UpdateHeight{Height: 20})
+	id, _ = table.HighlightedRow()
+	assert.Equal(t, "alpha", id)
+	assert.Equal(t, []string{"bravo", "charlie", "alpha"}, displayedOrder(table.View()))
+}
+
+func TestEmptiedTable(t *testing.T) {
+	columns, rows := testColumnsAndRows()
+	table := columntable.New(columns, rows, 10, "", true, columntable.Options{})
+
+	// The rows may be removed while the table is displayed, for example by a watch.
+	table, _ = table.Update(columntable.UpdateRowsAndColumns{Columns: columns, Rows: nil})
+
+	_, ok := table.HighlightedRow()
+	assert.False(t, ok)
+
+	table, cmd := table.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+
+	_, cmd = table.Update(tea.KeyMsg{Type: tea.KeyDelete})
+	assert.Nil(t, cmd)
+}
@@ -0,0 +1,240 @@
+// Package commandpalette provides a component used to choose a command by typing a part of its name, similar to the command mode of vim.
+package commandpalette
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"kubeui/internal/pkg/fuzzy"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	descriptionStyle = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "245", Dark: "245"})
+	selectedStyle    = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "200", Dark: "200"})
+	matchedStyle     = lipgloss.NewStyle().Bold(true).Underline(true)
+)
+
+// KeyMap defines the key bindings for the palette.
+type KeyMap struct {
+	Enter    key.Binding
+	Cancel   key.Binding
+	Next     key.Binding
+	Previous key.Binding
+}
+
+// newKeyMap creates a new KeyMap.
+func newKeyMap() *KeyMap {
+	return &KeyMap{
+		Enter: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "Run the selected command"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "Close the palette"),
+		),
+		Next: key.NewBinding(
+			key.WithKeys("down", "tab"),
+			key.WithHelp("↓/tab", "Next command"),
+		),
+		Previous: key.NewBinding(
+			key.WithKeys("up", "shift+tab"),
+			key.WithHelp("↑/shift+tab", "Previous command"),
+		),
+	}
+}
+
+// Command is a command that can be chosen in the palette.
+type Command struct {
+	// Name is used to identify the command.
+	Name string
+	// Aliases can be typed instead of the name.
+	Aliases []string
+	// Displayed next to the name.
+	Description string
+}
+
+// Selection represents the act of choosing a command.
+type Selection struct {
+	Command Command
+}
+
+// Cancellation represents the act of closing the palette without choosing a command.
+type Cancellation struct{}
+
+// Match is a command that matched a query.
+type Match struct {
+	Command Command
+	Score   int
+	// Indexes of the matched runes for the name followed by each of the aliases.
+	Indexes [][]int
+}
+
+// Filter returns the commands matching a query, with the best match first.
+// A query matches a command if it fuzzy matches the name or one of the aliases, a leading colon is ignored.
+// Commands where the query equals the name or one of the aliases are always ranked first, so that typing a short alias such as "po" is never ambiguous.
+// An empty query matches all commands in their original order.
+func Filter(query string, commands []Command) []Match {
+	query = strings.TrimPrefix(strings.TrimSpace(query), ":")
+
+	matches := []Match{}
+	exact := map[string]bool{}
+
+	for _, command := range commands {
+		names := append([]string{command.Name}, command.Aliases...)
+
+		result, ok := fuzzy.MatchRecord([]fuzzy.Term{{Column: fuzzy.AnyColumn, Pattern: query}}, names)
+		if !ok {
+			continue
+		}
+
+		for _, name := range names {
+			if query != "" && strings.EqualFold(name, query) {
+				exact[command.Name] = true
+			}
+		}
+
+		matches = append(matches, Match{Command: command, Score: result.Score, Indexes: result.Indexes})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if exact[matches[i].Command.Name] != exact[matches[j].Command.Name] {
+			return exact[matches[i].Command.Name]
+		}
+
+		return matches[i].Score > matches[j].Score
+	})
+
+	return matches
+}
+
+// Model defines a component used to choose one of several commands.
+type Model struct {
+	keys     *KeyMap
+	input    textinput.Model
+	commands []Command
+	matches  []Match
+	cursor   int
+}
+
+// Returns a list of keybindings to be used in help text.
+func (d Model) KeyList() []key.Binding {
+	return []key.Binding{
+		d.keys.Enter,
+		d.keys.Cancel,
+		d.keys.Next,
+		d.keys.Previous,
+	}
+}
+
+// New creates a new Model, the commands are listed in the given order until a query is typed.
+func New(commands []Command) Model {
+	input := textinput.New()
+	input.Prompt = ":"
+	input.Placeholder = "command"
+	input.Focus()
+	input.CharLimit = 64
+	input.Width = 40
+
+	return Model{
+		keys:     newKeyMap(),
+		input:    input,
+		commands: commands,
+		matches:  Filter("", commands),
+	}
+}
+
+// Update updates the model and optionally returns a command.
+// It is part of the bubbletea model interface.
+func (d Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+
+		case key.Matches(msg, d.keys.Cancel):
+			return d, func() tea.Msg {
+				return Cancellation{}
+			}
+
+		case key.Matches(msg, d.keys.Enter):
+			if len(d.matches) == 0 {
+				return d, nil
+			}
+
+			command := d.matches[d.cursor].Command
+			return d, func() tea.Msg {
+				return Selection{Command: command}
+			}
+
+		case key.Matches(msg, d.keys.Next):
+			if len(d.matches) > 0 {
+				d.cursor = (d.cursor + 1) % len(d.matches)
+			}
+			return d, nil
+
+		case key.Matches(msg, d.keys.Previous):
+			if len(d.matches) > 0 {
+				d.cursor = (d.cursor - 1 + len(d.matches)) % len(d.matches)
+			}
+			return d, nil
+		}
+	}
+
+	value := d.input.Value()
+
+	var cmd tea.Cmd
+	d.input, cmd = d.input.Update(msg)
+
+	// The best match is selected again whenever the query changes.
+	if d.input.Value() != value {
+		d.matches = Filter(d.input.Value(), d.commands)
+		d.cursor = 0
+	}
+
+	return d, cmd
+}
+
+// View returns the view for the model.
+// It is part of the bubbletea model interface.
+func (d Model) View() string {
+	var builder strings.Builder
+
+	builder.WriteString(d.input.View() + "\n\n")
+
+	if len(d.matches) == 0 {
+		builder.WriteString("No matching commands")
+		return builder.String()
+	}
+
+	for i, match := range d.matches {
+		cursor := " "
+		style := lipgloss.NewStyle()
+		if i == d.cursor {
+			cursor = ">"
+			style = selectedStyle
+		}
+
+		line := fmt.Sprintf("%s :%s", cursor, fuzzy.Highlight(match.Command.Name, match.Indexes[0], matchedStyle, style))
+
+		if len(match.Command.Aliases) > 0 {
+			aliases := []string{}
+			for j, alias := range match.Command.Aliases {
+				aliases = append(aliases, fuzzy.Highlight(alias, match.Indexes[j+1], matchedStyle, style))
+			}
+			line += style.Render(" (") + strings.Join(aliases, style.Render(", ")) + style.Render(")")
+		}
+
+		if match.Command.Description != "" {
+			line += "  " + descriptionStyle.Render(match.Command.Description)
+		}
+
+		builder.WriteString(line + "\n")
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,66 @@
+package commandpalette_test
+
+import (
+	"testing"
+
+	"kubeui/internal/pkg/component/commandpalette"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+var commands = []commandpalette.Command{
+	{Name: "pods", Aliases: []string{"po", "pod"}},
+	{Name: "deploy", Aliases: []string{"deployments"}},
+	{Name: "ns", Aliases: []string{"namespaces"}},
+	{Name: "ctx", Aliases: []string{"contexts"}},
+	{Name: "nodes", Aliases: []string{"no"}},
+}
+
+func names(matches []commandpalette.Match) []string {
+	result := []string{}
+	for _, match := range matches {
+		result = append(result, match.Command.Name)
+	}
+
+	return result
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"empty query matches all commands in order", "", []string{"pods", "deploy", "ns", "ctx", "nodes"}},
+		{"leading colon is ignored", ":ctx", []string{"ctx"}},
+		{"fuzzy match on name", "dpl", []string{"deploy"}},
+		{"fuzzy match on alias", "cnxt", []string{"ctx"}},
+		{"case is ignored", "CTX", []string{"ctx"}},
+		{"exact alias ranks first", "po", []string{"pods", "deploy"}},
+		{"no match", "xyz", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, names(commandpalette.Filter(tt.query, commands)))
+		})
+	}
+}
+
+func TestSelection(t *testing.T) {
+	palette := commandpalette.New(commands)
+
+	for _, r := range "dep" {
+		palette, _ = palette.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	_, cmd := palette.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.Equal(t, commandpalette.Selection{Command: commands[1]}, cmd())
+}
+
+func TestCancellation(t *testing.T) {
+	_, cmd := commandpalette.New(commands).Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	assert.Equal(t, commandpalette.Cancellation{}, cmd())
+}
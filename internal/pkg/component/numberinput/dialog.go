@@ -0,0 +1,151 @@
+package numberinput
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	errorStyle = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "9", Dark: "9"})
+)
+
+// KeyMap defines the key bindings for the dialog.
+type KeyMap struct {
+	Enter  key.Binding
+	Cancel key.Binding
+}
+
+// newKeyMap creates a new KeyMap.
+func newKeyMap() *KeyMap {
+	return &KeyMap{
+		Enter: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "Submit the number"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "Cancel"),
+		),
+	}
+}
+
+// Submission represents the act of submitting a valid number.
+type Submission struct {
+	Id    string
+	Value int
+}
+
+// Cancellation represents the act of closing the dialog without submitting a number.
+type Cancellation struct {
+	Id string
+}
+
+// Options specifies additional options to be considered when creating a numberinput.
+type Options struct {
+	// The smallest number that can be submitted.
+	Min int
+	// The largest number that can be submitted, no limit is applied if it is zero.
+	Max int
+}
+
+// Model defines a component used to input a non negative number.
+type Model struct {
+	keys    *KeyMap
+	id      string
+	text    string
+	input   textinput.Model
+	err     string
+	options Options
+}
+
+// Returns a list of keybindings to be used in help text.
+func (d Model) KeyList() []key.Binding {
+	keyList := []key.Binding{
+		d.keys.Enter,
+		d.keys.Cancel,
+	}
+
+	return keyList
+}
+
+// New creates a new Model.
+// The id is passed along with the result, and the input starts out with the given value.
+func New(id, text string, value int, options Options) Model {
+	input := textinput.New()
+	input.Placeholder = "0"
+	input.SetValue(strconv.Itoa(value))
+	input.Focus()
+	input.CharLimit = 9
+	input.Width = 10
+
+	return Model{
+		keys:    newKeyMap(),
+		id:      id,
+		text:    text,
+		input:   input,
+		options: options,
+	}
+}
+
+// Update updates the model and optionally returns a command.
+// It is part of the bubbletea model interface.
+func (d Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+
+		case key.Matches(msg, d.keys.Cancel):
+			id := d.id
+			return d, func() tea.Msg {
+				return Cancellation{Id: id}
+			}
+
+		case key.Matches(msg, d.keys.Enter):
+			value, err := strconv.Atoi(strings.TrimSpace(d.input.Value()))
+			if err != nil || value < 0 {
+				d.err = fmt.Sprintf("%q is not a valid number", d.input.Value())
+				return d, nil
+			}
+
+			if value < d.options.Min || (d.options.Max > 0 && value > d.options.Max) {
+				d.err = fmt.Sprintf("%d is out of range", value)
+				return d, nil
+			}
+
+			id := d.id
+			return d, func() tea.Msg {
+				return Submission{Id: id, Value: value}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	d.input, cmd = d.input.Update(msg)
+	return d, cmd
+}
+
+// View returns the view for the model.
+// It is part of the bubbletea model interface.
+func (d Model) View() string {
+	var dialogBuilder strings.Builder
+
+	dialogBuilder.WriteString(d.text + "\n\n")
+	dialogBuilder.WriteString(d.input.View())
+
+	if d.err != "" {
+		dialogBuilder.WriteString("\n\n" + errorStyle.Render(d.err))
+	}
+
+	return dialogBuilder.String()
+}
+
+// Init returns an initial command.
+// It is part of the bubbletea model interface.
+func (d Model) Init() tea.Cmd {
+	return textinput.Blink
+}
@@ -0,0 +1,162 @@
+package queryinput
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	errorStyle = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "9", Dark: "9"})
+)
+
+// KeyMap defines the key bindings for the dialog.
+type KeyMap struct {
+	Enter  key.Binding
+	Cancel key.Binding
+}
+
+// newKeyMap creates a new KeyMap.
+func newKeyMap() *KeyMap {
+	return &KeyMap{
+		Enter: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "Submit the query"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "Cancel"),
+		),
+	}
+}
+
+// Submission represents the act of submitting a valid query.
+type Submission struct {
+	Id    string
+	Value string
+}
+
+// Cancellation represents the act of closing the dialog without submitting a query.
+type Cancellation struct {
+	Id string
+}
+
+// Options specifies additional options to be considered when creating a queryinput.
+type Options struct {
+	// Displayed while the input is empty.
+	Placeholder string
+	// Validates the query as it is typed, a query can only be submitted if it returns nil.
+	Validate func(query string) error
+}
+
+// Model defines a component used to input a query, which is validated as it is typed.
+type Model struct {
+	keys    *KeyMap
+	id      string
+	text    string
+	input   textinput.Model
+	err     string
+	options Options
+}
+
+// Returns a list of keybindings to be used in help text.
+func (d Model) KeyList() []key.Binding {
+	keyList := []key.Binding{
+		d.keys.Enter,
+		d.keys.Cancel,
+	}
+
+	return keyList
+}
+
+// New creates a new Model.
+// The id is passed along with the result, and the input starts out with the given value.
+func New(id, text, value string, options Options) Model {
+	input := textinput.New()
+	input.Placeholder = options.Placeholder
+	input.SetValue(value)
+	input.Focus()
+	input.CharLimit = 512
+	input.Width = 60
+
+	d := Model{
+		keys:    newKeyMap(),
+		id:      id,
+		text:    text,
+		input:   input,
+		options: options,
+	}
+
+	return d.validate()
+}
+
+// validate validates the current value of the input, keeping the error for display.
+func (d Model) validate() Model {
+	d.err = ""
+
+	if d.options.Validate == nil {
+		return d
+	}
+
+	if err := d.options.Validate(strings.TrimSpace(d.input.Value())); err != nil {
+		d.err = err.Error()
+	}
+
+	return d
+}
+
+// Update updates the model and optionally returns a command.
+// It is part of the bubbletea model interface.
+func (d Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+
+		case key.Matches(msg, d.keys.Cancel):
+			id := d.id
+			return d, func() tea.Msg {
+				return Cancellation{Id: id}
+			}
+
+		case key.Matches(msg, d.keys.Enter):
+			// Invalid queries can not be submitted, the error is already displayed.
+			if d.err != "" {
+				return d, nil
+			}
+
+			id := d.id
+			value := strings.TrimSpace(d.input.Value())
+			return d, func() tea.Msg {
+				return Submission{Id: id, Value: value}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	d.input, cmd = d.input.Update(msg)
+	d = d.validate()
+	return d, cmd
+}
+
+// View returns the view for the model.
+// It is part of the bubbletea model interface.
+func (d Model) View() string {
+	var dialogBuilder strings.Builder
+
+	dialogBuilder.WriteString(d.text + "\n\n")
+	dialogBuilder.WriteString(d.input.View())
+
+	if d.err != "" {
+		dialogBuilder.WriteString("\n\n" + errorStyle.Render(d.err))
+	}
+
+	return dialogBuilder.String()
+}
+
+// Init returns an initial command.
+// It is part of the bubbletea model interface.
+func (d Model) Init() tea.Cmd {
+	return textinput.Blink
+}
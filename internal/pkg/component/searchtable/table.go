@@ -5,16 +5,21 @@ import (
 	"math"
 	"strings"
 
+	"kubeui/internal/pkg/fuzzy"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/life4/genesis/slices"
+	"k8s.io/utils/integer"
 )
 
 var (
 	selectedPageStyle   = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "235", Dark: "252"})
 	unSelectedPageStyle = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "250", Dark: "238"})
 	highlightedStyle    = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "200", Dark: "200"})
+	matchedStyle        = lipgloss.NewStyle().Bold(true).Underline(true)
 )
 
 // KeyMap defines the key bindings for the SearchTable.
@@ -27,6 +32,7 @@ type KeyMap struct {
 	Right      key.Binding
 	Enter      key.Binding
 	Delete     key.Binding
+	Mark       key.Binding
 }
 
 // Selection represents the act of selecting an item.
@@ -49,6 +55,11 @@ type UpdateHighlighted struct {
 	Item string
 }
 
+// UpdateHeight sets the number of lines the table may use, the number of items per page is adjusted to fit.
+type UpdateHeight struct {
+	Height int
+}
+
 // newKeyMap creates a new KeyMap.
 func newKeyMap(itemName string) *KeyMap {
 
@@ -99,6 +110,10 @@ func newKeyMap(itemName string) *KeyMap {
 			key.WithKeys("delete"),
 			key.WithHelp("delete", deletePhrase),
 		),
+		Mark: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "Mark or unmark an item, selecting all marked items"),
+		),
 	}
 }
 
@@ -116,6 +131,9 @@ type Options struct {
 	// If true, then the search field will be active to start with.
 	StartInSearchMode bool
 
+	// If true, then items can be marked in order to select several items at once.
+	AllowMarking bool
+
 	// Named Columns
 	Columns []*Column
 }
@@ -130,11 +148,15 @@ type Model struct {
 	highlighted string
 
 	allowDelete       bool
+	allowMarking      bool
+	marked            []string
 	currentItemsSlice []string
-	currentPage       int
-	pageSize          int
-	numPages          int
-	numItems          int
+	// The indexes of the runes matching the search value, for each item in currentItemsSlice.
+	currentMatches [][]int
+	currentPage    int
+	pageSize       int
+	numPages       int
+	numItems       int
 
 	numFilteredItems int
 	searchField      textinput.Model
@@ -157,9 +179,29 @@ func (st Model) KeyList() []key.Binding {
 		keyList = append(keyList, st.keys.Delete)
 	}
 
+	if st.allowMarking {
+		keyList = append(keyList, st.keys.Mark)
+	}
+
 	return keyList
 }
 
+// chromeHeight is the number of lines used by the search field and the paginator.
+const chromeHeight = 6
+
+// defaultPageSize is the number of items per page used when the available height is not known.
+const defaultPageSize = 10
+
+// PageSize returns the number of items per page that fit in the given number of lines, at least one item is always displayed.
+// A height of zero means that the height is not known yet.
+func PageSize(height int) int {
+	if height == 0 {
+		return defaultPageSize
+	}
+
+	return integer.IntMax(height-chromeHeight, 1)
+}
+
 // calcSlice calculates the indexes to use to get a page out of a slice.
 func calcSlice(length, currentPage, pageSize int) (int, int) {
 	if pageSize == 0 {
@@ -194,7 +236,9 @@ func New(items []string, pageSize int, previousChoice string, allowDelete bool,
 		keys:              newKeyMap(options.SingularItemName),
 		items:             items,
 		currentItemsSlice: items[sliceStart:sliceEnd],
+		currentMatches:    make([][]int, sliceEnd-sliceStart),
 		allowDelete:       allowDelete,
+		allowMarking:      options.AllowMarking,
 		highlighted:       previousChoice,
 		pageSize:          pageSize,
 		numPages:          numPages,
@@ -211,6 +255,21 @@ func (st Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	var cmd tea.Cmd
 
+	// When the page size changes the cursor is moved along with the item it is on.
+	cursorItem := ""
+	if len(st.currentItemsSlice) > 0 {
+		cursorItem = st.currentItemsSlice[st.cursor]
+	}
+	keepCursor := false
+
+	// Items can be marked both in search and select mode, since tab is not used for searching.
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && st.allowMarking && key.Matches(keyMsg, st.keys.Mark) {
+		if len(st.currentItemsSlice) > 0 {
+			st.marked = toggleMarked(st.marked, st.currentItemsSlice[st.cursor])
+		}
+		return st, nil
+	}
+
 	if st.searchMode {
 		st, cmd = updateInSearchMode(st, msg)
 	} else {
@@ -225,27 +284,42 @@ func (st Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		st.items = m.Items
 	case UpdateHighlighted:
 		st.highlighted = m.Item
+	case UpdateHeight:
+		st.pageSize = PageSize(m.Height)
+		keepCursor = true
 	}
 
-	// Filter items based on the search value.
-	filteredItems := []string{}
+	// Filter items based on the search value, displaying the best matches first.
+	results := fuzzy.Filter(st.searchField.Value(), nil, slices.Map(st.items, func(item string) []string { return []string{item} }))
+	fuzzy.SortByScore(results)
 
-	for _, item := range st.items {
-		if strings.Contains(item, st.searchField.Value()) {
-			filteredItems = append(filteredItems, item)
-		}
-	}
+	filteredItems := slices.Map(results, func(r fuzzy.Result) string { return st.items[r.Index] })
+	filteredMatches := slices.Map(results, func(r fuzzy.Result) []int { return r.Indexes[0] })
 
 	// If we have a search result that is different than the last result we reset the page.
 	if numFilteredItems := len(filteredItems); numFilteredItems != st.numFilteredItems {
 		st.numFilteredItems = numFilteredItems
 		st.currentPage = 0
-		st.numPages = int(math.Ceil(float64(st.numFilteredItems) / float64(st.pageSize)))
+	}
+
+	// The number of pages also changes when the page size changes.
+	st.numPages = int(math.Ceil(float64(st.numFilteredItems) / float64(st.pageSize)))
+
+	// Move to the page and position of the item that the cursor was on.
+	if keepCursor && cursorItem != "" {
+		for i, item := range filteredItems {
+			if item == cursorItem {
+				st.currentPage = i / st.pageSize
+				st.cursor = i % st.pageSize
+				break
+			}
+		}
 	}
 
 	// Calculate which items should be displayed based on the current page and the pageSize.
 	sliceStart, sliceEnd := calcSlice(st.numFilteredItems, st.currentPage, st.pageSize)
 	st.currentItemsSlice = filteredItems[sliceStart:sliceEnd]
+	st.currentMatches = filteredMatches[sliceStart:sliceEnd]
 
 	// If the selection on the previous page was at a higher index than the current pages total items
 	// then we reset it to avoid having a missing cursor.
@@ -259,6 +333,36 @@ func (st Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 }
 
+// Searching reports whether the table is in search mode, where typed text is entered into the search field.
+func (st Model) Searching() bool {
+	return st.searchMode
+}
+
+// Marked returns the marked items in the order they were marked.
+func (st Model) Marked() []string {
+	marked := make([]string, len(st.marked))
+	copy(marked, st.marked)
+	return marked
+}
+
+// toggleMarked marks an item that is not marked and unmarks an item that is.
+// A new slice is returned, since the model is copied on each update.
+func toggleMarked(marked []string, item string) []string {
+	result := make([]string, 0, len(marked)+1)
+
+	for _, m := range marked {
+		if m != item {
+			result = append(result, m)
+		}
+	}
+
+	if len(result) == len(marked) {
+		result = append(result, item)
+	}
+
+	return result
+}
+
 // updateInselectMode updates a searchTable when in select mode.
 func updateInselectMode(st Model, msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -273,16 +377,27 @@ func updateInselectMode(st Model, msg tea.Msg) (Model, tea.Cmd) {
 			return st, nil
 		// The "up" and "k" keys move the cursor up
 		case key.Matches(msg, st.keys.Up):
-			if st.cursor <= 0 {
+			switch {
+			case st.cursor > 0:
+				st.cursor--
+			// Moving up from the first row of a page scrolls to the last row of the previous page.
+			case st.currentPage > 0:
+				st.currentPage--
+				st.cursor = st.pageSize - 1
+			default:
 				st.searchMode = true
 				return st, nil
 			}
-			st.cursor--
 
 		// The "down" and "j" keys move the cursor down
 		case key.Matches(msg, st.keys.Down):
-			if st.cursor < len(st.currentItemsSlice)-1 {
+			switch {
+			case st.cursor < len(st.currentItemsSlice)-1:
 				st.cursor++
+			// Moving down from the last row of a page scrolls to the first row of the next page.
+			case st.currentPage < st.numPages-1:
+				st.currentPage++
+				st.cursor = 0
 			}
 
 		case key.Matches(msg, st.keys.Left):
@@ -350,14 +465,25 @@ func (n Model) View() string {
 		if n.cursor == i {
 			cursor = ">" // cursor!
 		}
-		// Render the row
+		// Marked items are prefixed, the others are padded to keep the items aligned.
+		if n.allowMarking {
+			mark := " "
+			for _, m := range n.marked {
+				if m == item {
+					mark = "+"
+				}
+			}
+			cursor = fmt.Sprintf("%s %s", cursor, mark)
+		}
+
+		itemStyle := lipgloss.NewStyle()
 		if item == n.highlighted {
-			//selectBuilder.WriteString(fmt.Sprintf("%s %s\n", cursor, item))
-			selectBuilder.WriteString(fmt.Sprintf("%s %s\n", cursor, highlightedStyle.Render(item)))
-		} else {
-			selectBuilder.WriteString(fmt.Sprintf("%s %s\n", cursor, item))
+			itemStyle = highlightedStyle
 		}
 
+		// Render the row
+		selectBuilder.WriteString(fmt.Sprintf("%s %s\n", cursor, fuzzy.Highlight(item, n.currentMatches[i], matchedStyle, itemStyle)))
+
 	}
 
 	// Start building the pageinator view.
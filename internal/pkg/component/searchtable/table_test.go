@@ -0,0 +1,67 @@
+package searchtable_test
+
+import (
+	"strings"
+	"testing"
+
+	"kubeui/internal/pkg/component/searchtable"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+// highlightedItem returns the item on the line with the cursor.
+// The first line is skipped since it contains the prompt of the search field.
+func highlightedItem(view string) string {
+	for _, line := range strings.Split(view, "\n")[1:] {
+		if strings.HasPrefix(line, ">") {
+			return strings.TrimSpace(strings.TrimPrefix(line, ">"))
+		}
+	}
+
+	return ""
+}
+
+func TestPageSizeFitsHeight(t *testing.T) {
+	height := 9
+	table := searchtable.New([]string{"default", "kube-system", "monitoring"}, searchtable.PageSize(height), "", false, searchtable.Options{})
+
+	assert.Equal(t, height, len(strings.Split(table.View(), "\n")))
+}
+
+func TestScrolling(t *testing.T) {
+	table := searchtable.New([]string{"default", "kube-system", "monitoring"}, 2, "", false, searchtable.Options{})
+
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	up := tea.KeyMsg{Type: tea.KeyUp}
+
+	tests := []struct {
+		key  tea.KeyMsg
+		want string
+	}{
+		{down, "kube-system"},
+		{down, "monitoring"},
+		{down, "monitoring"},
+		{up, "kube-system"},
+		{up, "default"},
+	}
+	for _, tt := range tests {
+		table, _ = table.Update(tt.key)
+		assert.Equal(t, tt.want, highlightedItem(table.View()))
+	}
+}
+
+func TestUpdateHeightKeepsCursorOnItem(t *testing.T) {
+	table := searchtable.New([]string{"default", "kube-system", "monitoring"}, 10, "", false, searchtable.Options{})
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyDown})
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	table, _ = table.Update(searchtable.UpdateHeight{Height: 7})
+	assert.Equal(t, "monitoring", highlightedItem(table.View()))
+	assert.NotContains(t, table.View(), "default")
+
+	table, _ = table.Update(searchtable.UpdateHeight{Height: 20})
+	assert.Equal(t, "monitoring", highlightedItem(table.View()))
+	assert.Contains(t, table.View(), "default")
+}
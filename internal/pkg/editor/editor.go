@@ -0,0 +1,160 @@
+// Package editor allows the manifest of a kubernetes resource to be edited in the editor of the user, in the same way as `kubectl edit`.
+package editor
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"kubeui/internal/pkg/kubeui"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pmezard/go-difflib/difflib"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// defaultEditor is used if neither KUBE_EDITOR nor EDITOR is set.
+const defaultEditor = "vi"
+
+// EditedMsg is sent when the user has closed the editor.
+type EditedMsg struct {
+	// Namespace and name of the edited resource.
+	Namespace string
+	Name      string
+	// Manifest before and after editing.
+	Original []byte
+	Edited   []byte
+}
+
+// Changed reports whether the manifest was changed in the editor.
+func (m EditedMsg) Changed() bool {
+	return !bytes.Equal(bytes.TrimSpace(m.Original), bytes.TrimSpace(m.Edited))
+}
+
+// Unmarshal parses the edited manifest into obj.
+// Unknown fields are rejected, as are changes to the name or namespace since they would make the edit apply to another resource.
+func (m EditedMsg) Unmarshal(obj metav1.Object) error {
+	if err := yaml.UnmarshalStrict(m.Edited, obj); err != nil {
+		return m.Error(fmt.Errorf("failed to parse edited manifest: %v", err))
+	}
+
+	if obj.GetName() != m.Name || obj.GetNamespace() != m.Namespace {
+		return m.Error(fmt.Errorf("the name and namespace of %s can not be changed", m.Name))
+	}
+
+	return nil
+}
+
+// Error adds a diff of the edited manifest to err, so that the user can see which changes were not applied.
+func (m EditedMsg) Error(err error) error {
+	return kubeui.DetailedError{
+		Err:     err,
+		Details: Diff(m.Original, m.Edited),
+	}
+}
+
+// Edit writes the manifest of obj to a temporary file and opens it in the editor of the user while the program is suspended.
+// An EditedMsg is returned when the editor is closed.
+// The managed fields of obj are cleared, since they are maintained by the server and only get in the way when editing.
+func Edit(obj metav1.Object) tea.Cmd {
+	obj.SetManagedFields(nil)
+
+	manifest, err := yaml.Marshal(obj)
+	if err != nil {
+		return kubeui.Error(fmt.Errorf("failed to marshal %s: %v", obj.GetName(), err))
+	}
+
+	path, err := writeTempFile(manifest)
+	if err != nil {
+		return kubeui.Error(err)
+	}
+
+	msg := EditedMsg{
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Original:  manifest,
+	}
+
+	return tea.ExecProcess(Command(path), func(err error) tea.Msg {
+		defer os.Remove(path)
+
+		if err != nil {
+			return fmt.Errorf("failed to run editor: %v", err)
+		}
+
+		edited, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read edited manifest: %v", err)
+		}
+
+		msg.Edited = edited
+		return msg
+	})
+}
+
+// writeTempFile writes the manifest to a new temporary file and returns its path.
+func writeTempFile(manifest []byte) (string, error) {
+	file, err := os.CreateTemp("", "kubeui-edit-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %v", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(manifest); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write temporary file: %v", err)
+	}
+
+	return file.Name(), nil
+}
+
+// Command creates the command which opens a file in the editor of the user.
+// The editor is taken from KUBE_EDITOR or EDITOR, in that order, and may include arguments such as "code --wait".
+func Command(path string) *exec.Cmd {
+	editor := os.Getenv("KUBE_EDITOR")
+	if strings.TrimSpace(editor) == "" {
+		editor = os.Getenv("EDITOR")
+	}
+
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{defaultEditor}
+	}
+
+	return exec.Command(args[0], append(args[1:], path)...)
+}
+
+// Diff creates a unified diff between the original and the edited manifest.
+func Diff(original, edited []byte) string {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(original),
+		B:        splitLines(edited),
+		FromFile: "original",
+		ToFile:   "edited",
+		Context:  3,
+	})
+
+	if err != nil {
+		return ""
+	}
+
+	return diff
+}
+
+// splitLines splits a manifest into lines which all end with a newline, as expected by difflib.
+func splitLines(manifest []byte) []string {
+	text := string(manifest)
+	if text == "" {
+		return []string{}
+	}
+
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	return lines[:len(lines)-1]
+}
@@ -0,0 +1,93 @@
+package editor_test
+
+import (
+	"fmt"
+	"testing"
+
+	"kubeui/internal/pkg/editor"
+	"kubeui/internal/pkg/kubeui"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestCommand(t *testing.T) {
+	tests := []struct {
+		name       string
+		kubeEditor string
+		editor     string
+		want       []string
+	}{
+		{"no editor set", "", "", []string{"vi", "/tmp/pod.yaml"}},
+		{"editor set", "", "nano", []string{"nano", "/tmp/pod.yaml"}},
+		{"kube editor takes precedence", "vim", "nano", []string{"vim", "/tmp/pod.yaml"}},
+		{"editor with arguments", "", "code --wait", []string{"code", "--wait", "/tmp/pod.yaml"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("KUBE_EDITOR", tt.kubeEditor)
+			t.Setenv("EDITOR", tt.editor)
+
+			cmd := editor.Command("/tmp/pod.yaml")
+			assert.Equal(t, tt.want, cmd.Args)
+		})
+	}
+}
+
+func TestEditedMsg_Changed(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		edited   string
+		want     bool
+	}{
+		{"unchanged", "a: b\n", "a: b\n", false},
+		{"trailing whitespace", "a: b\n", "a: b\n\n", false},
+		{"changed", "a: b\n", "a: c\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := editor.EditedMsg{Original: []byte(tt.original), Edited: []byte(tt.edited)}
+			assert.Equal(t, tt.want, msg.Changed())
+		})
+	}
+}
+
+func TestEditedMsg_Unmarshal(t *testing.T) {
+	original := "metadata:\n  name: web\n  namespace: default\n"
+
+	tests := []struct {
+		name    string
+		edited  string
+		wantErr bool
+	}{
+		{"valid", "metadata:\n  name: web\n  namespace: default\n  labels:\n    app: web\n", false},
+		{"invalid yaml", "metadata: [\n", true},
+		{"unknown field", "metadata:\n  name: web\n  namespace: default\n  unknown: true\n", true},
+		{"renamed", "metadata:\n  name: api\n  namespace: default\n", true},
+		{"moved", "metadata:\n  name: web\n  namespace: other\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := editor.EditedMsg{Namespace: "default", Name: "web", Original: []byte(original), Edited: []byte(tt.edited)}
+
+			err := msg.Unmarshal(&v1.Pod{})
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.NotEmpty(t, kubeui.ErrorDetails(err))
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestEditedMsg_Error(t *testing.T) {
+	msg := editor.EditedMsg{Original: []byte("a: b\n"), Edited: []byte("a: c\n")}
+
+	err := msg.Error(fmt.Errorf("conflict"))
+
+	assert.Equal(t, "conflict", err.Error())
+	assert.Equal(t, "--- original\n+++ edited\n@@ -1 +1 @@\n-a: b\n+a: c\n", kubeui.ErrorDetails(err))
+}
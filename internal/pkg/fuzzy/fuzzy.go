@@ -0,0 +1,287 @@
+// Package fuzzy provides the fuzzy matching used when searching the tables of kubeui.
+//
+// A query consists of whitespace separated terms that all have to match.
+// A term is matched against all values of an item unless it is prefixed with the name of a column, as in "status:crash".
+package fuzzy
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/utils/integer"
+)
+
+// Scores used to rank matches, a higher score is a better match.
+const (
+	scoreMatch       = 16
+	bonusConsecutive = 16
+	bonusBoundary    = 12
+	penaltyGap       = 2
+	maxPenaltyGap    = 12
+)
+
+// AnyColumn is the column of a term that is not targeted at a specific column.
+const AnyColumn = -1
+
+// Match describes how a pattern matched a value.
+type Match struct {
+	Score int
+	// The indexes of the runes in the value that matched the pattern, in ascending order.
+	Indexes []int
+}
+
+// Find matches a pattern against a value, ignoring case.
+// The value matches if all runes of the pattern are found in the value in the same order.
+// The last return value is false if the value does not match.
+func Find(pattern, value string) (Match, bool) {
+	patternRunes := []rune(strings.ToLower(pattern))
+	valueRunes := []rune(value)
+
+	if len(patternRunes) == 0 {
+		return Match{}, true
+	}
+
+	lowerRunes := make([]rune, len(valueRunes))
+	for i, r := range valueRunes {
+		lowerRunes[i] = unicode.ToLower(r)
+	}
+
+	best := Match{}
+	found := false
+
+	// Matching greedily from each possible start finds the best match in most practical cases,
+	// such as preferring "web" at the start of "web-worker" over the scattered letters in "w-e-b".
+	for start, r := range lowerRunes {
+		if r != patternRunes[0] {
+			continue
+		}
+
+		match, ok := matchFrom(patternRunes, valueRunes, lowerRunes, start)
+		if ok && (!found || match.Score > best.Score) {
+			best = match
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+// matchFrom matches the pattern greedily starting at the given index of the value.
+func matchFrom(pattern, value, lower []rune, start int) (Match, bool) {
+	indexes := make([]int, 0, len(pattern))
+	score := 0
+	previous := -1
+
+	for i, p := 0, start; i < len(pattern); i, p = i+1, p+1 {
+		for p < len(lower) && lower[p] != pattern[i] {
+			p++
+		}
+
+		if p == len(lower) {
+			return Match{}, false
+		}
+
+		score += scoreMatch
+
+		if isBoundary(value, p) {
+			score += bonusBoundary
+		}
+
+		if previous >= 0 && p == previous+1 {
+			score += bonusConsecutive
+		} else if previous >= 0 {
+			score -= integer.IntMin(penaltyGap*(p-previous-1), maxPenaltyGap)
+		}
+
+		indexes = append(indexes, p)
+		previous = p
+	}
+
+	// Matches further into the value are ranked lower.
+	score -= integer.IntMin(start, maxPenaltyGap)
+
+	return Match{Score: score, Indexes: indexes}, true
+}
+
+// isBoundary reports whether the rune at the index starts a word, such as after a dash or in camel case.
+func isBoundary(value []rune, index int) bool {
+	if index == 0 {
+		return true
+	}
+
+	previous, current := value[index-1], value[index]
+
+	switch {
+	case !unicode.IsLetter(previous) && !unicode.IsDigit(previous):
+		return true
+	case unicode.IsLower(previous) && unicode.IsUpper(current):
+		return true
+	case unicode.IsLetter(previous) != unicode.IsLetter(current):
+		return true
+	}
+
+	return false
+}
+
+// Term is a single part of a query.
+type Term struct {
+	// The index of the column the term is matched against, or AnyColumn.
+	Column  int
+	Pattern string
+}
+
+// ParseQuery splits a query into terms.
+// Terms prefixed with the name of one of the columns followed by a colon are only matched against that column,
+// the column names are compared ignoring case. Other terms, including those with an unknown prefix, are matched against all columns.
+func ParseQuery(query string, columns []string) []Term {
+	terms := []Term{}
+
+	for _, field := range strings.Fields(query) {
+		term := Term{Column: AnyColumn, Pattern: field}
+
+		if name, pattern, found := strings.Cut(field, ":"); found {
+			for i, column := range columns {
+				if strings.EqualFold(name, column) {
+					term = Term{Column: i, Pattern: pattern}
+					break
+				}
+			}
+		}
+
+		terms = append(terms, term)
+	}
+
+	return terms
+}
+
+// RecordMatch describes how a query matched the values of an item.
+type RecordMatch struct {
+	Score int
+	// The indexes of the matched runes for each value, in ascending order.
+	Indexes [][]int
+}
+
+// MatchRecord matches all terms against the values of an item, the values are expected to be in the same order as the columns of the query.
+// A term that is not targeted at a column matches the value that gives the best score.
+// The last return value is false if any of the terms does not match.
+func MatchRecord(terms []Term, values []string) (RecordMatch, bool) {
+	result := RecordMatch{Indexes: make([][]int, len(values))}
+
+	for _, term := range terms {
+		best := Match{}
+		bestValue := -1
+
+		for i, value := range values {
+			if term.Column != AnyColumn && term.Column != i {
+				continue
+			}
+
+			match, ok := Find(term.Pattern, value)
+			if ok && (bestValue < 0 || match.Score > best.Score) {
+				best = match
+				bestValue = i
+			}
+		}
+
+		if bestValue < 0 {
+			return RecordMatch{}, false
+		}
+
+		result.Score += best.Score
+		result.Indexes[bestValue] = mergeIndexes(result.Indexes[bestValue], best.Indexes)
+	}
+
+	return result, true
+}
+
+// mergeIndexes returns the sorted union of two sets of indexes.
+func mergeIndexes(a, b []int) []int {
+	merged := append(append([]int{}, a...), b...)
+	sort.Ints(merged)
+
+	result := []int{}
+	for i, index := range merged {
+		if i == 0 || index != merged[i-1] {
+			result = append(result, index)
+		}
+	}
+
+	return result
+}
+
+// Highlight renders the matched runes of a value with the matched style and the other runes with the unmatched style.
+// The matched style inherits from the unmatched style, so that for example the color of the unmatched style is kept.
+func Highlight(value string, indexes []int, matched, unmatched lipgloss.Style) string {
+	if len(indexes) == 0 {
+		return unmatched.Render(value)
+	}
+
+	matched = matched.Inherit(unmatched)
+
+	var builder strings.Builder
+
+	segment := []rune{}
+	segmentMatched := false
+	next := 0
+
+	flush := func() {
+		if len(segment) == 0 {
+			return
+		}
+
+		if segmentMatched {
+			builder.WriteString(matched.Render(string(segment)))
+		} else {
+			builder.WriteString(unmatched.Render(string(segment)))
+		}
+		segment = []rune{}
+	}
+
+	for i, r := range []rune(value) {
+		isMatched := next < len(indexes) && indexes[next] == i
+		if isMatched {
+			next++
+		}
+
+		if isMatched != segmentMatched {
+			flush()
+			segmentMatched = isMatched
+		}
+
+		segment = append(segment, r)
+	}
+	flush()
+
+	return builder.String()
+}
+
+// Result is an item that matched a query.
+type Result struct {
+	// The index of the item in the records passed to Filter.
+	Index int
+	RecordMatch
+}
+
+// Filter matches a query against the values of each item and returns the items that match, in their original order.
+// An empty query matches all items.
+func Filter(query string, columns []string, records [][]string) []Result {
+	terms := ParseQuery(query, columns)
+
+	results := []Result{}
+	for i, values := range records {
+		if match, ok := MatchRecord(terms, values); ok {
+			results = append(results, Result{Index: i, RecordMatch: match})
+		}
+	}
+
+	return results
+}
+
+// SortByScore sorts results with the best match first, results with equal scores keep their order.
+func SortByScore(results []Result) {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+}
@@ -0,0 +1,146 @@
+package fuzzy_test
+
+import (
+	"testing"
+
+	"kubeui/internal/pkg/fuzzy"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name        string
+		pattern     string
+		value       string
+		wantOk      bool
+		wantIndexes []int
+	}{
+		{"empty pattern", "", "web-1", true, nil},
+		{"substring", "web", "web-1", true, []int{0, 1, 2}},
+		{"ignores case", "crashloop", "CrashLoopBackOff", true, []int{0, 1, 2, 3, 4, 5, 6, 7, 8}},
+		{"scattered", "wb1", "web-1", true, []int{0, 2, 4}},
+		{"prefers word start", "w", "db-worker", true, []int{3}},
+		{"prefers consecutive", "web", "w-e-b-web", true, []int{6, 7, 8}},
+		{"wrong order", "bew", "web-1", false, nil},
+		{"missing rune", "webx", "web-1", false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, ok := fuzzy.Find(tt.pattern, tt.value)
+			assert.Equal(t, tt.wantOk, ok)
+			if tt.wantIndexes != nil {
+				assert.Equal(t, tt.wantIndexes, match.Indexes)
+			}
+		})
+	}
+}
+
+func TestFindRanking(t *testing.T) {
+	exact, _ := fuzzy.Find("web", "web")
+	prefix, _ := fuzzy.Find("web", "web-worker")
+	scattered, _ := fuzzy.Find("web", "w-e-b")
+	late, _ := fuzzy.Find("web", "frontend-web")
+
+	assert.Greater(t, exact.Score, scattered.Score)
+	assert.Greater(t, prefix.Score, scattered.Score)
+	assert.Greater(t, prefix.Score, late.Score)
+	assert.Greater(t, late.Score, scattered.Score)
+}
+
+func TestParseQuery(t *testing.T) {
+	columns := []string{"Name", "Status"}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []fuzzy.Term
+	}{
+		{"empty", "", []fuzzy.Term{}},
+		{"any column", "web", []fuzzy.Term{{Column: fuzzy.AnyColumn, Pattern: "web"}}},
+		{"targeted column", "status:crash", []fuzzy.Term{{Column: 1, Pattern: "crash"}}},
+		{"column name ignores case", "NAME:web", []fuzzy.Term{{Column: 0, Pattern: "web"}}},
+		{"unknown column", "age:5m", []fuzzy.Term{{Column: fuzzy.AnyColumn, Pattern: "age:5m"}}},
+		{"several terms", "web status:run", []fuzzy.Term{{Column: fuzzy.AnyColumn, Pattern: "web"}, {Column: 1, Pattern: "run"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fuzzy.ParseQuery(tt.query, columns))
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	columns := []string{"Name", "Status"}
+	records := [][]string{
+		{"web-1", "Running"},
+		{"db-1", "CrashLoopBackOff"},
+		{"crash-reporter", "Running"},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []int
+	}{
+		{"empty query", "", []int{0, 1, 2}},
+		{"any column", "crash", []int{1, 2}},
+		{"targeted column", "status:crash", []int{1}},
+		{"all terms must match", "crash run", []int{2}},
+		{"no match", "worker", []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := fuzzy.Filter(tt.query, columns, records)
+
+			indexes := []int{}
+			for _, r := range results {
+				indexes = append(indexes, r.Index)
+			}
+
+			assert.Equal(t, tt.want, indexes)
+		})
+	}
+}
+
+func TestFilterMatchedIndexes(t *testing.T) {
+	results := fuzzy.Filter("status:run web", []string{"Name", "Status"}, [][]string{{"web-1", "Running"}})
+
+	assert.Len(t, results, 1)
+	assert.Equal(t, [][]int{{0, 1, 2}, {0, 1, 2}}, results[0].Indexes)
+}
+
+func TestSortByScore(t *testing.T) {
+	results := fuzzy.Filter("web", nil, [][]string{{"w-e-b"}, {"frontend-web"}, {"web"}, {"w-e-b"}})
+	fuzzy.SortByScore(results)
+
+	indexes := []int{}
+	for _, r := range results {
+		indexes = append(indexes, r.Index)
+	}
+
+	assert.Equal(t, []int{2, 1, 0, 3}, indexes)
+}
+
+func TestHighlight(t *testing.T) {
+	// Renders the style as brackets, since no colors are output when testing.
+	matched := lipgloss.NewStyle().Transform(func(s string) string { return "[" + s + "]" })
+
+	tests := []struct {
+		name    string
+		value   string
+		indexes []int
+		want    string
+	}{
+		{"no matches", "web-1", nil, "web-1"},
+		{"consecutive", "web-1", []int{0, 1, 2}, "[web]-1"},
+		{"scattered", "web-1", []int{0, 2, 4}, "[w]e[b]-[1]"},
+		{"multibyte", "åäö", []int{1}, "å[ä]ö"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fuzzy.Highlight(tt.value, tt.indexes, matched, lipgloss.NewStyle()))
+		})
+	}
+}
@@ -64,17 +64,19 @@ func lightColorSet() ColorSet {
 	}
 }
 
-// NewFormatter creates a new formatter.
-func NewFormatter() *Formatter {
-
-	colorSet := defaultColorSet()
-
+// NewColorSet returns the ColorSet matching the background of the terminal.
+func NewColorSet() ColorSet {
 	if !lipgloss.HasDarkBackground() {
-		colorSet = lightColorSet()
+		return lightColorSet()
 	}
 
+	return defaultColorSet()
+}
+
+// NewFormatter creates a new formatter.
+func NewFormatter() *Formatter {
 	return &Formatter{
-		ColorSet:        colorSet,
+		ColorSet:        NewColorSet(),
 		StringMaxLength: 0,
 		Indent:          0,
 		RawStrings:      false,
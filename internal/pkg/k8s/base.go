@@ -2,13 +2,15 @@ package k8s
 
 import (
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
 )
 
 // NewKClientSet creates a kubernetes ClientSet that can be used to issue kubernetes commands.
 func NewKClientSet(kubeconfig string, access clientcmd.ConfigAccess) (*kubernetes.Clientset, error) {
 
-	config, err := clientcmd.BuildConfigFromKubeconfigGetter("", access.GetStartingConfig)
+	config, err := NewRestConfig(access)
 	if err != nil {
 		return nil, err
 	}
@@ -22,6 +24,17 @@ func NewKClientSet(kubeconfig string, access clientcmd.ConfigAccess) (*kubernete
 	return clientset, nil
 }
 
+// NewRestConfig creates the configuration used to connect to the current context of the kubeconfig.
+// Apart from creating a ClientSet it is needed for connections that are upgraded to streams, such as exec sessions.
+func NewRestConfig(access clientcmd.ConfigAccess) (*rest.Config, error) {
+	return clientcmd.BuildConfigFromKubeconfigGetter("", access.GetStartingConfig)
+}
+
+// NewRestConfigForConfig creates the configuration used to connect to the current context of a kubeconfig that has already been loaded.
+func NewRestConfigForConfig(config api.Config) (*rest.Config, error) {
+	return clientcmd.NewDefaultClientConfig(config, &clientcmd.ConfigOverrides{}).ClientConfig()
+}
+
 // NewClientConfig creates a ClientConfig object representing the kubeconfig of the user.
 func NewClientConfig(context, kubeconfigPath string) clientcmd.ClientConfig {
 
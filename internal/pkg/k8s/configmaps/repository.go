@@ -0,0 +1,37 @@
+package configmaps
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// Repository defines the interface for the configmaps repository.
+type Repository interface {
+	Get(ctx context.Context, namespace, name string) (*v1.ConfigMap, error)
+	List(ctx context.Context, namespace string) (*v1.ConfigMapList, error)
+}
+
+// NewRepository creates a new Repository.
+func NewRepository(kubectl corev1.CoreV1Interface) Repository {
+	return &RepositoryImpl{
+		kubectl: kubectl,
+	}
+}
+
+// RepositoryImpl is used to fetch configmap related data from kubernetes.
+type RepositoryImpl struct {
+	kubectl corev1.CoreV1Interface
+}
+
+// Get fetches a single configmap.
+func (c *RepositoryImpl) Get(ctx context.Context, namespace, name string) (*v1.ConfigMap, error) {
+	return c.kubectl.ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// List fetches a list of configmaps for a given namespace.
+func (c *RepositoryImpl) List(ctx context.Context, namespace string) (*v1.ConfigMapList, error) {
+	return c.kubectl.ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+}
@@ -0,0 +1,12 @@
+package deployments
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// Deployment contains extended information about a kubernetes deployment.
+type Deployment struct {
+	Deployment appsv1.Deployment
+	Events     []v1.Event
+}
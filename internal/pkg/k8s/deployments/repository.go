@@ -0,0 +1,62 @@
+package deployments
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	typedappsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// Repository defines the interface for the deployments repository.
+type Repository interface {
+	Get(ctx context.Context, namespace, name string) (*appsv1.Deployment, error)
+	List(ctx context.Context, namespace string) (*appsv1.DeploymentList, error)
+	Events(ctx context.Context, namespace, name string) (*v1.EventList, error)
+	Patch(ctx context.Context, namespace, name string, patchType types.PatchType, data []byte) (*appsv1.Deployment, error)
+	Update(ctx context.Context, namespace string, deployment *appsv1.Deployment) (*appsv1.Deployment, error)
+}
+
+// NewRepository creates a new Repository.
+func NewRepository(apps typedappsv1.AppsV1Interface, kubectl corev1.CoreV1Interface) Repository {
+	return &RepositoryImpl{
+		apps:    apps,
+		kubectl: kubectl,
+	}
+}
+
+// RepositoryImpl is used to fetch deployment related data from kubernetes.
+type RepositoryImpl struct {
+	apps    typedappsv1.AppsV1Interface
+	kubectl corev1.CoreV1Interface
+}
+
+// Get fetches a single deployment.
+func (c *RepositoryImpl) Get(ctx context.Context, namespace, name string) (*appsv1.Deployment, error) {
+	return c.apps.Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// List fetches a list of deployments for a given namespace.
+func (c *RepositoryImpl) List(ctx context.Context, namespace string) (*appsv1.DeploymentList, error) {
+	return c.apps.Deployments(namespace).List(ctx, metav1.ListOptions{})
+}
+
+// Events fetches the current events for a deployment.
+func (c *RepositoryImpl) Events(ctx context.Context, namespace, name string) (*v1.EventList, error) {
+	return c.kubectl.Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: fmt.Sprintf("involvedObject.name=%s,involvedObject.kind=Deployment", name)})
+}
+
+// Patch applies a patch to a deployment.
+func (c *RepositoryImpl) Patch(ctx context.Context, namespace, name string, patchType types.PatchType, data []byte) (*appsv1.Deployment, error) {
+	return c.apps.Deployments(namespace).Patch(ctx, name, patchType, data, metav1.PatchOptions{})
+}
+
+// Update replaces a deployment with the given version.
+// The update is rejected with a conflict if the resource version of the deployment does not match the current version in the cluster.
+func (c *RepositoryImpl) Update(ctx context.Context, namespace string, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	return c.apps.Deployments(namespace).Update(ctx, deployment, metav1.UpdateOptions{})
+}
@@ -2,11 +2,17 @@ package k8s
 
 import (
 	"fmt"
+	"kubeui/internal/pkg/k8s/ingresses"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/life4/genesis/slices"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/duration"
 )
 
@@ -134,6 +140,326 @@ func NewListEventFormat(event v1.Event, now time.Time) *ListEventFormat {
 	}
 }
 
+// ListDeploymentFormat contains information about a deployment, as shown when running `kubectl get deployments`.
+type ListDeploymentFormat struct {
+	Name      string
+	Ready     string
+	UpToDate  string
+	Available string
+	Age       string
+}
+
+// NewListDeploymentFormat collects the ListDeploymentFormat information for a given deployment.
+func NewListDeploymentFormat(deployment appsv1.Deployment, now time.Time) *ListDeploymentFormat {
+
+	return &ListDeploymentFormat{
+		Name:      deployment.Name,
+		Ready:     fmt.Sprintf("%d/%d", deployment.Status.ReadyReplicas, DesiredReplicas(deployment.Spec.Replicas)),
+		UpToDate:  fmt.Sprintf("%d", deployment.Status.UpdatedReplicas),
+		Available: fmt.Sprintf("%d", deployment.Status.AvailableReplicas),
+		Age:       duration.HumanDuration(now.Sub(deployment.CreationTimestamp.Time)),
+	}
+}
+
+// ListStatefulSetFormat contains information about a statefulset, as shown when running `kubectl get statefulsets`.
+type ListStatefulSetFormat struct {
+	Name  string
+	Ready string
+	Age   string
+}
+
+// NewListStatefulSetFormat collects the ListStatefulSetFormat information for a given statefulset.
+func NewListStatefulSetFormat(statefulSet appsv1.StatefulSet, now time.Time) *ListStatefulSetFormat {
+
+	return &ListStatefulSetFormat{
+		Name:  statefulSet.Name,
+		Ready: fmt.Sprintf("%d/%d", statefulSet.Status.ReadyReplicas, DesiredReplicas(statefulSet.Spec.Replicas)),
+		Age:   duration.HumanDuration(now.Sub(statefulSet.CreationTimestamp.Time)),
+	}
+}
+
+// ListConfigMapFormat contains information about a configmap, as shown when running `kubectl get configmaps`.
+type ListConfigMapFormat struct {
+	Name string
+	Data string
+	Age  string
+}
+
+// NewListConfigMapFormat collects the ListConfigMapFormat information for a given configmap.
+func NewListConfigMapFormat(configMap v1.ConfigMap, now time.Time) *ListConfigMapFormat {
+
+	return &ListConfigMapFormat{
+		Name: configMap.Name,
+		Data: fmt.Sprintf("%d", len(configMap.Data)+len(configMap.BinaryData)),
+		Age:  duration.HumanDuration(now.Sub(configMap.CreationTimestamp.Time)),
+	}
+}
+
+// ListSecretFormat contains information about a secret, as shown when running `kubectl get secrets`.
+type ListSecretFormat struct {
+	Name string
+	Type string
+	Data string
+	Age  string
+}
+
+// NewListSecretFormat collects the ListSecretFormat information for a given secret.
+func NewListSecretFormat(secret v1.Secret, now time.Time) *ListSecretFormat {
+
+	return &ListSecretFormat{
+		Name: secret.Name,
+		Type: string(secret.Type),
+		Data: fmt.Sprintf("%d", len(secret.Data)),
+		Age:  duration.HumanDuration(now.Sub(secret.CreationTimestamp.Time)),
+	}
+}
+
+// ListNodeFormat contains information about a node, as shown when running `kubectl get nodes` together with the allocatable resources and the number of pods.
+type ListNodeFormat struct {
+	Name    string
+	Status  string
+	Roles   string
+	Version string
+	Age     string
+	CPU     string
+	Memory  string
+	Pods    string
+}
+
+// NewListNodeFormat collects the ListNodeFormat information for a given node and the number of pods running on it.
+func NewListNodeFormat(node v1.Node, podCount int, now time.Time) *ListNodeFormat {
+
+	return &ListNodeFormat{
+		Name:    node.Name,
+		Status:  NodeStatus(node),
+		Roles:   NodeRoles(node),
+		Version: node.Status.NodeInfo.KubeletVersion,
+		Age:     duration.HumanDuration(now.Sub(node.CreationTimestamp.Time)),
+		CPU:     node.Status.Allocatable.Cpu().String(),
+		Memory:  FormatBytes(node.Status.Allocatable.Memory().Value()),
+		Pods:    fmt.Sprintf("%d", podCount),
+	}
+}
+
+// NodeStatus returns the status of a node based on its ready condition, with SchedulingDisabled added if the node is cordoned.
+func NodeStatus(node v1.Node) string {
+	status := "Unknown"
+
+	for _, condition := range node.Status.Conditions {
+		if condition.Type != v1.NodeReady {
+			continue
+		}
+
+		switch condition.Status {
+		case v1.ConditionTrue:
+			status = "Ready"
+		case v1.ConditionFalse:
+			status = "NotReady"
+		}
+	}
+
+	if node.Spec.Unschedulable {
+		status += ",SchedulingDisabled"
+	}
+
+	return status
+}
+
+// nodeRoleLabelPrefix is the prefix of the labels that define the roles of a node, such as node-role.kubernetes.io/control-plane.
+const nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+// NodeRoles returns the roles of a node as a comma separated list, or <none> if the node has no roles.
+// The roles are read from the labels of the node the same way as `kubectl get nodes` does.
+func NodeRoles(node v1.Node) string {
+	roles := []string{}
+
+	for label, value := range node.Labels {
+		switch {
+		case strings.HasPrefix(label, nodeRoleLabelPrefix) && label != nodeRoleLabelPrefix:
+			roles = append(roles, strings.TrimPrefix(label, nodeRoleLabelPrefix))
+		case label == "kubernetes.io/role" && value != "":
+			roles = append(roles, value)
+		}
+	}
+
+	if len(roles) == 0 {
+		return "<none>"
+	}
+
+	sort.Strings(roles)
+
+	return strings.Join(roles, ",")
+}
+
+// byteUnits are the binary units used by FormatBytes, from the largest to the smallest.
+var byteUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"Ti", 1 << 40},
+	{"Gi", 1 << 30},
+	{"Mi", 1 << 20},
+	{"Ki", 1 << 10},
+}
+
+// FormatBytes formats a number of bytes using the largest binary unit that the number is at least one of, such as 15.6Gi.
+func FormatBytes(bytes int64) string {
+	for _, unit := range byteUnits {
+		if bytes >= unit.size {
+			value := strconv.FormatFloat(float64(bytes)/float64(unit.size), 'f', 1, 64)
+			return strings.TrimSuffix(value, ".0") + unit.suffix
+		}
+	}
+
+	return fmt.Sprintf("%d", bytes)
+}
+
+// ListServiceFormat contains information about a service, as shown when running `kubectl get services -o wide`.
+type ListServiceFormat struct {
+	Name        string
+	Type        string
+	ClusterIP   string
+	ExternalIPs string
+	Ports       string
+	Selector    string
+	Age         string
+}
+
+// NewListServiceFormat collects the ListServiceFormat information for a given service.
+func NewListServiceFormat(service v1.Service, now time.Time) *ListServiceFormat {
+
+	return &ListServiceFormat{
+		Name:        service.Name,
+		Type:        string(service.Spec.Type),
+		ClusterIP:   notEmptyOrNone(service.Spec.ClusterIP),
+		ExternalIPs: ServiceExternalIPs(service),
+		Ports:       ServicePorts(service),
+		Selector:    notEmptyOrNone(labels.SelectorFromSet(service.Spec.Selector).String()),
+		Age:         duration.HumanDuration(now.Sub(service.CreationTimestamp.Time)),
+	}
+}
+
+// ServiceExternalIPs returns the external addresses of a service, the same way as `kubectl get services`.
+// Load balancers that have not been assigned an address yet are shown as <pending>.
+func ServiceExternalIPs(service v1.Service) string {
+	switch service.Spec.Type {
+	case v1.ServiceTypeExternalName:
+		return service.Spec.ExternalName
+
+	case v1.ServiceTypeLoadBalancer:
+		addresses := append([]string{}, service.Spec.ExternalIPs...)
+
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				addresses = append(addresses, ingress.IP)
+			} else if ingress.Hostname != "" {
+				addresses = append(addresses, ingress.Hostname)
+			}
+		}
+
+		if len(addresses) == 0 {
+			return "<pending>"
+		}
+
+		return strings.Join(addresses, ",")
+	}
+
+	return notEmptyOrNone(strings.Join(service.Spec.ExternalIPs, ","))
+}
+
+// ServicePorts returns the ports of a service formatted as port/protocol, or port:nodePort/protocol for ports that are exposed on the nodes.
+func ServicePorts(service v1.Service) string {
+	ports := slices.Map(service.Spec.Ports, func(p v1.ServicePort) string {
+		if p.NodePort == 0 {
+			return fmt.Sprintf("%d/%s", p.Port, p.Protocol)
+		}
+
+		return fmt.Sprintf("%d:%d/%s", p.Port, p.NodePort, p.Protocol)
+	})
+
+	return notEmptyOrNone(strings.Join(ports, ","))
+}
+
+// notEmptyOrNone returns the string, or <none> if it is empty.
+func notEmptyOrNone(str string) string {
+	if str == "" {
+		return "<none>"
+	}
+
+	return str
+}
+
+// ListIngressRouteFormat contains information about a route of an ingress, which is a host and path together with its backend.
+type ListIngressRouteFormat struct {
+	Ingress string
+	Host    string
+	Path    string
+	Backend string
+	TLS     string
+	Status  string
+}
+
+// NewListIngressRouteFormat collects the ListIngressRouteFormat information for a given route.
+// Routes matching all hosts are shown with the host *, and the status of a route is either OK or its problems.
+func NewListIngressRouteFormat(route ingresses.Route) *ListIngressRouteFormat {
+
+	host := route.Host
+	if host == "" {
+		host = "*"
+	}
+
+	path := notEmptyOrNone(route.Path)
+	if route.PathType != "" {
+		path = fmt.Sprintf("%s (%s)", path, route.PathType)
+	}
+
+	status := "OK"
+	if len(route.Problems) > 0 {
+		status = strings.Join(route.Problems, "; ")
+	}
+
+	return &ListIngressRouteFormat{
+		Ingress: route.Ingress,
+		Host:    host,
+		Path:    path,
+		Backend: notEmptyOrNone(route.Backend),
+		TLS:     notEmptyOrNone(route.TLSSecret),
+		Status:  status,
+	}
+}
+
+// FormatTableCell formats a cell of a table returned by the api server, the same way as `kubectl get` does.
+// Cells of date columns contain timestamps, which are formatted as the age relative to now.
+func FormatTableCell(column metav1.TableColumnDefinition, cell interface{}, now time.Time) string {
+	if cell == nil {
+		return "<none>"
+	}
+
+	switch value := cell.(type) {
+	case string:
+		if column.Type == "date" {
+			if t, err := time.Parse(time.RFC3339, value); err == nil {
+				return duration.HumanDuration(now.Sub(t))
+			}
+		}
+	// Numbers are decoded from json as floats, which are printed without exponent.
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	return fmt.Sprint(cell)
+}
+
+// DesiredReplicas returns the number of desired replicas given the replicas field of a workload spec.
+// Kubernetes defaults the number of replicas to 1 if it is not set.
+func DesiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+
+	return *replicas
+}
+
 func IsPodInitializing(pod v1.Pod) bool {
 	initializing := false
 
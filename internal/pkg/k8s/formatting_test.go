@@ -3,11 +3,14 @@ package k8s_test
 import (
 	"fmt"
 	"kubeui/internal/pkg/k8s"
+	"kubeui/internal/pkg/k8s/ingresses"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
 )
@@ -502,3 +505,322 @@ func TestPodNonInitStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestNewListDeploymentFormat(t *testing.T) {
+
+	comparisonTime := time.Now()
+	createdTime := comparisonTime.Add(-(2 * time.Hour))
+	replicas := int32(3)
+
+	tests := []struct {
+		name       string
+		deployment appsv1.Deployment
+		want       *k8s.ListDeploymentFormat
+	}{
+		{
+			"Should use the desired number of replicas",
+			appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", CreationTimestamp: metav1.NewTime(createdTime)},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				Status:     appsv1.DeploymentStatus{ReadyReplicas: 2, UpdatedReplicas: 3, AvailableReplicas: 1},
+			},
+			&k8s.ListDeploymentFormat{Name: "web", Ready: "2/3", UpToDate: "3", Available: "1", Age: "120m"},
+		},
+		{
+			"Should default to one desired replica",
+			appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", CreationTimestamp: metav1.NewTime(createdTime)},
+			},
+			&k8s.ListDeploymentFormat{Name: "web", Ready: "0/1", UpToDate: "0", Available: "0", Age: "120m"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := k8s.NewListDeploymentFormat(tt.deployment, comparisonTime)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewListStatefulSetFormat(t *testing.T) {
+
+	comparisonTime := time.Now()
+	createdTime := comparisonTime.Add(-(2 * time.Hour))
+	replicas := int32(3)
+
+	tests := []struct {
+		name        string
+		statefulSet appsv1.StatefulSet
+		want        *k8s.ListStatefulSetFormat
+	}{
+		{
+			"Should use the desired number of replicas",
+			appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", CreationTimestamp: metav1.NewTime(createdTime)},
+				Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+				Status:     appsv1.StatefulSetStatus{ReadyReplicas: 2},
+			},
+			&k8s.ListStatefulSetFormat{Name: "db", Ready: "2/3", Age: "120m"},
+		},
+		{
+			"Should default to one desired replica",
+			appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", CreationTimestamp: metav1.NewTime(createdTime)},
+			},
+			&k8s.ListStatefulSetFormat{Name: "db", Ready: "0/1", Age: "120m"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := k8s.NewListStatefulSetFormat(tt.statefulSet, comparisonTime)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewListNodeFormat(t *testing.T) {
+
+	comparisonTime := time.Now()
+	createdTime := comparisonTime.Add(-(2 * time.Hour))
+
+	allocatable := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("3920m"),
+		v1.ResourceMemory: resource.MustParse("16384Mi"),
+	}
+
+	tests := []struct {
+		name     string
+		node     v1.Node
+		podCount int
+		want     *k8s.ListNodeFormat
+	}{
+		{
+			"Should format a ready control plane node",
+			v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "node-1",
+					CreationTimestamp: metav1.NewTime(createdTime),
+					Labels:            map[string]string{"node-role.kubernetes.io/control-plane": "", "node-role.kubernetes.io/etcd": "true"},
+				},
+				Status: v1.NodeStatus{
+					Conditions:  []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+					NodeInfo:    v1.NodeSystemInfo{KubeletVersion: "v1.32.1"},
+					Allocatable: allocatable,
+				},
+			},
+			12,
+			&k8s.ListNodeFormat{Name: "node-1", Status: "Ready", Roles: "control-plane,etcd", Version: "v1.32.1", Age: "120m", CPU: "3920m", Memory: "16Gi", Pods: "12"},
+		},
+		{
+			"Should format a cordoned node without roles",
+			v1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-2", CreationTimestamp: metav1.NewTime(createdTime)},
+				Spec:       v1.NodeSpec{Unschedulable: true},
+				Status: v1.NodeStatus{
+					Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionFalse}},
+				},
+			},
+			0,
+			&k8s.ListNodeFormat{Name: "node-2", Status: "NotReady,SchedulingDisabled", Roles: "<none>", Age: "120m", CPU: "0", Memory: "0", Pods: "0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := k8s.NewListNodeFormat(tt.node, tt.podCount, comparisonTime)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNodeStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		node v1.Node
+		want string
+	}{
+		{"Should be unknown without a ready condition", v1.Node{}, "Unknown"},
+		{"Should be unknown if the ready condition is unknown", v1.Node{Status: v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionUnknown}}}}, "Unknown"},
+		{"Should ignore other conditions", v1.Node{Status: v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: v1.NodeMemoryPressure, Status: v1.ConditionTrue}}}}, "Unknown"},
+		{"Should be ready", v1.Node{Status: v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}}}}, "Ready"},
+		{"Should show that scheduling is disabled", v1.Node{Spec: v1.NodeSpec{Unschedulable: true}, Status: v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}}}}, "Ready,SchedulingDisabled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, k8s.NodeStatus(tt.node))
+		})
+	}
+}
+
+func TestNodeRoles(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   string
+	}{
+		{"Should be none without labels", nil, "<none>"},
+		{"Should ignore unrelated labels", map[string]string{"kubernetes.io/hostname": "node-1"}, "<none>"},
+		{"Should use the role labels", map[string]string{"node-role.kubernetes.io/worker": "", "node-role.kubernetes.io/control-plane": ""}, "control-plane,worker"},
+		{"Should use the legacy role label", map[string]string{"kubernetes.io/role": "master"}, "master"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, k8s.NodeRoles(v1.Node{ObjectMeta: metav1.ObjectMeta{Labels: tt.labels}}))
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		bytes int64
+		want  string
+	}{
+		{0, "0"},
+		{512, "512"},
+		{2048, "2Ki"},
+		{1536 * 1024, "1.5Mi"},
+		{16_754_606_080, "15.6Gi"},
+		{3 << 40, "3Ti"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, k8s.FormatBytes(tt.bytes))
+		})
+	}
+}
+
+func TestNewListConfigMapFormat(t *testing.T) {
+
+	comparisonTime := time.Now()
+	createdTime := comparisonTime.Add(-(2 * time.Hour))
+
+	configMap := v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "settings", CreationTimestamp: metav1.NewTime(createdTime)},
+		Data:       map[string]string{"a": "1", "b": "2"},
+		BinaryData: map[string][]byte{"c": {0}},
+	}
+
+	got := k8s.NewListConfigMapFormat(configMap, comparisonTime)
+	assert.Equal(t, &k8s.ListConfigMapFormat{Name: "settings", Data: "3", Age: "120m"}, got)
+}
+
+func TestNewListSecretFormat(t *testing.T) {
+
+	comparisonTime := time.Now()
+	createdTime := comparisonTime.Add(-(2 * time.Hour))
+
+	secret := v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tls", CreationTimestamp: metav1.NewTime(createdTime)},
+		Type:       v1.SecretTypeTLS,
+		Data:       map[string][]byte{"tls.crt": {}, "tls.key": {}},
+	}
+
+	got := k8s.NewListSecretFormat(secret, comparisonTime)
+	assert.Equal(t, &k8s.ListSecretFormat{Name: "tls", Type: "kubernetes.io/tls", Data: "2", Age: "120m"}, got)
+}
+
+func TestFormatTableCell(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		column metav1.TableColumnDefinition
+		cell   interface{}
+		want   string
+	}{
+		{"should format missing values", metav1.TableColumnDefinition{Type: "string"}, nil, "<none>"},
+		{"should format strings", metav1.TableColumnDefinition{Type: "string"}, "Running", "Running"},
+		{"should format numbers without exponent", metav1.TableColumnDefinition{Type: "integer"}, float64(1000000), "1000000"},
+		{"should format dates as ages", metav1.TableColumnDefinition{Type: "date"}, "2024-01-01T11:55:00Z", "5m"},
+		{"should keep dates that can not be parsed", metav1.TableColumnDefinition{Type: "date"}, "yesterday", "yesterday"},
+		{"should format booleans", metav1.TableColumnDefinition{Type: "boolean"}, true, "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, k8s.FormatTableCell(tt.column, tt.cell, now))
+		})
+	}
+}
+
+func TestNewListServiceFormat(t *testing.T) {
+
+	comparisonTime := time.Now()
+	createdTime := comparisonTime.Add(-(2 * time.Hour))
+
+	tests := []struct {
+		name    string
+		service v1.Service
+		want    *k8s.ListServiceFormat
+	}{
+		{
+			"should format a cluster ip service",
+			v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", CreationTimestamp: metav1.NewTime(createdTime)},
+				Spec: v1.ServiceSpec{
+					Type:      v1.ServiceTypeClusterIP,
+					ClusterIP: "10.96.0.10",
+					Ports:     []v1.ServicePort{{Port: 80, Protocol: v1.ProtocolTCP}, {Port: 53, Protocol: v1.ProtocolUDP}},
+					Selector:  map[string]string{"tier": "frontend", "app": "web"},
+				},
+			},
+			&k8s.ListServiceFormat{Name: "web", Type: "ClusterIP", ClusterIP: "10.96.0.10", ExternalIPs: "<none>", Ports: "80/TCP,53/UDP", Selector: "app=web,tier=frontend", Age: "120m"},
+		},
+		{
+			"should format a pending load balancer",
+			v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "lb", CreationTimestamp: metav1.NewTime(createdTime)},
+				Spec: v1.ServiceSpec{
+					Type:      v1.ServiceTypeLoadBalancer,
+					ClusterIP: "10.96.0.11",
+					Ports:     []v1.ServicePort{{Port: 443, NodePort: 30443, Protocol: v1.ProtocolTCP}},
+				},
+			},
+			&k8s.ListServiceFormat{Name: "lb", Type: "LoadBalancer", ClusterIP: "10.96.0.11", ExternalIPs: "<pending>", Ports: "443:30443/TCP", Selector: "<none>", Age: "120m"},
+		},
+		{
+			"should format a load balancer with addresses",
+			v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "lb", CreationTimestamp: metav1.NewTime(createdTime)},
+				Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer, ExternalIPs: []string{"1.2.3.4"}},
+				Status:     v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "5.6.7.8"}, {Hostname: "lb.example.com"}}}},
+			},
+			&k8s.ListServiceFormat{Name: "lb", Type: "LoadBalancer", ClusterIP: "<none>", ExternalIPs: "1.2.3.4,5.6.7.8,lb.example.com", Ports: "<none>", Selector: "<none>", Age: "120m"},
+		},
+		{
+			"should format an external name service",
+			v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", CreationTimestamp: metav1.NewTime(createdTime)},
+				Spec:       v1.ServiceSpec{Type: v1.ServiceTypeExternalName, ExternalName: "db.example.com"},
+			},
+			&k8s.ListServiceFormat{Name: "db", Type: "ExternalName", ClusterIP: "<none>", ExternalIPs: "db.example.com", Ports: "<none>", Selector: "<none>", Age: "120m"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, k8s.NewListServiceFormat(tt.service, comparisonTime))
+		})
+	}
+}
+
+func TestNewListIngressRouteFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		route ingresses.Route
+		want  *k8s.ListIngressRouteFormat
+	}{
+		{
+			"should format a default backend",
+			ingresses.Route{Ingress: "main", Backend: "web:80"},
+			&k8s.ListIngressRouteFormat{Ingress: "main", Host: "*", Path: "<none>", Backend: "web:80", TLS: "<none>", Status: "OK"},
+		},
+		{
+			"should format a route with problems",
+			ingresses.Route{Ingress: "main", Host: "www.example.com", Path: "/api", PathType: "Prefix", Backend: "api:http", TLSSecret: "www-tls", Problems: []string{"service api not found", "tls secret www-tls not found"}},
+			&k8s.ListIngressRouteFormat{Ingress: "main", Host: "www.example.com", Path: "/api (Prefix)", Backend: "api:http", TLS: "www-tls", Status: "service api not found; tls secret www-tls not found"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, k8s.NewListIngressRouteFormat(tt.route))
+		})
+	}
+}
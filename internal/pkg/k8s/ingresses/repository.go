@@ -0,0 +1,31 @@
+package ingresses
+
+import (
+	"context"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typednetworkingv1 "k8s.io/client-go/kubernetes/typed/networking/v1"
+)
+
+// Repository defines the interface for the ingresses repository.
+type Repository interface {
+	List(ctx context.Context, namespace string) (*networkingv1.IngressList, error)
+}
+
+// NewRepository creates a new Repository.
+func NewRepository(networking typednetworkingv1.NetworkingV1Interface) Repository {
+	return &RepositoryImpl{
+		networking: networking,
+	}
+}
+
+// RepositoryImpl is used to fetch ingress related data from kubernetes.
+type RepositoryImpl struct {
+	networking typednetworkingv1.NetworkingV1Interface
+}
+
+// List fetches all ingresses in a namespace.
+func (c *RepositoryImpl) List(ctx context.Context, namespace string) (*networkingv1.IngressList, error) {
+	return c.networking.Ingresses(namespace).List(ctx, metav1.ListOptions{})
+}
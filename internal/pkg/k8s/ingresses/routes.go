@@ -0,0 +1,161 @@
+package ingresses
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/life4/genesis/slices"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// Route is a single host and path of an ingress, together with the backend that requests are routed to.
+type Route struct {
+	Ingress string
+	// Host is empty if the route matches all hosts.
+	Host string
+	// Path is empty for the default backend of an ingress and for rules without paths.
+	Path     string
+	PathType string
+	// Name of the backing service, empty if the backend is not a service.
+	Service string
+	// Backend describes the backend, as service:port or kind/name for resource backends.
+	Backend string
+	// Name of the secret holding the tls certificate for the host, empty if tls is not configured for the host.
+	TLSSecret string
+	// Problems found with the backend or the tls secret, such as references to services or secrets that do not exist.
+	Problems []string
+}
+
+// Routes flattens the rules of ingresses into one route per host and path.
+// The backends are checked against the given services, and the tls secrets against the given secret names.
+// If secretNames is nil, then the secrets are not checked, which is used when the secrets can not be listed.
+func Routes(ingresses []networkingv1.Ingress, services []v1.Service, secretNames []string) []Route {
+	routes := []Route{}
+
+	for _, ingress := range ingresses {
+		if ingress.Spec.DefaultBackend != nil {
+			routes = append(routes, newRoute(ingress, "", networkingv1.HTTPIngressPath{Backend: *ingress.Spec.DefaultBackend}, services, secretNames))
+		}
+
+		for _, rule := range ingress.Spec.Rules {
+			if rule.HTTP == nil {
+				route := Route{Ingress: ingress.Name, Host: rule.Host, Problems: []string{"rule has no paths"}}
+				route.TLSSecret = tlsSecret(ingress, rule.Host)
+				routes = append(routes, route)
+				continue
+			}
+
+			for _, path := range rule.HTTP.Paths {
+				routes = append(routes, newRoute(ingress, rule.Host, path, services, secretNames))
+			}
+		}
+	}
+
+	return routes
+}
+
+// newRoute creates the route of a path of an ingress rule and checks its backend and tls secret.
+func newRoute(ingress networkingv1.Ingress, host string, path networkingv1.HTTPIngressPath, services []v1.Service, secretNames []string) Route {
+	route := Route{
+		Ingress:   ingress.Name,
+		Host:      host,
+		Path:      path.Path,
+		TLSSecret: tlsSecret(ingress, host),
+	}
+
+	if path.PathType != nil {
+		route.PathType = string(*path.PathType)
+	}
+
+	switch {
+	case path.Backend.Service != nil:
+		route.Service = path.Backend.Service.Name
+		route.Backend = fmt.Sprintf("%s:%s", path.Backend.Service.Name, formatBackendPort(path.Backend.Service.Port))
+
+		if problem := checkServiceBackend(*path.Backend.Service, services); problem != "" {
+			route.Problems = append(route.Problems, problem)
+		}
+
+	case path.Backend.Resource != nil:
+		route.Backend = fmt.Sprintf("%s/%s", path.Backend.Resource.Kind, path.Backend.Resource.Name)
+	}
+
+	if route.TLSSecret != "" && secretNames != nil && !slices.Contains(secretNames, route.TLSSecret) {
+		route.Problems = append(route.Problems, fmt.Sprintf("tls secret %s not found", route.TLSSecret))
+	}
+
+	return route
+}
+
+// formatBackendPort formats the port of a service backend, which is either a port number or the name of a port of the service.
+func formatBackendPort(port networkingv1.ServiceBackendPort) string {
+	if port.Name != "" {
+		return port.Name
+	}
+
+	return fmt.Sprintf("%d", port.Number)
+}
+
+// checkServiceBackend checks that the service of a backend exists and has the port of the backend.
+// Returns a description of the problem, or an empty string if there is none.
+func checkServiceBackend(backend networkingv1.IngressServiceBackend, services []v1.Service) string {
+	service, err := slices.Find(services, func(s v1.Service) bool {
+		return s.Name == backend.Name
+	})
+
+	if err != nil {
+		return fmt.Sprintf("service %s not found", backend.Name)
+	}
+
+	hasPort := slices.Any(service.Spec.Ports, func(p v1.ServicePort) bool {
+		if backend.Port.Name != "" {
+			return p.Name == backend.Port.Name
+		}
+
+		return p.Port == backend.Port.Number
+	})
+
+	if !hasPort {
+		return fmt.Sprintf("port %s not found on service %s", formatBackendPort(backend.Port), backend.Name)
+	}
+
+	return ""
+}
+
+// TLSSecretNames returns the distinct names of the secrets referenced by the tls entries of ingresses, in the order they are referenced.
+func TLSSecretNames(ingresses []networkingv1.Ingress) []string {
+	names := []string{}
+
+	for _, ingress := range ingresses {
+		for _, tls := range ingress.Spec.TLS {
+			if tls.SecretName != "" && !slices.Contains(names, tls.SecretName) {
+				names = append(names, tls.SecretName)
+			}
+		}
+	}
+
+	return names
+}
+
+// tlsSecret returns the name of the secret holding the tls certificate for a host of an ingress.
+// A tls entry without hosts applies to all hosts.
+func tlsSecret(ingress networkingv1.Ingress, host string) string {
+	for _, tls := range ingress.Spec.TLS {
+		if len(tls.Hosts) == 0 || slices.Contains(tls.Hosts, host) || matchesWildcard(tls.Hosts, host) {
+			return tls.SecretName
+		}
+	}
+
+	return ""
+}
+
+// matchesWildcard reports whether a host is matched by a wildcard host such as *.example.com, which matches a single dns label.
+func matchesWildcard(hosts []string, host string) bool {
+	_, domain, found := strings.Cut(host, ".")
+	if !found {
+		return false
+	}
+
+	return slices.Contains(hosts, "*."+domain)
+}
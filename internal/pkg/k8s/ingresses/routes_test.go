@@ -0,0 +1,79 @@
+package ingresses_test
+
+import (
+	"testing"
+
+	"kubeui/internal/pkg/k8s/ingresses"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func serviceBackend(name string, port networkingv1.ServiceBackendPort) networkingv1.IngressBackend {
+	return networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: name, Port: port}}
+}
+
+func TestRoutes(t *testing.T) {
+	services := []v1.Service{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web"}, Spec: v1.ServiceSpec{Ports: []v1.ServicePort{{Name: "http", Port: 80}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "api"}, Spec: v1.ServiceSpec{Ports: []v1.ServicePort{{Port: 8080}}}},
+	}
+
+	ingress := networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "main"},
+		Spec: networkingv1.IngressSpec{
+			DefaultBackend: ptr.To(serviceBackend("web", networkingv1.ServiceBackendPort{Name: "http"})),
+			TLS: []networkingv1.IngressTLS{
+				{Hosts: []string{"www.example.com"}, SecretName: "www-tls"},
+				{Hosts: []string{"*.example.org"}, SecretName: "missing-tls"},
+			},
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: "www.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{
+						{Path: "/", PathType: ptr.To(networkingv1.PathTypePrefix), Backend: serviceBackend("web", networkingv1.ServiceBackendPort{Number: 80})},
+						{Path: "/api", PathType: ptr.To(networkingv1.PathTypeExact), Backend: serviceBackend("api", networkingv1.ServiceBackendPort{Number: 9090})},
+					}}},
+				},
+				{
+					Host: "shop.example.org",
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{
+						{Path: "/", Backend: serviceBackend("shop", networkingv1.ServiceBackendPort{Name: "http"})},
+						{Path: "/static", Backend: networkingv1.IngressBackend{Resource: &v1.TypedLocalObjectReference{Kind: "StorageBucket", Name: "assets"}}},
+					}}},
+				},
+				{Host: "empty.example.com"},
+			},
+		},
+	}
+
+	got := ingresses.Routes([]networkingv1.Ingress{ingress}, services, []string{"www-tls"})
+
+	assert.Equal(t, []ingresses.Route{
+		{Ingress: "main", Service: "web", Backend: "web:http"},
+		{Ingress: "main", Host: "www.example.com", Path: "/", PathType: "Prefix", Service: "web", Backend: "web:80", TLSSecret: "www-tls"},
+		{Ingress: "main", Host: "www.example.com", Path: "/api", PathType: "Exact", Service: "api", Backend: "api:9090", TLSSecret: "www-tls", Problems: []string{"port 9090 not found on service api"}},
+		{Ingress: "main", Host: "shop.example.org", Path: "/", Service: "shop", Backend: "shop:http", TLSSecret: "missing-tls", Problems: []string{"service shop not found", "tls secret missing-tls not found"}},
+		{Ingress: "main", Host: "shop.example.org", Path: "/static", Backend: "StorageBucket/assets", TLSSecret: "missing-tls", Problems: []string{"tls secret missing-tls not found"}},
+		{Ingress: "main", Host: "empty.example.com", Problems: []string{"rule has no paths"}},
+	}, got)
+
+	// Secrets are not checked when they could not be listed.
+	got = ingresses.Routes([]networkingv1.Ingress{ingress}, services, nil)
+	assert.Equal(t, []string{"service shop not found"}, got[3].Problems)
+	assert.Nil(t, got[4].Problems)
+}
+
+func TestTLSSecretNames(t *testing.T) {
+	ingressList := []networkingv1.Ingress{
+		{Spec: networkingv1.IngressSpec{TLS: []networkingv1.IngressTLS{{SecretName: "www-tls"}, {Hosts: []string{"example.org"}}}}},
+		{Spec: networkingv1.IngressSpec{TLS: []networkingv1.IngressTLS{{SecretName: "shop-tls"}, {SecretName: "www-tls"}}}},
+		{},
+	}
+
+	assert.Equal(t, []string{"www-tls", "shop-tls"}, ingresses.TLSSecretNames(ingressList))
+	assert.Equal(t, []string{}, ingresses.TLSSecretNames(nil))
+}
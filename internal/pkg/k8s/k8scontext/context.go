@@ -16,10 +16,20 @@ type Client interface {
 	// Returns the api.Context for the currently selected context if it exists.
 	// If no api.Context exists for the current context then the bool should be set to false.
 	CurrentApiContext() (*api.Context, bool)
+	// Returns the api.Cluster of the currently selected context if it exists.
+	// If the context or its cluster does not exist then the bool should be set to false.
+	CurrentCluster() (*api.Cluster, bool)
 	// Returns the currently selected context.
 	CurrentContext() string
+	// Returns the kubeconfig including the changes made through the client, which is used to connect to the current context.
+	Config() api.Config
 	// Switch to the specified context and optionally set the default namespace.
+	// The kubeconfig is only changed if the selection is not session scoped.
 	SwitchContext(ctx, namespace string) (err error)
+	// Returns whether switching context and namespace only affects the current session, leaving the kubeconfig unchanged.
+	SessionScoped() bool
+	// Sets whether switching context and namespace only affects the current session.
+	SetSessionScoped(scoped bool)
 	// Delete the specified context.
 	DeleteContext(ctx string) (err error)
 	// Delete the specified user entry.
@@ -37,6 +47,10 @@ type ClientImpl struct {
 	modifyConfig ModifyConfigFunc
 	configAccess clientcmd.ConfigAccess
 	config       api.Config
+
+	// A copy of config with the context and namespaces selected during the session.
+	// It is only set when the selection is session scoped, in which case it is used instead of config.
+	session *api.Config
 }
 
 // NewClientImpl creates a new Client.
@@ -57,25 +71,84 @@ func NewClientImpl(configAccess clientcmd.ConfigAccess, config api.Config, modif
 
 }
 
+// current returns the kubeconfig including the selections of the session if the selection is session scoped.
+func (c *ClientImpl) current() *api.Config {
+	if c.session != nil {
+		return c.session
+	}
+
+	return &c.config
+}
+
+// SessionScoped returns whether switching context and namespace only affects the current session.
+func (c *ClientImpl) SessionScoped() bool {
+	return c.session != nil
+}
+
+// SetSessionScoped sets whether switching context and namespace only affects the current session.
+// The session starts out with the context and namespaces of the kubeconfig, and what was selected during the session is discarded when it is no longer session scoped.
+func (c *ClientImpl) SetSessionScoped(scoped bool) {
+	if !scoped {
+		c.session = nil
+		return
+	}
+
+	if c.session == nil {
+		c.session = c.config.DeepCopy()
+	}
+}
+
 // CurrentApiContext returns the currently active api context.
 func (c *ClientImpl) CurrentApiContext() (*api.Context, bool) {
-	ctx, ok := c.config.Contexts[c.config.CurrentContext]
+	ctx, ok := c.current().Contexts[c.current().CurrentContext]
 	return ctx, ok
 }
 
+// CurrentCluster returns the cluster of the currently active context.
+func (c *ClientImpl) CurrentCluster() (*api.Cluster, bool) {
+	ctx, ok := c.CurrentApiContext()
+	if !ok {
+		return nil, false
+	}
+
+	cluster, ok := c.current().Clusters[ctx.Cluster]
+	return cluster, ok
+}
+
 // CurrentContext returns the currently active context.
 func (c *ClientImpl) CurrentContext() string {
-	return c.config.CurrentContext
+	return c.current().CurrentContext
+}
+
+// Config returns the kubeconfig as it is kept in memory, including the selections of the session if the selection is session scoped.
+func (c *ClientImpl) Config() api.Config {
+	return *c.current()
 }
 
 // Contexts returns a list of available contexts.
 func (c *ClientImpl) Contexts() []string {
-	return maps.Keys(c.config.Contexts)
+	return maps.Keys(c.current().Contexts)
 }
 
 // SwitchContext changes the active context in a kubeconfig.
+// If the selection is session scoped then only the context of the session is changed.
 func (c *ClientImpl) SwitchContext(ctx, namespace string) (err error) {
 
+	if c.session != nil {
+		kubeCtx, ok := c.session.Contexts[ctx]
+		if !ok {
+			return fmt.Errorf("context %s doesn't exists", ctx)
+		}
+
+		c.session.CurrentContext = ctx
+
+		if namespace != "" {
+			kubeCtx.Namespace = namespace
+		}
+
+		return nil
+	}
+
 	kubeCtx, ok := c.config.Contexts[ctx]
 
 	if !ok {
@@ -117,6 +190,14 @@ func (c *ClientImpl) DeleteContext(ctx string) (err error) {
 		c.config.CurrentContext = ""
 	}
 
+	if c.session != nil {
+		delete(c.session.Contexts, ctx)
+
+		if ctx == c.session.CurrentContext {
+			c.session.CurrentContext = ""
+		}
+	}
+
 	return nil
 }
 
@@ -134,6 +215,10 @@ func (c *ClientImpl) DeleteUser(user string) (err error) {
 
 	delete(c.config.AuthInfos, user)
 
+	if c.session != nil {
+		delete(c.session.AuthInfos, user)
+	}
+
 	return nil
 }
 
@@ -151,5 +236,9 @@ func (c *ClientImpl) DeleteClusterEntry(cluster string) (err error) {
 
 	delete(c.config.Clusters, cluster)
 
+	if c.session != nil {
+		delete(c.session.Clusters, cluster)
+	}
+
 	return nil
 }
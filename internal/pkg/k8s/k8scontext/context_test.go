@@ -62,6 +62,54 @@ func TestContextClientImpl_CurrentApiContext(t *testing.T) {
 	}
 }
 
+func TestContextClientImpl_CurrentCluster(t *testing.T) {
+
+	createConfig := func(currentContext string, clusters map[string]*api.Cluster) api.Config {
+		config := api.NewConfig()
+		config.CurrentContext = currentContext
+		config.Contexts = map[string]*api.Context{"test": {Cluster: "test-cluster"}}
+		config.Clusters = clusters
+		return *config
+	}
+
+	cluster := &api.Cluster{Server: "https://127.0.0.1:6443"}
+
+	tests := []struct {
+		name   string
+		config api.Config
+		want   *api.Cluster
+		exist  bool
+	}{
+		{
+			"Non existing current context",
+			createConfig("not-here", map[string]*api.Cluster{"test-cluster": cluster}),
+			nil,
+			false,
+		},
+		{
+			"Non existing cluster",
+			createConfig("test", map[string]*api.Cluster{"other-cluster": cluster}),
+			nil,
+			false,
+		},
+		{
+			"Successfully found cluster",
+			createConfig("test", map[string]*api.Cluster{"test-cluster": cluster}),
+			cluster,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := k8scontext.NewClientImpl(nil, tt.config, nil)
+
+			got, exist := c.CurrentCluster()
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.exist, exist)
+		})
+	}
+}
+
 func TestContextClientImpl_CurrentContext(t *testing.T) {
 
 	createConfig := func(currentContext string) api.Config {
@@ -201,6 +249,9 @@ func TestContextClientImpl_SwitchContext(t *testing.T) {
 				// Check that the current context matches what we supplied.
 				assert.Equal(t, tt.args.ctx, c.CurrentContext())
 
+				// The config used to connect to the new context should have changed as well.
+				assert.Equal(t, tt.args.ctx, c.Config().CurrentContext)
+
 				// If we supplied a namespace we expect the namespace of the current context to match.
 				if tt.args.namespace != "" {
 					current, _ := c.CurrentApiContext()
@@ -211,6 +262,49 @@ func TestContextClientImpl_SwitchContext(t *testing.T) {
 	}
 }
 
+func TestContextClientImpl_SessionScoped(t *testing.T) {
+
+	written := []api.Config{}
+	modifyFunc := func(configAccess clientcmd.ConfigAccess, newConfig api.Config, relativizePaths bool) error {
+		written = append(written, newConfig)
+		return nil
+	}
+
+	config := *api.NewConfig()
+	config.CurrentContext = "test"
+	config.Contexts = map[string]*api.Context{
+		"test":  api.NewContext(),
+		"test2": api.NewContext(),
+	}
+
+	c := k8scontext.NewClientImpl(nil, config, modifyFunc)
+	c.SetSessionScoped(true)
+	assert.True(t, c.SessionScoped())
+
+	err := c.SwitchContext("test2", "kube-system")
+	assert.Nil(t, err)
+
+	// The session has switched context, while the kubeconfig is left unchanged.
+	assert.Empty(t, written)
+	assert.Equal(t, "test2", c.CurrentContext())
+	assert.Equal(t, "test2", c.Config().CurrentContext)
+	current, _ := c.CurrentApiContext()
+	assert.Equal(t, "kube-system", current.Namespace)
+	assert.Equal(t, "", config.Contexts["test2"].Namespace)
+
+	// Deleting a context is written to the kubeconfig without the context selected during the session.
+	err = c.DeleteContext("test")
+	assert.Nil(t, err)
+	assert.Len(t, written, 1)
+	assert.Equal(t, "test", written[0].CurrentContext)
+	assert.NotContains(t, c.Contexts(), "test")
+
+	// The selection of the session is discarded once it is no longer session scoped.
+	c.SetSessionScoped(false)
+	assert.False(t, c.SessionScoped())
+	assert.Equal(t, "", c.CurrentContext())
+}
+
 func TestContextClientImpl_DeleteContext(t *testing.T) {
 
 	nilModifyFunc := func(configAccess clientcmd.ConfigAccess, newConfig api.Config, relativizePaths bool) error {
@@ -0,0 +1,67 @@
+package k8scontext
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// KubectlCommands returns the command that defines a kubectl shell function, which passes the current context and namespace of a kubeconfig to kubectl.
+// The kubeconfig is left unchanged, so kubectl in other shells is not affected.
+func KubectlCommands(config api.Config) ([]string, error) {
+	kubeCtx, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return nil, fmt.Errorf("context %s doesn't exists", config.CurrentContext)
+	}
+
+	flags := fmt.Sprintf("--context=%s", shellQuote(config.CurrentContext))
+
+	if kubeCtx.Namespace != "" {
+		flags += fmt.Sprintf(" --namespace=%s", shellQuote(kubeCtx.Namespace))
+	}
+
+	return []string{fmt.Sprintf(`kubectl() { command kubectl %s "$@"; }`, flags)}, nil
+}
+
+// KubeconfigCommands writes a kubeconfig for a single shell to path and returns the command that makes the shell use it.
+// The written kubeconfig only contains the current context of config, including its namespace. It is listed first in KUBECONFIG,
+// followed by the kubeconfig files that were loaded, which still provide the clusters and users.
+// The kubeconfig of other shells is left unchanged.
+func KubeconfigCommands(path string, config api.Config, loadingPrecedence []string) ([]string, error) {
+	kubeCtx, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return nil, fmt.Errorf("context %s doesn't exists", config.CurrentContext)
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path %s: %v", path, err)
+	}
+	path = absPath
+
+	shellConfig := api.NewConfig()
+	shellConfig.CurrentContext = config.CurrentContext
+	shellConfig.Contexts[config.CurrentContext] = kubeCtx.DeepCopy()
+
+	if err := clientcmd.WriteToFile(*shellConfig, path); err != nil {
+		return nil, fmt.Errorf("failed to write kubeconfig %s: %v", path, err)
+	}
+
+	files := []string{path}
+	for _, file := range loadingPrecedence {
+		// The kubeconfig of the shell is already listed when it has been written before.
+		if file != path {
+			files = append(files, file)
+		}
+	}
+
+	return []string{fmt.Sprintf("export KUBECONFIG=%s", shellQuote(strings.Join(files, string(filepath.ListSeparator))))}, nil
+}
+
+// shellQuote quotes a value so that it is passed as a single argument by a POSIX shell.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
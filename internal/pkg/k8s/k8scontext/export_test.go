@@ -0,0 +1,68 @@
+package k8scontext_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"kubeui/internal/pkg/k8s/k8scontext"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+func exportConfig(currentContext, namespace string) api.Config {
+	config := *api.NewConfig()
+	config.CurrentContext = currentContext
+	config.Contexts = map[string]*api.Context{
+		"dev":          {Cluster: "dev", AuthInfo: "admin"},
+		"it's-staging": {Cluster: "staging", AuthInfo: "admin", Namespace: namespace},
+	}
+	return config
+}
+
+func TestKubectlCommands(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  api.Config
+		want    []string
+		wantErr bool
+	}{
+		{"Context without namespace", exportConfig("dev", ""), []string{`kubectl() { command kubectl --context='dev' "$@"; }`}, false},
+		{
+			"Context with namespace is quoted",
+			exportConfig("it's-staging", "web"),
+			[]string{`kubectl() { command kubectl --context='it'\''s-staging' --namespace='web' "$@"; }`},
+			false,
+		},
+		{"Non existing context", exportConfig("not-here", ""), nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := k8scontext.KubectlCommands(tt.config)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestKubeconfigCommands(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "shell")
+	original := filepath.Join(dir, "config")
+
+	got, err := k8scontext.KubeconfigCommands(path, exportConfig("it's-staging", "web"), []string{path, original})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"export KUBECONFIG='" + path + string(filepath.ListSeparator) + original + "'"}, got)
+
+	written, err := clientcmd.LoadFromFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "it's-staging", written.CurrentContext)
+	assert.Len(t, written.Contexts, 1)
+	assert.Equal(t, "web", written.Contexts["it's-staging"].Namespace)
+	assert.Empty(t, written.AuthInfos)
+
+	_, err = k8scontext.KubeconfigCommands(path, exportConfig("not-here", ""), nil)
+	assert.Error(t, err)
+}
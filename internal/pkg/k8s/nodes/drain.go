@@ -0,0 +1,88 @@
+package nodes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/life4/genesis/slices"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// mirrorPodAnnotation is set on pods that the kubelet creates in the api server for its static pods.
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// DrainOptions defines the options used when draining a node.
+type DrainOptions struct {
+	// The grace period given to each pod to terminate, nil uses the termination grace period of each pod.
+	GracePeriodSeconds *int64
+	// Allows pods using emptyDir volumes to be evicted, deleting the data in those volumes.
+	// The drain is refused if any such pods exist and this is not set.
+	DeleteEmptyDirData bool
+}
+
+// DrainStatus describes the state of a single pod while a node is being drained.
+type DrainStatus string
+
+const (
+	// Skipped is used for pods that are not evicted, such as DaemonSet pods and mirror pods.
+	Skipped DrainStatus = "Skipped"
+	// Waiting is used for pods that have been evicted but have not yet terminated.
+	Waiting DrainStatus = "Waiting"
+	// BlockedByPDB is used for pods whose eviction is refused since it would violate a PodDisruptionBudget, the eviction is retried.
+	BlockedByPDB DrainStatus = "BlockedByPDB"
+	// Evicted is used for pods that have been evicted and terminated.
+	Evicted DrainStatus = "Evicted"
+	// Failed is used for pods that could not be evicted.
+	Failed DrainStatus = "Failed"
+)
+
+// DrainEvent reports a change in the status of a pod while a node is being drained.
+type DrainEvent struct {
+	Namespace string
+	Name      string
+	Status    DrainStatus
+	// Explains why a pod was skipped, or why the eviction failed or is blocked.
+	Message string
+}
+
+// SkipReason returns the reason for not evicting a pod when draining its node, or an empty string if the pod should be evicted.
+// DaemonSet pods would be recreated on the node right away and mirror pods can only be removed from the node itself, same as in `kubectl drain`.
+func SkipReason(pod v1.Pod) string {
+	if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+		return "mirror pod"
+	}
+
+	if controller := metav1.GetControllerOf(&pod); controller != nil && controller.Kind == "DaemonSet" {
+		return "managed by DaemonSet"
+	}
+
+	return ""
+}
+
+// HasEmptyDirData reports whether a pod uses emptyDir volumes, the data in which is deleted when the pod is evicted.
+// Pods that have terminated are not considered, since they are no longer using the data.
+func HasEmptyDirData(pod v1.Pod) bool {
+	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+		return false
+	}
+
+	return slices.Any(pod.Spec.Volumes, func(volume v1.Volume) bool { return volume.EmptyDir != nil })
+}
+
+// CheckDrainable returns an error if the pods can not be evicted with the given options.
+func CheckDrainable(pods []v1.Pod, options DrainOptions) error {
+	if options.DeleteEmptyDirData {
+		return nil
+	}
+
+	emptyDirPods := slices.Filter(pods, func(pod v1.Pod) bool { return SkipReason(pod) == "" && HasEmptyDirData(pod) })
+
+	if len(emptyDirPods) == 0 {
+		return nil
+	}
+
+	names := slices.Map(emptyDirPods, func(pod v1.Pod) string { return pod.Namespace + "/" + pod.Name })
+
+	return fmt.Errorf("pods with emptyDir data would lose their data: %s", strings.Join(names, ", "))
+}
@@ -0,0 +1,53 @@
+package nodes_test
+
+import (
+	"kubeui/internal/pkg/k8s/nodes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSkipReason(t *testing.T) {
+	controller := true
+
+	tests := []struct {
+		name string
+		pod  v1.Pod
+		want string
+	}{
+		{"Should evict a pod without owner", v1.Pod{}, ""},
+		{"Should evict a pod owned by a ReplicaSet", v1.Pod{ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Controller: &controller}}}}, ""},
+		{"Should skip a pod owned by a DaemonSet", v1.Pod{ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Controller: &controller}}}}, "managed by DaemonSet"},
+		{"Should skip a mirror pod", v1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"kubernetes.io/config.mirror": "hash"}}}, "mirror pod"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nodes.SkipReason(tt.pod))
+		})
+	}
+}
+
+func TestCheckDrainable(t *testing.T) {
+	emptyDir := []v1.Volume{{Name: "cache", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}}
+
+	tests := []struct {
+		name    string
+		pod     v1.Pod
+		options nodes.DrainOptions
+		wantErr bool
+	}{
+		{"Should allow pods without emptyDir volumes", v1.Pod{}, nodes.DrainOptions{}, false},
+		{"Should refuse pods with emptyDir volumes", v1.Pod{Spec: v1.PodSpec{Volumes: emptyDir}}, nodes.DrainOptions{}, true},
+		{"Should allow pods with emptyDir volumes if the data may be deleted", v1.Pod{Spec: v1.PodSpec{Volumes: emptyDir}}, nodes.DrainOptions{DeleteEmptyDirData: true}, false},
+		{"Should allow terminated pods with emptyDir volumes", v1.Pod{Spec: v1.PodSpec{Volumes: emptyDir}, Status: v1.PodStatus{Phase: v1.PodSucceeded}}, nodes.DrainOptions{}, false},
+		{"Should allow skipped pods with emptyDir volumes", v1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"kubernetes.io/config.mirror": "hash"}}, Spec: v1.PodSpec{Volumes: emptyDir}}, nodes.DrainOptions{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := nodes.CheckDrainable([]v1.Pod{tt.pod}, tt.options)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package nodes
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// Node contains extended information about a kubernetes node.
+type Node struct {
+	Node   v1.Node
+	Events []v1.Event
+}
+
+// NodeList contains the nodes of a cluster together with the number of pods running on each node.
+type NodeList struct {
+	Items []v1.Node
+	// The number of pods that are not terminated, keyed by the name of the node they are scheduled on.
+	PodCounts map[string]int
+}
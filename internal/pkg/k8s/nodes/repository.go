@@ -0,0 +1,52 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// Repository defines the interface for the nodes repository.
+type Repository interface {
+	Get(ctx context.Context, name string) (*v1.Node, error)
+	List(ctx context.Context) (*v1.NodeList, error)
+	Events(ctx context.Context, name string) (*v1.EventList, error)
+	Patch(ctx context.Context, name string, patchType types.PatchType, data []byte) (*v1.Node, error)
+}
+
+// NewRepository creates a new Repository.
+func NewRepository(kubectl corev1.CoreV1Interface) Repository {
+	return &RepositoryImpl{
+		kubectl: kubectl,
+	}
+}
+
+// RepositoryImpl is used to fetch node related data from kubernetes.
+type RepositoryImpl struct {
+	kubectl corev1.CoreV1Interface
+}
+
+// Get fetches a single node.
+func (c *RepositoryImpl) Get(ctx context.Context, name string) (*v1.Node, error) {
+	return c.kubectl.Nodes().Get(ctx, name, metav1.GetOptions{})
+}
+
+// List fetches all nodes in the cluster.
+func (c *RepositoryImpl) List(ctx context.Context) (*v1.NodeList, error) {
+	return c.kubectl.Nodes().List(ctx, metav1.ListOptions{})
+}
+
+// Events fetches the current events for a node.
+// Nodes are not namespaced, so the events are searched for in all namespaces.
+func (c *RepositoryImpl) Events(ctx context.Context, name string) (*v1.EventList, error) {
+	return c.kubectl.Events("").List(ctx, metav1.ListOptions{FieldSelector: fmt.Sprintf("involvedObject.name=%s,involvedObject.kind=Node", name)})
+}
+
+// Patch applies a patch to a node.
+func (c *RepositoryImpl) Patch(ctx context.Context, name string, patchType types.PatchType, data []byte) (*v1.Node, error) {
+	return c.kubectl.Nodes().Patch(ctx, name, patchType, data, metav1.PatchOptions{})
+}
@@ -9,9 +9,6 @@ import (
 type Pod struct {
 	Pod    v1.Pod
 	Events []v1.Event
-
-	// Stores the latest logs for each container, mapped on the container name.
-	Logs map[string]string
 }
 
 // ContainerNames returns the names of all containers in the pod.
@@ -25,3 +22,29 @@ func (p *Pod) ContainerNames() []string {
 		return s.Name
 	})
 }
+
+// WatchEventType describes the kind of change a WatchEvent represents.
+type WatchEventType string
+
+const (
+	// Added is used when a pod has been added.
+	Added WatchEventType = "ADDED"
+	// Modified is used when a pod has been modified.
+	Modified WatchEventType = "MODIFIED"
+	// Deleted is used when a pod has been deleted.
+	Deleted WatchEventType = "DELETED"
+)
+
+// WatchEvent represents a change to a pod observed while watching a namespace.
+type WatchEvent struct {
+	Type WatchEventType
+	Pod  v1.Pod
+}
+
+// DeleteResult is the outcome of deleting a single pod out of several.
+type DeleteResult struct {
+	Namespace string
+	Name      string
+	// Nil if the pod was deleted.
+	Err error
+}
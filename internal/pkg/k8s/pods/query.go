@@ -0,0 +1,93 @@
+package pods
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/life4/genesis/slices"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// FieldSelectorKeys are the fields of a pod that are supported by field selectors.
+var FieldSelectorKeys = []string{
+	"metadata.name",
+	"metadata.namespace",
+	"spec.nodeName",
+	"spec.restartPolicy",
+	"spec.schedulerName",
+	"spec.serviceAccountName",
+	"spec.hostNetwork",
+	"status.phase",
+	"status.podIP",
+	"status.podIPs",
+	"status.nominatedNodeName",
+}
+
+// ParseQuery parses a query consisting of comma separated label and field selector requirements into ListOptions,
+// for example "app=web,tier!=db,status.phase=Running".
+// Requirements on one of the FieldSelectorKeys end up in the field selector, all other requirements in the label selector.
+func ParseQuery(query string) (ListOptions, error) {
+	labelRequirements := []string{}
+	fieldRequirements := []string{}
+
+	for _, requirement := range splitRequirements(query) {
+		requirement = strings.TrimSpace(requirement)
+
+		if requirement == "" {
+			continue
+		}
+
+		if slices.Contains(FieldSelectorKeys, requirementKey(requirement)) {
+			fieldRequirements = append(fieldRequirements, requirement)
+		} else {
+			labelRequirements = append(labelRequirements, requirement)
+		}
+	}
+
+	labelSelector := strings.Join(labelRequirements, ",")
+	if _, err := labels.Parse(labelSelector); err != nil {
+		return ListOptions{}, fmt.Errorf("invalid label selector: %v", err)
+	}
+
+	fieldSelector := strings.Join(fieldRequirements, ",")
+	if _, err := fields.ParseSelector(fieldSelector); err != nil {
+		return ListOptions{}, fmt.Errorf("invalid field selector: %v", err)
+	}
+
+	return ListOptions{LabelSelector: labelSelector, FieldSelector: fieldSelector}, nil
+}
+
+// splitRequirements splits a query on the commas separating requirements.
+// Commas within parentheses are part of set based requirements such as "env in (dev,test)" and are not split on.
+func splitRequirements(query string) []string {
+	requirements := []string{}
+	depth := 0
+	start := 0
+
+	for i, r := range query {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				requirements = append(requirements, query[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(requirements, query[start:])
+}
+
+// requirementKey returns the key of an equality based requirement, or an empty string for other requirements.
+func requirementKey(requirement string) string {
+	i := strings.IndexAny(requirement, "!=")
+	if i < 0 {
+		return ""
+	}
+
+	return strings.TrimSpace(requirement[:i])
+}
@@ -0,0 +1,39 @@
+package pods_test
+
+import (
+	"kubeui/internal/pkg/k8s/pods"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    pods.ListOptions
+		wantErr bool
+	}{
+		{"empty query", "", pods.ListOptions{}, false},
+		{"label selector", "app=web,tier!=db", pods.ListOptions{LabelSelector: "app=web,tier!=db"}, false},
+		{"field selector", "status.phase=Running, spec.nodeName=node-1", pods.ListOptions{FieldSelector: "status.phase=Running,spec.nodeName=node-1"}, false},
+		{"label and field selector", "app=web,status.phase!=Running", pods.ListOptions{LabelSelector: "app=web", FieldSelector: "status.phase!=Running"}, false},
+		{"set based label selector", "env in (dev,test),!canary", pods.ListOptions{LabelSelector: "env in (dev,test),!canary"}, false},
+		{"label with prefix", "app.kubernetes.io/name==web", pods.ListOptions{LabelSelector: "app.kubernetes.io/name==web"}, false},
+		{"invalid label selector", "app=web=api", pods.ListOptions{}, true},
+		{"unbalanced parentheses", "env in (dev", pods.ListOptions{}, true},
+		{"invalid field selector", "status.phase=Running=Failed", pods.ListOptions{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pods.ParseQuery(tt.query)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
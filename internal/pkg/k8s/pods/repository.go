@@ -1,37 +1,51 @@
 package pods
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
-	"time"
+	"net/http"
 
-	"golang.org/x/sync/errgroup"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
 )
 
 // Repository defines the interface for the pods repository.
 type Repository interface {
 	Get(ctx context.Context, namespace, name string) (*v1.Pod, error)
 	Delete(ctx context.Context, namespace, name string) error
-	List(ctx context.Context, namespace string) (*v1.PodList, error)
+	Evict(ctx context.Context, namespace, name string, gracePeriodSeconds *int64) error
+	Update(ctx context.Context, namespace string, pod *v1.Pod) (*v1.Pod, error)
+	List(ctx context.Context, namespace string, options ListOptions) (*v1.PodList, error)
+	Watch(ctx context.Context, namespace string, options WatchOptions) (watch.Interface, error)
 	Events(ctx context.Context, namespace, name string) (*v1.EventList, error)
-	TailLogs(ctx context.Context, pod *v1.Pod, options LogsOptions) (map[string]string, error)
+	StreamLogs(ctx context.Context, namespace, name, container string, options LogsOptions) (io.ReadCloser, error)
+	Exec(ctx context.Context, namespace, name, container string, options ExecOptions) error
+	PortForward(namespace, name string, options PortForwardOptions) error
 }
 
 // NewRepository creates a new Client.
-func NewRepository(kubectl corev1.CoreV1Interface) Repository {
+// The restConfig is needed to open exec sessions, if it is nil then Exec will fail.
+func NewRepository(kubectl corev1.CoreV1Interface, restConfig *rest.Config) Repository {
 	return &RepositoryImpl{
-		kubectl: kubectl,
+		kubectl:    kubectl,
+		restConfig: restConfig,
 	}
 }
 
 // RepositoryImpl is used to fetch pod related data from kubernetes.
 type RepositoryImpl struct {
-	kubectl corev1.CoreV1Interface
+	kubectl    corev1.CoreV1Interface
+	restConfig *rest.Config
 }
 
 // Get fetches a single pod.
@@ -44,9 +58,47 @@ func (c *RepositoryImpl) Delete(ctx context.Context, namespace, name string) err
 	return c.kubectl.Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
+// Evict evicts a pod using the eviction API, which refuses the eviction if it would violate a PodDisruptionBudget.
+// A nil grace period uses the termination grace period of the pod.
+func (c *RepositoryImpl) Evict(ctx context.Context, namespace, name string, gracePeriodSeconds *int64) error {
+	return c.kubectl.Pods(namespace).EvictV1(ctx, &policyv1.Eviction{
+		ObjectMeta:    metav1.ObjectMeta{Namespace: namespace, Name: name},
+		DeleteOptions: &metav1.DeleteOptions{GracePeriodSeconds: gracePeriodSeconds},
+	})
+}
+
+// Update replaces a pod with the given version.
+// The update is rejected with a conflict if the resource version of the pod does not match the current version in the cluster.
+func (c *RepositoryImpl) Update(ctx context.Context, namespace string, pod *v1.Pod) (*v1.Pod, error) {
+	return c.kubectl.Pods(namespace).Update(ctx, pod, metav1.UpdateOptions{})
+}
+
+// ListOptions defines extra options to apply when listing pods.
+type ListOptions struct {
+	// Only pods with labels matching the selector are listed, for example "app=web".
+	LabelSelector string
+	// Only pods with fields matching the selector are listed, for example "status.phase=Running".
+	FieldSelector string
+}
+
 // List fetches a list of pods for a given namespace.
-func (c *RepositoryImpl) List(ctx context.Context, namespace string) (*v1.PodList, error) {
-	return c.kubectl.Pods(namespace).List(ctx, metav1.ListOptions{})
+func (c *RepositoryImpl) List(ctx context.Context, namespace string, options ListOptions) (*v1.PodList, error) {
+	return c.kubectl.Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: options.LabelSelector, FieldSelector: options.FieldSelector})
+}
+
+// WatchOptions defines extra options to apply when watching pods.
+type WatchOptions struct {
+	// ResourceVersion to start watching from, typically taken from a previous list.
+	// If empty the watch starts with synthetic added events for all existing pods.
+	ResourceVersion string
+	// Selectors restricting the watched pods, in the same format as those of ListOptions.
+	LabelSelector string
+	FieldSelector string
+}
+
+// Watch starts a watch on the pods in a given namespace.
+func (c *RepositoryImpl) Watch(ctx context.Context, namespace string, options WatchOptions) (watch.Interface, error) {
+	return c.kubectl.Pods(namespace).Watch(ctx, metav1.ListOptions{ResourceVersion: options.ResourceVersion, LabelSelector: options.LabelSelector, FieldSelector: options.FieldSelector})
 }
 
 // Events fetches the current events for a pod.
@@ -59,57 +111,147 @@ type LogsOptions struct {
 	Count uint32
 }
 
-// TailLogs fetches the latest logs for a pod.
-// By default the last 100 log lines will be fetched but this can be customized using the options parameter.
-// Logs are returned as a mapping between the container name and the logs as a unified string separated by linebreaks '\n'.
-func (c *RepositoryImpl) TailLogs(ctx context.Context, pod *v1.Pod, options LogsOptions) (map[string]string, error) {
-	containerLogs := map[string]string{}
+// StreamLogs opens a stream following the logs of a container, equivalent to `kubectl logs -f`.
+// By default the stream starts with the last 100 log lines but this can be customized using the options parameter.
+// The stream is closed when ctx is cancelled, or when the container stops writing logs.
+func (c *RepositoryImpl) StreamLogs(ctx context.Context, namespace, name, container string, options LogsOptions) (io.ReadCloser, error) {
+	tailLines := int64(options.Count)
+
+	if tailLines == 0 {
+		tailLines = 100
+	}
+
+	logsRequest := c.kubectl.Pods(namespace).GetLogs(name, &v1.PodLogOptions{Container: container, TailLines: &tailLines, Follow: true})
+
+	if logsRequest == nil {
+		return nil, fmt.Errorf("failed to issue request to stream container logs for %s", container)
+	}
+
+	return logsRequest.Stream(ctx)
+}
+
+// ExecOptions defines the command to run in a container along with the streams connected to it.
+type ExecOptions struct {
+	Command []string
+	Stdin   io.Reader
+	Stdout  io.Writer
+	Stderr  io.Writer
+	// If true then stdin is treated as a terminal and stderr is merged into stdout.
+	TTY bool
+	// Delivers changes to the size of the terminal, only used if TTY is true.
+	TerminalSizeQueue remotecommand.TerminalSizeQueue
+}
 
-	errGroup := &errgroup.Group{}
+// Exec runs a command in a container, equivalent to `kubectl exec`.
+// It blocks until the command exits or ctx is cancelled.
+func (c *RepositoryImpl) Exec(ctx context.Context, namespace, name, container string, options ExecOptions) error {
+	if c.restConfig == nil {
+		return fmt.Errorf("no rest config available to exec into %s", name)
+	}
 
-	for i := range pod.Status.ContainerStatuses {
+	request := c.kubectl.RESTClient().
+		Post().
+		Namespace(namespace).
+		Resource("pods").
+		Name(name).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: container,
+			Command:   options.Command,
+			Stdin:     options.Stdin != nil,
+			Stdout:    options.Stdout != nil,
+			Stderr:    options.Stderr != nil && !options.TTY,
+			TTY:       options.TTY,
+		}, scheme.ParameterCodec)
 
-		container := pod.Status.ContainerStatuses[i]
+	// Like kubectl we prefer websockets, falling back to SPDY for servers that don't support them.
+	websocketExecutor, err := remotecommand.NewWebSocketExecutor(c.restConfig, "GET", request.URL().String())
+	if err != nil {
+		return err
+	}
 
-		errGroup.Go(func() error {
+	spdyExecutor, err := remotecommand.NewSPDYExecutor(c.restConfig, "POST", request.URL())
+	if err != nil {
+		return err
+	}
 
-			logsCtx, logsCancel := context.WithTimeout(context.Background(), 5*time.Second)
-			defer logsCancel()
+	executor, err := remotecommand.NewFallbackExecutor(websocketExecutor, spdyExecutor, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+	if err != nil {
+		return err
+	}
 
-			tailLines := int64(options.Count)
+	streamOptions := remotecommand.StreamOptions{
+		Stdin:             options.Stdin,
+		Stdout:            options.Stdout,
+		Stderr:            options.Stderr,
+		Tty:               options.TTY,
+		TerminalSizeQueue: options.TerminalSizeQueue,
+	}
 
-			if tailLines == 0 {
-				tailLines = 100
-			}
+	if options.TTY {
+		streamOptions.Stderr = nil
+	}
 
-			logsRequest := c.kubectl.Pods(pod.Namespace).GetLogs(pod.GetName(), &v1.PodLogOptions{Container: container.Name, TailLines: &tailLines})
+	return executor.StreamWithContext(ctx, streamOptions)
+}
 
-			if logsRequest == nil {
-				return fmt.Errorf("failed to issue request to fetch container logs for %s", container.Name)
-			}
+// PortForwardOptions defines the ports to forward along with the channels used to control the forward.
+type PortForwardOptions struct {
+	LocalPort  int
+	RemotePort int
+	// Closing the stop channel stops the forward.
+	StopChannel <-chan struct{}
+	// The ready channel is closed once the local port is listening.
+	ReadyChannel chan struct{}
+}
 
-			podLogs, err := logsRequest.Stream(logsCtx)
+// PortForward forwards a local port to a port of a pod, equivalent to `kubectl port-forward`.
+// It blocks until the stop channel is closed or the connection to the pod is lost.
+func (c *RepositoryImpl) PortForward(namespace, name string, options PortForwardOptions) error {
+	if c.restConfig == nil {
+		return fmt.Errorf("no rest config available to port forward to %s", name)
+	}
 
-			if err != nil {
-				return err
-			}
-			defer podLogs.Close()
+	url := c.kubectl.RESTClient().
+		Post().
+		Namespace(namespace).
+		Resource("pods").
+		Name(name).
+		SubResource("portforward").
+		URL()
 
-			buf := new(bytes.Buffer)
-			_, err = io.Copy(buf, podLogs)
-			if err != nil {
-				return err
-			}
+	transport, upgrader, err := spdy.RoundTripperFor(c.restConfig)
+	if err != nil {
+		return err
+	}
 
-			containerLogs[container.Name] = buf.String()
+	spdyDialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", url)
 
-			return nil
-		})
+	// Like kubectl we prefer websockets, falling back to SPDY for servers that don't support them.
+	websocketDialer, err := portforward.NewSPDYOverWebsocketDialer(url, c.restConfig)
+	if err != nil {
+		return err
 	}
 
-	if err := errGroup.Wait(); err != nil {
-		return containerLogs, err
+	dialer := portforward.NewFallbackDialer(websocketDialer, spdyDialer, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+
+	// Output is discarded as it would otherwise be written on top of the ui.
+	forwarder, err := portforward.NewOnAddresses(
+		dialer,
+		[]string{"localhost"},
+		[]string{fmt.Sprintf("%d:%d", options.LocalPort, options.RemotePort)},
+		options.StopChannel,
+		options.ReadyChannel,
+		io.Discard,
+		io.Discard,
+	)
+	if err != nil {
+		return err
 	}
 
-	return containerLogs, nil
+	return forwarder.ForwardPorts()
 }
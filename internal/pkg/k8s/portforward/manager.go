@@ -0,0 +1,208 @@
+// Package portforward keeps track of port forwards that run in the background while the user keeps using the application.
+package portforward
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"kubeui/internal/pkg/k8s/pods"
+)
+
+// Status describes the state of a port forward.
+type Status string
+
+const (
+	// Starting is used until the local port is listening.
+	Starting Status = "Starting"
+	// Active is used while connections to the local port are forwarded.
+	Active Status = "Active"
+	// Failed is used when the forward could not be started or the connection to the pod was lost.
+	Failed Status = "Failed"
+)
+
+// Forward contains information about a port forward.
+type Forward struct {
+	Id         string
+	Namespace  string
+	Pod        string
+	LocalPort  int
+	RemotePort int
+	Status     Status
+	// Err contains the reason of the failure if the status is Failed.
+	Err error
+}
+
+// Forwarder represents the interface towards kubernetes needed to forward ports.
+type Forwarder interface {
+	PortForward(namespace, name string, options pods.PortForwardOptions) error
+}
+
+// forward is a Forward along with the channel used to stop it.
+type forward struct {
+	Forward
+	stop chan struct{}
+	// Closed when the forward has finished, whether it was stopped or failed.
+	done chan struct{}
+}
+
+// Manager starts, stops and keeps track of port forwards.
+// It is safe to use from several goroutines.
+type Manager struct {
+	forwarder Forwarder
+
+	mu       sync.Mutex
+	forwards map[string]*forward
+
+	// Used to wait for the goroutines of all forwards to finish when stopping them.
+	wg sync.WaitGroup
+}
+
+// NewManager creates a new Manager.
+func NewManager(forwarder Forwarder) *Manager {
+	return &Manager{
+		forwarder: forwarder,
+		forwards:  map[string]*forward{},
+	}
+}
+
+// Start starts forwarding a local port to a port of a pod in the background.
+// A local port can only be used by one forward at a time, unless the previous forward has failed in which case it is replaced.
+// Returns the id of the forward.
+func (m *Manager) Start(namespace, pod string, localPort, remotePort int) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := fmt.Sprintf("localhost:%d", localPort)
+
+	if existing, ok := m.forwards[id]; ok {
+		if existing.Status != Failed {
+			return "", fmt.Errorf("local port %d is already forwarded to %s", localPort, existing.Pod)
+		}
+
+		// The failed forward is replaced, it is stopped in case it is still cleaning up.
+		close(existing.stop)
+	}
+
+	f := &forward{
+		Forward: Forward{
+			Id:         id,
+			Namespace:  namespace,
+			Pod:        pod,
+			LocalPort:  localPort,
+			RemotePort: remotePort,
+			Status:     Starting,
+		},
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	m.forwards[id] = f
+
+	ready := make(chan struct{})
+	forwarder := m.forwarder
+
+	m.wg.Add(2)
+	go func() {
+		defer m.wg.Done()
+		defer close(f.done)
+
+		err := forwarder.PortForward(namespace, pod, pods.PortForwardOptions{
+			LocalPort:    localPort,
+			RemotePort:   remotePort,
+			StopChannel:  f.stop,
+			ReadyChannel: ready,
+		})
+
+		select {
+		case <-f.stop:
+			// The forward was stopped, so it has already been removed.
+			return
+		default:
+		}
+
+		if err == nil {
+			err = fmt.Errorf("port forward closed")
+		}
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		f.Status = Failed
+		f.Err = err
+	}()
+
+	// Waits for the forward to become ready, which never happens if it fails or is stopped first.
+	go func() {
+		defer m.wg.Done()
+
+		select {
+		case <-ready:
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			if f.Status == Starting {
+				f.Status = Active
+			}
+		case <-f.done:
+		}
+	}()
+
+	return id, nil
+}
+
+// SetForwarder replaces the forwarder used to start new forwards, such as after switching to another cluster.
+// Forwards that are already running are not affected.
+func (m *Manager) SetForwarder(forwarder Forwarder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.forwarder = forwarder
+}
+
+// Stop stops a forward and removes it from the manager.
+func (m *Manager) Stop(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, ok := m.forwards[id]
+
+	if !ok {
+		return fmt.Errorf("no port forward called %s", id)
+	}
+
+	close(f.stop)
+	delete(m.forwards, id)
+
+	return nil
+}
+
+// StopAll stops all forwards and waits for them to finish.
+func (m *Manager) StopAll() {
+	m.mu.Lock()
+
+	for id, f := range m.forwards {
+		close(f.stop)
+		delete(m.forwards, id)
+	}
+
+	m.mu.Unlock()
+
+	m.wg.Wait()
+}
+
+// List returns all forwards, sorted by local port.
+func (m *Manager) List() []Forward {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	forwards := make([]Forward, 0, len(m.forwards))
+
+	for _, f := range m.forwards {
+		forwards = append(forwards, f.Forward)
+	}
+
+	sort.Slice(forwards, func(i, j int) bool {
+		return forwards[i].LocalPort < forwards[j].LocalPort
+	})
+
+	return forwards
+}
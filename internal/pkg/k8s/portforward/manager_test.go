@@ -0,0 +1,141 @@
+package portforward_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"kubeui/internal/pkg/k8s/pods"
+	"kubeui/internal/pkg/k8s/portforward"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockForwarder struct {
+	err error
+}
+
+// PortForward becomes ready and blocks until stopped, unless an error is configured in which case it fails immediately.
+func (f *mockForwarder) PortForward(namespace, name string, options pods.PortForwardOptions) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	close(options.ReadyChannel)
+	<-options.StopChannel
+	return nil
+}
+
+func TestStartAndStop(t *testing.T) {
+
+	manager := portforward.NewManager(&mockForwarder{})
+
+	id, err := manager.Start("default", "web-1", 8080, 80)
+	assert.Nil(t, err)
+	assert.Equal(t, "localhost:8080", id)
+
+	assert.Eventually(t, func() bool {
+		forwards := manager.List()
+		return len(forwards) == 1 && forwards[0].Status == portforward.Active
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = manager.Start("default", "web-2", 8080, 80)
+	assert.Error(t, err, "a local port can only be used once")
+
+	assert.Nil(t, manager.Stop(id))
+	assert.Empty(t, manager.List())
+	assert.Error(t, manager.Stop(id))
+}
+
+func TestFailedForward(t *testing.T) {
+
+	manager := portforward.NewManager(&mockForwarder{err: fmt.Errorf("connection refused")})
+
+	_, err := manager.Start("default", "web-1", 8080, 80)
+	assert.Nil(t, err)
+
+	assert.Eventually(t, func() bool {
+		forwards := manager.List()
+		return len(forwards) == 1 && forwards[0].Status == portforward.Failed
+	}, time.Second, 10*time.Millisecond)
+
+	assert.EqualError(t, manager.List()[0].Err, "connection refused")
+
+	// A failed forward can be replaced.
+	_, err = manager.Start("default", "web-1", 8080, 80)
+	assert.Nil(t, err)
+}
+
+func TestStopAll(t *testing.T) {
+
+	manager := portforward.NewManager(&mockForwarder{})
+
+	_, err := manager.Start("default", "web-1", 8081, 80)
+	assert.Nil(t, err)
+	_, err = manager.Start("default", "web-2", 8080, 80)
+	assert.Nil(t, err)
+
+	forwards := manager.List()
+	assert.Len(t, forwards, 2)
+	assert.Equal(t, 8080, forwards[0].LocalPort, "forwards are sorted by local port")
+
+	manager.StopAll()
+	assert.Empty(t, manager.List())
+}
+
+func TestSetForwarder(t *testing.T) {
+
+	manager := portforward.NewManager(&mockForwarder{})
+
+	_, err := manager.Start("default", "web-1", 8080, 80)
+	assert.Nil(t, err)
+
+	manager.SetForwarder(&mockForwarder{err: fmt.Errorf("connection refused")})
+
+	_, err = manager.Start("default", "web-2", 8081, 80)
+	assert.Nil(t, err)
+
+	assert.Eventually(t, func() bool {
+		forwards := manager.List()
+		return len(forwards) == 2 && forwards[0].Status == portforward.Active && forwards[1].Status == portforward.Failed
+	}, time.Second, 10*time.Millisecond, "only new forwards use the new forwarder")
+
+	manager.StopAll()
+}
+
+func TestRestartAfterFailureBeforeReady(t *testing.T) {
+
+	forwarder := &mockForwarder{err: fmt.Errorf("connection refused")}
+	manager := portforward.NewManager(forwarder)
+
+	_, err := manager.Start("default", "web-1", 8080, 80)
+	assert.Nil(t, err)
+
+	assert.Eventually(t, func() bool {
+		forwards := manager.List()
+		return len(forwards) == 1 && forwards[0].Status == portforward.Failed
+	}, time.Second, 10*time.Millisecond)
+
+	manager.SetForwarder(&mockForwarder{})
+
+	_, err = manager.Start("default", "web-1", 8080, 80)
+	assert.Nil(t, err)
+
+	assert.Eventually(t, func() bool {
+		forwards := manager.List()
+		return len(forwards) == 1 && forwards[0].Status == portforward.Active
+	}, time.Second, 10*time.Millisecond)
+
+	// StopAll waits for the goroutines of every forward, including the one that failed before it became ready.
+	stopped := make(chan struct{})
+	go func() {
+		manager.StopAll()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("the goroutines of the failed forward did not finish")
+	}
+}
@@ -0,0 +1,122 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/life4/genesis/slices"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Resource is a type of resource served by the api server, as found through discovery.
+type Resource struct {
+	GroupVersionResource schema.GroupVersionResource
+	Kind                 string
+	Namespaced           bool
+	ShortNames           []string
+}
+
+// Name returns the name of the resource qualified by its group, the same way as it is written when running `kubectl get`.
+// Resources in the core group are not qualified.
+func (r Resource) Name() string {
+	if r.GroupVersionResource.Group == "" {
+		return r.GroupVersionResource.Resource
+	}
+
+	return fmt.Sprintf("%s.%s", r.GroupVersionResource.Resource, r.GroupVersionResource.Group)
+}
+
+// NewResources creates the list of resource types from the result of discovery, sorted by name.
+// Subresources and resource types that can not be both listed and fetched are left out.
+func NewResources(apiResourceLists []*metav1.APIResourceList) ([]Resource, error) {
+	resources := []Resource{}
+
+	for _, apiResourceList := range apiResourceLists {
+		groupVersion, err := schema.ParseGroupVersion(apiResourceList.GroupVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse group version %s: %v", apiResourceList.GroupVersion, err)
+		}
+
+		for _, apiResource := range apiResourceList.APIResources {
+			if strings.Contains(apiResource.Name, "/") || !slices.Contains(apiResource.Verbs, "list") || !slices.Contains(apiResource.Verbs, "get") {
+				continue
+			}
+
+			resources = append(resources, Resource{
+				GroupVersionResource: groupVersion.WithResource(apiResource.Name),
+				Kind:                 apiResource.Kind,
+				Namespaced:           apiResource.Namespaced,
+				ShortNames:           apiResource.ShortNames,
+			})
+		}
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Name() < resources[j].Name()
+	})
+
+	return resources, nil
+}
+
+// Table is a list of resources in the table format used by `kubectl get`.
+type Table struct {
+	Columns []metav1.TableColumnDefinition
+	Rows    []TableRow
+}
+
+// TableRow is a single resource in a Table.
+type TableRow struct {
+	Namespace string
+	Name      string
+	// Cells contains one value for each column of the table.
+	Cells []interface{}
+}
+
+// NewTable creates a Table from a table returned by the api server.
+// The rows are expected to include the metadata of their objects, which is the default when requesting a table.
+func NewTable(table *metav1.Table) (*Table, error) {
+	rows := make([]TableRow, 0, len(table.Rows))
+
+	for _, row := range table.Rows {
+		var metadata metav1.PartialObjectMetadata
+		if err := json.Unmarshal(row.Object.Raw, &metadata); err != nil {
+			return nil, fmt.Errorf("failed to read metadata of table row: %v", err)
+		}
+
+		rows = append(rows, TableRow{
+			Namespace: metadata.Namespace,
+			Name:      metadata.Name,
+			Cells:     row.Cells,
+		})
+	}
+
+	return &Table{Columns: table.ColumnDefinitions, Rows: rows}, nil
+}
+
+// NewTableFromList creates a Table with the name and age of each resource in a list.
+// It is used for resources that the api server can not return as a table.
+// The age is a timestamp, the same as the age column of tables returned by the api server.
+func NewTableFromList(list *unstructured.UnstructuredList) *Table {
+	rows := make([]TableRow, 0, len(list.Items))
+
+	for _, item := range list.Items {
+		rows = append(rows, TableRow{
+			Namespace: item.GetNamespace(),
+			Name:      item.GetName(),
+			Cells:     []interface{}{item.GetName(), item.GetCreationTimestamp().UTC().Format(time.RFC3339)},
+		})
+	}
+
+	return &Table{
+		Columns: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string", Format: "name"},
+			{Name: "Age", Type: "date"},
+		},
+		Rows: rows,
+	}
+}
@@ -0,0 +1,75 @@
+package resources_test
+
+import (
+	"testing"
+	"time"
+
+	"kubeui/internal/pkg/k8s/resources"
+
+	"github.com/life4/genesis/slices"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestNewResources(t *testing.T) {
+	apiResourceLists := []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", Kind: "Pod", Namespaced: true, ShortNames: []string{"po"}, Verbs: metav1.Verbs{"get", "list", "watch"}},
+				{Name: "pods/log", Kind: "Pod", Namespaced: true, Verbs: metav1.Verbs{"get"}},
+				{Name: "bindings", Kind: "Binding", Namespaced: true, Verbs: metav1.Verbs{"create"}},
+				{Name: "nodes", Kind: "Node", Verbs: metav1.Verbs{"get", "list"}},
+			},
+		},
+		{
+			GroupVersion: "example.com/v1alpha1",
+			APIResources: []metav1.APIResource{
+				{Name: "widgets", Kind: "Widget", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+			},
+		},
+	}
+
+	got, err := resources.NewResources(apiResourceLists)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"nodes", "pods", "widgets.example.com"}, slices.Map(got, func(r resources.Resource) string { return r.Name() }))
+	assert.Equal(t, "Pod", got[1].Kind)
+	assert.True(t, got[1].Namespaced)
+	assert.Equal(t, []string{"po"}, got[1].ShortNames)
+	assert.Equal(t, "v1alpha1", got[2].GroupVersionResource.Version)
+
+	_, err = resources.NewResources([]*metav1.APIResourceList{{GroupVersion: "a/b/c"}})
+	assert.Error(t, err)
+}
+
+func TestNewTable(t *testing.T) {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{{Name: "Name", Type: "string"}},
+		Rows: []metav1.TableRow{
+			{Cells: []interface{}{"widget-1"}, Object: runtime.RawExtension{Raw: []byte(`{"metadata":{"name":"widget-1","namespace":"default"}}`)}},
+		},
+	}
+
+	got, err := resources.NewTable(table)
+	assert.Nil(t, err)
+	assert.Equal(t, table.ColumnDefinitions, got.Columns)
+	assert.Equal(t, []resources.TableRow{{Namespace: "default", Name: "widget-1", Cells: []interface{}{"widget-1"}}}, got.Rows)
+
+	table.Rows[0].Object.Raw = nil
+	_, err = resources.NewTable(table)
+	assert.Error(t, err)
+}
+
+func TestNewTableFromList(t *testing.T) {
+	item := unstructured.Unstructured{}
+	item.SetName("widget-1")
+	item.SetNamespace("default")
+	item.SetCreationTimestamp(metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)))
+
+	got := resources.NewTableFromList(&unstructured.UnstructuredList{Items: []unstructured.Unstructured{item}})
+
+	assert.Equal(t, []string{"Name", "Age"}, slices.Map(got.Columns, func(c metav1.TableColumnDefinition) string { return c.Name }))
+	assert.Equal(t, []resources.TableRow{{Namespace: "default", Name: "widget-1", Cells: []interface{}{"widget-1", "2024-01-01T12:00:00Z"}}}, got.Rows)
+}
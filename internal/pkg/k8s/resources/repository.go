@@ -0,0 +1,139 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+)
+
+// ErrTableNotSupported is returned when a list of resources can not be fetched in the table format.
+var ErrTableNotSupported = errors.New("table format not supported")
+
+// tableAcceptHeader requests a table from the api server, falling back to a regular list if tables are not supported.
+const tableAcceptHeader = "application/json;as=Table;v=v1;g=meta.k8s.io,application/json;as=Table;v=v1beta1;g=meta.k8s.io,application/json"
+
+// Repository defines the interface for the repository of arbitrary resources.
+type Repository interface {
+	ServerPreferredResources() ([]*metav1.APIResourceList, error)
+	ServerVersion(ctx context.Context) (*version.Info, error)
+	List(ctx context.Context, resource Resource, namespace string) (*unstructured.UnstructuredList, error)
+	ListTable(ctx context.Context, resource Resource, namespace string) (*metav1.Table, error)
+	Get(ctx context.Context, resource Resource, namespace, name string) (*unstructured.Unstructured, error)
+}
+
+// NewRepository creates a new Repository.
+// The dynamic client is used to fetch resources of any type, it may be nil in which case fetching resources fails.
+func NewRepository(discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface) Repository {
+	return &RepositoryImpl{
+		discoveryClient: discoveryClient,
+		dynamicClient:   dynamicClient,
+	}
+}
+
+// RepositoryImpl is used to discover the resource types of a cluster and to fetch resources of any type.
+type RepositoryImpl struct {
+	discoveryClient discovery.DiscoveryInterface
+	dynamicClient   dynamic.Interface
+}
+
+// ServerPreferredResources fetches the resource types served by the api server, in the preferred version of each group.
+// If some groups could not be discovered, then the resources of the remaining groups are returned along with the error.
+func (c *RepositoryImpl) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	return discovery.ServerPreferredResources(c.discoveryClient)
+}
+
+// ServerVersion fetches the version of the api server.
+// Unlike the discovery client, the request is cancelled through ctx.
+func (c *RepositoryImpl) ServerVersion(ctx context.Context) (*version.Info, error) {
+	body, err := c.discoveryClient.RESTClient().Get().AbsPath("/version").Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	var info version.Info
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server version: %v", err)
+	}
+
+	return &info, nil
+}
+
+// List fetches the resources of a type in a namespace, an empty namespace lists resources in all namespaces.
+func (c *RepositoryImpl) List(ctx context.Context, resource Resource, namespace string) (*unstructured.UnstructuredList, error) {
+	if c.dynamicClient == nil {
+		return nil, fmt.Errorf("no dynamic client configured")
+	}
+
+	if !resource.Namespaced {
+		return c.dynamicClient.Resource(resource.GroupVersionResource).List(ctx, metav1.ListOptions{})
+	}
+
+	return c.dynamicClient.Resource(resource.GroupVersionResource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+}
+
+// ListTable fetches the resources of a type in a namespace in the table format used by `kubectl get`.
+// An empty namespace lists resources in all namespaces.
+// ErrTableNotSupported is returned if the api server does not return a table.
+func (c *RepositoryImpl) ListTable(ctx context.Context, resource Resource, namespace string) (*metav1.Table, error) {
+	restClient := c.discoveryClient.RESTClient()
+	if restClient == nil {
+		return nil, ErrTableNotSupported
+	}
+
+	data, err := restClient.Get().
+		AbsPath(resourcePath(resource, namespace)...).
+		SetHeader("Accept", tableAcceptHeader).
+		Do(ctx).
+		Raw()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var table metav1.Table
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("failed to decode table: %v", err)
+	}
+
+	if table.Kind != "Table" {
+		return nil, ErrTableNotSupported
+	}
+
+	return &table, nil
+}
+
+// Get fetches a single resource.
+func (c *RepositoryImpl) Get(ctx context.Context, resource Resource, namespace, name string) (*unstructured.Unstructured, error) {
+	if c.dynamicClient == nil {
+		return nil, fmt.Errorf("no dynamic client configured")
+	}
+
+	if !resource.Namespaced {
+		return c.dynamicClient.Resource(resource.GroupVersionResource).Get(ctx, name, metav1.GetOptions{})
+	}
+
+	return c.dynamicClient.Resource(resource.GroupVersionResource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// resourcePath returns the segments of the api path used to list resources of a type.
+func resourcePath(resource Resource, namespace string) []string {
+	gvr := resource.GroupVersionResource
+
+	path := []string{"/apis", gvr.Group, gvr.Version}
+	if gvr.Group == "" {
+		path = []string{"/api", gvr.Version}
+	}
+
+	if resource.Namespaced && namespace != "" {
+		path = append(path, "namespaces", namespace)
+	}
+
+	return append(path, gvr.Resource)
+}
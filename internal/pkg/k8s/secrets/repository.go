@@ -0,0 +1,37 @@
+package secrets
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// Repository defines the interface for the secrets repository.
+type Repository interface {
+	Get(ctx context.Context, namespace, name string) (*v1.Secret, error)
+	List(ctx context.Context, namespace string) (*v1.SecretList, error)
+}
+
+// NewRepository creates a new Repository.
+func NewRepository(kubectl corev1.CoreV1Interface) Repository {
+	return &RepositoryImpl{
+		kubectl: kubectl,
+	}
+}
+
+// RepositoryImpl is used to fetch secret related data from kubernetes.
+type RepositoryImpl struct {
+	kubectl corev1.CoreV1Interface
+}
+
+// Get fetches a single secret.
+func (c *RepositoryImpl) Get(ctx context.Context, namespace, name string) (*v1.Secret, error) {
+	return c.kubectl.Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// List fetches a list of secrets for a given namespace.
+func (c *RepositoryImpl) List(ctx context.Context, namespace string) (*v1.SecretList, error) {
+	return c.kubectl.Secrets(namespace).List(ctx, metav1.ListOptions{})
+}
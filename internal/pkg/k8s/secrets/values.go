@@ -0,0 +1,147 @@
+package secrets
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// The functions in this file handle the values of secrets.
+// Errors returned by them never contain any part of the values, so that they can be displayed or logged safely.
+
+// Format describes the content of a value of a secret.
+type Format int
+
+const (
+	// Text is used for values that are printable text.
+	Text Format = iota
+	// JSON is used for values that are a JSON object or array.
+	JSON
+	// PEM is used for values containing PEM encoded certificates or keys.
+	PEM
+	// DockerConfigJSON is used for the registry credentials of image pull secrets.
+	DockerConfigJSON
+	// Binary is used for values that can not be displayed as text.
+	Binary
+)
+
+// String implements the stringer interface for Format.
+func (f Format) String() string {
+	switch f {
+	case Text:
+		return "text"
+	case JSON:
+		return "json"
+	case PEM:
+		return "pem"
+	case DockerConfigJSON:
+		return "dockerconfigjson"
+	case Binary:
+		return "binary"
+	}
+	return "unknown"
+}
+
+// DetectFormat detects the format of the value stored under the given key of a secret.
+// The values of secrets are base64 encoded by the api server, the value is expected to already have been decoded.
+func DetectFormat(key string, value []byte) Format {
+	trimmed := bytes.TrimSpace(value)
+
+	switch {
+	case key == v1.DockerConfigJsonKey || key == v1.DockerConfigKey:
+		return DockerConfigJSON
+	case !isText(value):
+		return Binary
+	case bytes.HasPrefix(trimmed, []byte("-----BEGIN ")):
+		if block, _ := pem.Decode(trimmed); block != nil {
+			return PEM
+		}
+	case bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("[")):
+		if json.Valid(trimmed) {
+			return JSON
+		}
+	}
+
+	return Text
+}
+
+// isText reports whether a value is valid utf8 without control characters other than whitespace.
+func isText(value []byte) bool {
+	if !utf8.Valid(value) {
+		return false
+	}
+
+	for _, r := range string(value) {
+		if unicode.IsControl(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DecodeDockerConfig parses the registry credentials of an image pull secret and decodes the auth field of each registry,
+// which contains the username and password separated by a colon, encoded as base64.
+// Both the .dockerconfigjson format, where the registries are listed under "auths", and the legacy .dockercfg format are supported.
+func DecodeDockerConfig(value []byte) (map[string]interface{}, error) {
+	var config map[string]interface{}
+	if err := json.Unmarshal(value, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse docker config, the value is not a json object")
+	}
+
+	registries := config
+	if auths, ok := config["auths"].(map[string]interface{}); ok {
+		registries = auths
+	}
+
+	for _, registry := range registries {
+		credentials, ok := registry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		auth, ok := credentials["auth"].(string)
+		if !ok {
+			continue
+		}
+
+		if decoded, err := base64.StdEncoding.DecodeString(auth); err == nil {
+			credentials["auth"] = string(decoded)
+		}
+	}
+
+	return config, nil
+}
+
+// DescribePEM describes each block of PEM encoded data, including the subject, issuer and expiry of certificates.
+func DescribePEM(value []byte) []string {
+	descriptions := []string{}
+
+	for {
+		block, rest := pem.Decode(value)
+		if block == nil {
+			break
+		}
+
+		description := block.Type
+
+		if block.Type == "CERTIFICATE" {
+			if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+				description = fmt.Sprintf("%s subject=%s issuer=%s expires=%s", block.Type, cert.Subject, cert.Issuer, cert.NotAfter.Format(time.RFC3339))
+			}
+		}
+
+		descriptions = append(descriptions, description)
+		value = rest
+	}
+
+	return descriptions
+}
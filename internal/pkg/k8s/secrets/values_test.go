@@ -0,0 +1,92 @@
+package secrets_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"kubeui/internal/pkg/k8s/secrets"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value string
+		want  secrets.Format
+	}{
+		{"Should detect text", "password", "hunter2", secrets.Text},
+		{"Should detect a json object", "config", ` {"debug": true}`, secrets.JSON},
+		{"Should detect a json array", "hosts", `["a", "b"]`, secrets.JSON},
+		{"Should treat invalid json as text", "config", `{not json`, secrets.Text},
+		{"Should detect pem", "tls.crt", "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n", secrets.PEM},
+		{"Should detect docker config by key", ".dockerconfigjson", `{"auths":{}}`, secrets.DockerConfigJSON},
+		{"Should detect legacy docker config by key", ".dockercfg", `{}`, secrets.DockerConfigJSON},
+		{"Should detect binary data", "keystore", "\x00\x01\xfe", secrets.Binary},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, secrets.DetectFormat(tt.key, []byte(tt.value)))
+		})
+	}
+}
+
+func TestDecodeDockerConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		registry func(map[string]interface{}) interface{}
+	}{
+		{
+			"Should decode auths",
+			`{"auths":{"registry.example.com":{"auth":"dXNlcjpwYXNz"}}}`,
+			func(config map[string]interface{}) interface{} {
+				return config["auths"].(map[string]interface{})["registry.example.com"]
+			},
+		},
+		{
+			"Should decode the legacy format",
+			`{"registry.example.com":{"auth":"dXNlcjpwYXNz"}}`,
+			func(config map[string]interface{}) interface{} { return config["registry.example.com"] },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := secrets.DecodeDockerConfig([]byte(tt.value))
+			assert.Nil(t, err)
+			assert.Equal(t, map[string]interface{}{"auth": "user:pass"}, tt.registry(config))
+		})
+	}
+
+	_, err := secrets.DecodeDockerConfig([]byte("s3cr3t"))
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "s3cr3t")
+}
+
+func TestDescribePEM(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	notAfter := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "example.com"}, NotAfter: notAfter}
+
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+
+	value := append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})...)
+
+	assert.Equal(t, []string{
+		"CERTIFICATE subject=CN=example.com issuer=CN=example.com expires=2030-01-02T03:04:05Z",
+		"EC PRIVATE KEY",
+	}, secrets.DescribePEM(value))
+}
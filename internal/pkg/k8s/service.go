@@ -1,13 +1,37 @@
 package k8s
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"kubeui/internal/pkg/k8s/configmaps"
+	"kubeui/internal/pkg/k8s/deployments"
+	"kubeui/internal/pkg/k8s/ingresses"
 	"kubeui/internal/pkg/k8s/namespace"
+	"kubeui/internal/pkg/k8s/nodes"
 	"kubeui/internal/pkg/k8s/pods"
+	"kubeui/internal/pkg/k8s/resources"
+	"kubeui/internal/pkg/k8s/secrets"
+	"kubeui/internal/pkg/k8s/services"
+	"kubeui/internal/pkg/k8s/statefulsets"
+	"sync"
 	"time"
 
+	"golang.org/x/sync/errgroup"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd/api"
 )
 
 // Service defines the interface to fetch data from kubernetes.
@@ -15,33 +39,199 @@ import (
 type Service interface {
 	// Lists namespaces in the cluster.
 	ListNamespaces() (*v1.NamespaceList, error)
-	// Lists pods in the specified namespace.
+	// Lists pods in the specified namespace, an empty namespace lists pods in all namespaces.
 	ListPods(namespace string) (*v1.PodList, error)
-	// Fetches information about a single pod, including events and logs.
+	// Watches pods in the specified namespace, starting from the given resource version.
+	// Events are delivered on the returned channel which is closed when ctx is cancelled or the watch ends.
+	WatchPods(ctx context.Context, namespace, resourceVersion string) (<-chan pods.WatchEvent, error)
+	// Lists pods in several namespaces and watches each of them for changes, an empty namespace means all namespaces.
+	// Only pods matching the selectors of the options are listed and watched.
+	// Events of all watches are delivered on the returned channel which is closed when ctx is cancelled or any of the watches end.
+	ListAndWatchPods(ctx context.Context, namespaces []string, options pods.ListOptions) (*v1.PodList, <-chan pods.WatchEvent, error)
+	// Fetches information about a single pod, including events.
 	GetPod(namespace, id string) (*pods.Pod, error)
+	// Streams the logs of a container, starting with the latest log lines and following new lines as they are written.
+	// Lines are delivered on the returned channel which is closed when ctx is cancelled or the stream ends.
+	StreamLogs(ctx context.Context, namespace, name, container string) (<-chan string, error)
+	// Runs a command in a container with the given streams attached, blocking until the command exits.
+	Exec(ctx context.Context, namespace, name, container string, options pods.ExecOptions) error
+	// Forwards a local port to a port of a pod, blocking until the forward is stopped or fails.
+	PortForward(namespace, name string, options pods.PortForwardOptions) error
 	// Delete the pod with the specified name in the specified namespace.
 	// Returns the name of the deleted pod.
 	DeletePod(namespace, name string) (string, error)
+	// Deletes several pods concurrently, a failure to delete one pod does not stop the others from being deleted.
+	// Returns the result of each deletion in the same order as the pods were given.
+	DeletePods(pods []types.NamespacedName) []pods.DeleteResult
+	// Replaces a pod with an edited version, which must have the resource version of the pod it was edited from.
+	// Returns the name of the updated pod.
+	UpdatePod(namespace string, pod *v1.Pod) (string, error)
+	// Lists deployments in the specified namespace.
+	ListDeployments(namespace string) (*appsv1.DeploymentList, error)
+	// Fetches information about a single deployment, including events.
+	GetDeployment(namespace, name string) (*deployments.Deployment, error)
+	// Lists the pods owned by a deployment, as matched by the selector of the deployment.
+	ListDeploymentPods(namespace, name string) (*v1.PodList, error)
+	// Replaces a deployment with an edited version, which must have the resource version of the deployment it was edited from.
+	// Returns the name of the updated deployment.
+	UpdateDeployment(namespace string, deployment *appsv1.Deployment) (string, error)
+	// Sets the number of desired replicas of a deployment.
+	// Returns the name of the scaled deployment.
+	ScaleDeployment(namespace, name string, replicas int32) (string, error)
+	// Restarts the pods of a deployment the same way as `kubectl rollout restart`.
+	// Returns the name of the restarted deployment.
+	RestartDeployment(namespace, name string) (string, error)
+	// Lists statefulsets in the specified namespace.
+	ListStatefulSets(namespace string) (*appsv1.StatefulSetList, error)
+	// Sets the number of desired replicas of a statefulset.
+	// Returns the name of the scaled statefulset.
+	ScaleStatefulSet(namespace, name string, replicas int32) (string, error)
+	// Restarts the pods of a statefulset the same way as `kubectl rollout restart`.
+	// Returns the name of the restarted statefulset.
+	RestartStatefulSet(namespace, name string) (string, error)
+	// Lists the nodes in the cluster, including the number of pods running on each node.
+	ListNodes() (*nodes.NodeList, error)
+	// Fetches information about a single node, including events.
+	GetNode(name string) (*nodes.Node, error)
+	// Lists the pods in all namespaces that are scheduled on a node.
+	ListNodePods(name string) (*v1.PodList, error)
+	// Marks a node as unschedulable, or schedulable again if unschedulable is false.
+	// Returns the name of the node.
+	CordonNode(name string, unschedulable bool) (string, error)
+	// Cordons a node and evicts its pods the same way as `kubectl drain`.
+	// The status of each pod is delivered on the returned channel which is closed when all pods have been handled or ctx is cancelled.
+	DrainNode(ctx context.Context, name string, options nodes.DrainOptions) (<-chan nodes.DrainEvent, error)
+	// Lists configmaps in the specified namespace.
+	ListConfigMaps(namespace string) (*v1.ConfigMapList, error)
+	// Fetches a single configmap.
+	GetConfigMap(namespace, name string) (*v1.ConfigMap, error)
+	// Lists secrets in the specified namespace.
+	ListSecrets(namespace string) (*v1.SecretList, error)
+	// Fetches a single secret.
+	GetSecret(namespace, name string) (*v1.Secret, error)
+	// Lists services in the specified namespace.
+	ListServices(namespace string) (*v1.ServiceList, error)
+	// Fetches information about a single service, including the endpoint slices backing it and events.
+	GetService(namespace, name string) (*services.Service, error)
+	// Lists the routes of all ingresses in the specified namespace, one for each host and path.
+	// Backends referencing services or ports that do not exist and missing tls secrets are reported as problems of the routes.
+	ListIngressRoutes(namespace string) ([]ingresses.Route, error)
+	// Lists the types of resources served by the cluster, including custom resources.
+	ListResourceTypes() ([]resources.Resource, error)
+	// Lists resources of any type in the table format used by `kubectl get`, an empty namespace lists resources in all namespaces.
+	// Namespaces are ignored for resources that are not namespaced.
+	ListResources(resource resources.Resource, namespace string) (*resources.Table, error)
+	// Fetches a single resource of any type.
+	GetResource(resource resources.Resource, namespace, name string) (*unstructured.Unstructured, error)
+	// Fetches the version of the api server, which fails if the cluster can not be reached or does not accept the credentials.
+	ServerVersion() (*version.Info, error)
+	// Cancels all requests in progress that were not given a context, such as listing or fetching resources.
+	// The Service should not be used afterwards, it is called when switching to another cluster.
+	Close()
+}
+
+// ServiceFactory creates a Service connected to the current context of a kubeconfig.
+type ServiceFactory func(config api.Config) (Service, error)
+
+// Repositories contains the repositories used by a Service to fetch data from kubernetes.
+type Repositories struct {
+	PodsRepository         pods.Repository
+	NamespaceRepository    namespace.Repository
+	DeploymentsRepository  deployments.Repository
+	StatefulSetsRepository statefulsets.Repository
+	NodesRepository        nodes.Repository
+	ConfigMapsRepository   configmaps.Repository
+	SecretsRepository      secrets.Repository
+	ResourcesRepository    resources.Repository
+	ServicesRepository     services.Repository
+	IngressesRepository    ingresses.Repository
+}
+
+// NewRepositories creates all repositories needed by a Service from a kubernetes ClientSet.
+// The restConfig is used for connections that are upgraded to streams and for fetching resources of arbitrary types,
+// it may be nil if those are not needed.
+func NewRepositories(clientSet kubernetes.Interface, restConfig *rest.Config) Repositories {
+	var dynamicClient dynamic.Interface
+	if restConfig != nil {
+		// Creating a dynamic client only fails for invalid configurations, which the clientSet was created from as well.
+		dynamicClient, _ = dynamic.NewForConfig(restConfig)
+	}
+
+	return Repositories{
+		PodsRepository:         pods.NewRepository(clientSet.CoreV1(), restConfig),
+		NamespaceRepository:    namespace.NewRepository(clientSet.CoreV1()),
+		DeploymentsRepository:  deployments.NewRepository(clientSet.AppsV1(), clientSet.CoreV1()),
+		StatefulSetsRepository: statefulsets.NewRepository(clientSet.AppsV1()),
+		NodesRepository:        nodes.NewRepository(clientSet.CoreV1()),
+		ConfigMapsRepository:   configmaps.NewRepository(clientSet.CoreV1()),
+		SecretsRepository:      secrets.NewRepository(clientSet.CoreV1()),
+		ResourcesRepository:    resources.NewRepository(clientSet.Discovery(), dynamicClient),
+		ServicesRepository:     services.NewRepository(clientSet.CoreV1(), clientSet.DiscoveryV1()),
+		IngressesRepository:    ingresses.NewRepository(clientSet.NetworkingV1()),
+	}
 }
 
 // NewK8sService creates a new Service.
-func NewK8sService(podsRepository pods.Repository, namespaceRepository namespace.Repository) Service {
+func NewK8sService(repositories Repositories) Service {
+	ctx, cancel := context.WithCancel(context.Background())
+
 	return &K8sServiceImpl{
-		PodsRepository:      podsRepository,
-		NamespaceRepository: namespaceRepository,
+		Repositories: repositories,
+		ctx:          ctx,
+		cancel:       cancel,
 	}
 }
 
+// NewK8sServiceForConfig creates a Service connected to the current context of a kubeconfig that has already been loaded.
+// Unlike connecting through a clientcmd.ConfigAccess, the kubeconfig is not read again, so changes that have not been saved are used.
+func NewK8sServiceForConfig(config api.Config) (Service, error) {
+	restConfig, err := NewRestConfigForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rest config for context %s: %v", config.CurrentContext, err)
+	}
+
+	clientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clientset for context %s: %v", config.CurrentContext, err)
+	}
+
+	return NewK8sService(NewRepositories(clientSet, restConfig)), nil
+}
+
 // K8sServiceImpl is used to fetch data and issue commands to a kubernetes cluster.
 type K8sServiceImpl struct {
-	PodsRepository      pods.Repository
-	NamespaceRepository namespace.Repository
+	Repositories
+
+	// Requests that are not given a context are cancelled through ctx when the Service is closed.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// ServerVersion fetches the version of the api server.
+// It is used to check that the cluster can be connected to, so it fails quickly if the cluster does not respond.
+func (c *K8sServiceImpl) ServerVersion() (*version.Info, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	info, err := c.ResourcesRepository.ServerVersion(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server version: %v", err)
+	}
+
+	return info, nil
+}
+
+// Close cancels all requests in progress that were not given a context.
+func (c *K8sServiceImpl) Close() {
+	c.cancel()
 }
 
 // ListNamespaces fetches all namespaces for the current context.
 func (c *K8sServiceImpl) ListNamespaces() (*v1.NamespaceList, error) {
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
 	defer cancel()
 
 	namespaces, err := c.NamespaceRepository.List(ctx)
@@ -56,24 +246,138 @@ func (c *K8sServiceImpl) ListNamespaces() (*v1.NamespaceList, error) {
 
 // ListPods fetches all pods for the current context and namespace.
 func (c *K8sServiceImpl) ListPods(namespace string) (*v1.PodList, error) {
+	return c.listPods(namespace, pods.ListOptions{})
+}
+
+// listPods fetches the pods in a namespace using the given options, see ListPods.
+func (c *K8sServiceImpl) listPods(namespace string, options pods.ListOptions) (*v1.PodList, error) {
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
 	defer cancel()
 
-	pods, err := c.PodsRepository.List(ctx, namespace)
+	podList, err := c.PodsRepository.List(ctx, namespace, options)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to list namespaces: %v", err)
 	}
 
-	return pods, nil
+	return podList, nil
+
+}
+
+// WatchPods watches the pods in a namespace until ctx is cancelled or the watch is closed by the server.
+// Bookmarks are skipped and an error event ends the watch, in which case the caller should list the pods again
+// and start a new watch from the resource version of that list.
+func (c *K8sServiceImpl) WatchPods(ctx context.Context, namespace, resourceVersion string) (<-chan pods.WatchEvent, error) {
+	return c.watchPods(ctx, namespace, pods.WatchOptions{ResourceVersion: resourceVersion})
+}
+
+// watchPods watches the pods in a namespace using the given options, see WatchPods.
+func (c *K8sServiceImpl) watchPods(ctx context.Context, namespace string, options pods.WatchOptions) (<-chan pods.WatchEvent, error) {
+
+	watcher, err := c.PodsRepository.Watch(ctx, namespace, options)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch pods: %v", err)
+	}
+
+	events := make(chan pods.WatchEvent)
+
+	go func() {
+		defer close(events)
+		defer watcher.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-watcher.ResultChan():
+				if !ok || e.Type == watch.Error {
+					return
+				}
+
+				pod, ok := e.Object.(*v1.Pod)
+				if !ok || e.Type == watch.Bookmark {
+					continue
+				}
+
+				select {
+				case events <- pods.WatchEvent{Type: pods.WatchEventType(e.Type), Pod: *pod}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// ListAndWatchPods lists the pods in each namespace and starts a watch from the resource version of that list.
+// All watches are stopped as soon as one of them ends, in which case the caller should list and watch the pods again.
+func (c *K8sServiceImpl) ListAndWatchPods(ctx context.Context, namespaces []string, options pods.ListOptions) (*v1.PodList, <-chan pods.WatchEvent, error) {
+
+	watchCtx, cancel := context.WithCancel(ctx)
+
+	podList := &v1.PodList{}
+	watches := make([]<-chan pods.WatchEvent, 0, len(namespaces))
+
+	for _, namespace := range namespaces {
+		namespacePodList, err := c.listPods(namespace, options)
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+
+		podList.Items = append(podList.Items, namespacePodList.Items...)
+
+		events, err := c.watchPods(watchCtx, namespace, pods.WatchOptions{
+			ResourceVersion: namespacePodList.ResourceVersion,
+			LabelSelector:   options.LabelSelector,
+			FieldSelector:   options.FieldSelector,
+		})
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+
+		watches = append(watches, events)
+	}
+
+	events := make(chan pods.WatchEvent)
+	wg := sync.WaitGroup{}
+
+	for _, namespaceEvents := range watches {
+		wg.Add(1)
+
+		go func(namespaceEvents <-chan pods.WatchEvent) {
+			defer wg.Done()
+			// The end of one watch ends all of them, since the pods have to be listed again anyway.
+			defer cancel()
+
+			for e := range namespaceEvents {
+				select {
+				case events <- e:
+				case <-watchCtx.Done():
+					return
+				}
+			}
+		}(namespaceEvents)
+	}
+
+	go func() {
+		wg.Wait()
+		cancel()
+		close(events)
+	}()
 
+	return podList, events, nil
 }
 
 // GetPod fetches a pod in the current context and namespace.
 func (c *K8sServiceImpl) GetPod(namespace, name string) (*pods.Pod, error) {
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
 	defer cancel()
 
 	pod, err := c.PodsRepository.Get(ctx, namespace, name)
@@ -82,7 +386,7 @@ func (c *K8sServiceImpl) GetPod(namespace, name string) (*pods.Pod, error) {
 		return nil, fmt.Errorf("failed to get pod: %v", err)
 	}
 
-	eventsCtx, eventsCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	eventsCtx, eventsCancel := context.WithTimeout(c.ctx, 5*time.Second)
 	defer eventsCancel()
 
 	events, err := c.PodsRepository.Events(eventsCtx, namespace, name)
@@ -91,31 +395,73 @@ func (c *K8sServiceImpl) GetPod(namespace, name string) (*pods.Pod, error) {
 		return nil, fmt.Errorf("failed to get pod events: %v", err)
 	}
 
-	logs := map[string]string{}
+	return &pods.Pod{
+		Pod:    *pod,
+		Events: events.Items,
+	}, nil
+
+}
+
+// StreamLogs streams the logs of a container line by line until ctx is cancelled or the stream is closed by the server.
+func (c *K8sServiceImpl) StreamLogs(ctx context.Context, namespace, name, container string) (<-chan string, error) {
 
-	logsCtx, logsCancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer logsCancel()
+	stream, err := c.PodsRepository.StreamLogs(ctx, namespace, name, container, pods.LogsOptions{})
 
-	if len(pod.Status.ContainerStatuses) > 0 {
-		logs, err = c.PodsRepository.TailLogs(logsCtx, pod, pods.LogsOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream logs: %v", err)
 	}
 
+	// Buffered to allow consumers to receive lines in batches.
+	lines := make(chan string, 100)
+
+	go func() {
+		defer close(lines)
+		defer stream.Close()
+
+		scanner := bufio.NewScanner(stream)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return lines, nil
+}
+
+// Exec runs a command in a container until it exits or ctx is cancelled.
+func (c *K8sServiceImpl) Exec(ctx context.Context, namespace, name, container string, options pods.ExecOptions) error {
+
+	err := c.PodsRepository.Exec(ctx, namespace, name, container, options)
+
 	if err != nil {
-		return nil, err
+		// The error is wrapped, so that the exit code of the command can be read by the caller.
+		return fmt.Errorf("failed to exec into container %s: %w", container, err)
 	}
 
-	return &pods.Pod{
-		Pod:    *pod,
-		Events: events.Items,
-		Logs:   logs,
-	}, nil
+	return nil
+}
+
+// PortForward forwards a local port to a pod until the stop channel of the options is closed.
+func (c *K8sServiceImpl) PortForward(namespace, name string, options pods.PortForwardOptions) error {
+
+	err := c.PodsRepository.PortForward(namespace, name, options)
+
+	if err != nil {
+		return fmt.Errorf("failed to forward port %d to %s: %v", options.RemotePort, name, err)
+	}
 
+	return nil
 }
 
 // DeletePod deletes a pod in the current context and namespace.
 func (c *K8sServiceImpl) DeletePod(namespace, name string) (string, error) {
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
 	defer cancel()
 
 	err := c.PodsRepository.Delete(ctx, namespace, name)
@@ -126,3 +472,666 @@ func (c *K8sServiceImpl) DeletePod(namespace, name string) (string, error) {
 
 	return name, nil
 }
+
+// maxConcurrentDeletions limits the number of pods that DeletePods deletes at the same time.
+const maxConcurrentDeletions = 5
+
+// DeletePods deletes several pods, at most maxConcurrentDeletions at a time.
+func (c *K8sServiceImpl) DeletePods(podNames []types.NamespacedName) []pods.DeleteResult {
+	results := make([]pods.DeleteResult, len(podNames))
+
+	errGroup := &errgroup.Group{}
+	errGroup.SetLimit(maxConcurrentDeletions)
+
+	for i, podName := range podNames {
+		errGroup.Go(func() error {
+			_, err := c.DeletePod(podName.Namespace, podName.Name)
+
+			// Each goroutine writes to its own index, so no locking is needed.
+			results[i] = pods.DeleteResult{Namespace: podName.Namespace, Name: podName.Name, Err: err}
+
+			// The error is part of the result, returning it would not stop the other deletions anyway.
+			return nil
+		})
+	}
+
+	_ = errGroup.Wait()
+
+	return results
+}
+
+// UpdatePod replaces a pod in the current context and namespace.
+func (c *K8sServiceImpl) UpdatePod(namespace string, pod *v1.Pod) (string, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := c.PodsRepository.Update(ctx, namespace, pod)
+
+	if err != nil {
+		return "", updateError("pod", err)
+	}
+
+	return pod.Name, nil
+}
+
+// ListDeployments fetches all deployments for the current context and namespace.
+func (c *K8sServiceImpl) ListDeployments(namespace string) (*appsv1.DeploymentList, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	deploymentList, err := c.DeploymentsRepository.List(ctx, namespace)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %v", err)
+	}
+
+	return deploymentList, nil
+}
+
+// GetDeployment fetches a deployment in the current context and namespace.
+func (c *K8sServiceImpl) GetDeployment(namespace, name string) (*deployments.Deployment, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	deployment, err := c.DeploymentsRepository.Get(ctx, namespace, name)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment: %v", err)
+	}
+
+	eventsCtx, eventsCancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer eventsCancel()
+
+	events, err := c.DeploymentsRepository.Events(eventsCtx, namespace, name)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment events: %v", err)
+	}
+
+	return &deployments.Deployment{
+		Deployment: *deployment,
+		Events:     events.Items,
+	}, nil
+}
+
+// ListDeploymentPods fetches the pods matching the selector of a deployment in the current context and namespace.
+func (c *K8sServiceImpl) ListDeploymentPods(namespace, name string) (*v1.PodList, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	deployment, err := c.DeploymentsRepository.Get(ctx, namespace, name)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment: %v", err)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector for deployment %s: %v", name, err)
+	}
+
+	podList, err := c.PodsRepository.List(ctx, namespace, pods.ListOptions{LabelSelector: selector.String()})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods for deployment: %v", err)
+	}
+
+	return podList, nil
+}
+
+// UpdateDeployment replaces a deployment in the current context and namespace.
+func (c *K8sServiceImpl) UpdateDeployment(namespace string, deployment *appsv1.Deployment) (string, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := c.DeploymentsRepository.Update(ctx, namespace, deployment)
+
+	if err != nil {
+		return "", updateError("deployment", err)
+	}
+
+	return deployment.Name, nil
+}
+
+// ScaleDeployment sets the number of replicas of a deployment in the current context and namespace.
+func (c *K8sServiceImpl) ScaleDeployment(namespace, name string, replicas int32) (string, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := c.DeploymentsRepository.Patch(ctx, namespace, name, types.MergePatchType, scalePatch(replicas))
+
+	if err != nil {
+		return "", fmt.Errorf("failed to scale deployment: %v", err)
+	}
+
+	return name, nil
+}
+
+// RestartDeployment triggers a rolling restart of a deployment in the current context and namespace.
+func (c *K8sServiceImpl) RestartDeployment(namespace, name string) (string, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := c.DeploymentsRepository.Patch(ctx, namespace, name, types.StrategicMergePatchType, restartPatch(time.Now()))
+
+	if err != nil {
+		return "", fmt.Errorf("failed to restart deployment: %v", err)
+	}
+
+	return name, nil
+}
+
+// ListStatefulSets fetches all statefulsets for the current context and namespace.
+func (c *K8sServiceImpl) ListStatefulSets(namespace string) (*appsv1.StatefulSetList, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	statefulSetList, err := c.StatefulSetsRepository.List(ctx, namespace)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %v", err)
+	}
+
+	return statefulSetList, nil
+}
+
+// ScaleStatefulSet sets the number of replicas of a statefulset in the current context and namespace.
+func (c *K8sServiceImpl) ScaleStatefulSet(namespace, name string, replicas int32) (string, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := c.StatefulSetsRepository.Patch(ctx, namespace, name, types.MergePatchType, scalePatch(replicas))
+
+	if err != nil {
+		return "", fmt.Errorf("failed to scale statefulset: %v", err)
+	}
+
+	return name, nil
+}
+
+// RestartStatefulSet triggers a rolling restart of a statefulset in the current context and namespace.
+func (c *K8sServiceImpl) RestartStatefulSet(namespace, name string) (string, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := c.StatefulSetsRepository.Patch(ctx, namespace, name, types.StrategicMergePatchType, restartPatch(time.Now()))
+
+	if err != nil {
+		return "", fmt.Errorf("failed to restart statefulset: %v", err)
+	}
+
+	return name, nil
+}
+
+// RestartedAtAnnotation is the pod template annotation that is set by `kubectl rollout restart`.
+const RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// ListNodes fetches all nodes in the cluster and counts the pods that are not terminated on each of them.
+func (c *K8sServiceImpl) ListNodes() (*nodes.NodeList, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	nodeList, err := c.NodesRepository.List(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %v", err)
+	}
+
+	// Pods that have terminated no longer use the resources of their node, so they are not counted, same as in `kubectl describe node`.
+	podList, err := c.PodsRepository.List(ctx, "", pods.ListOptions{FieldSelector: "status.phase!=Succeeded,status.phase!=Failed"})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %v", err)
+	}
+
+	podCounts := map[string]int{}
+	for _, pod := range podList.Items {
+		if pod.Spec.NodeName != "" {
+			podCounts[pod.Spec.NodeName]++
+		}
+	}
+
+	return &nodes.NodeList{
+		Items:     nodeList.Items,
+		PodCounts: podCounts,
+	}, nil
+}
+
+// GetNode fetches a single node including its events.
+func (c *K8sServiceImpl) GetNode(name string) (*nodes.Node, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	node, err := c.NodesRepository.Get(ctx, name)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node: %v", err)
+	}
+
+	events, err := c.NodesRepository.Events(ctx, name)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node events: %v", err)
+	}
+
+	return &nodes.Node{
+		Node:   *node,
+		Events: events.Items,
+	}, nil
+}
+
+// ListNodePods fetches the pods scheduled on a node, matched by the node name of the pods.
+func (c *K8sServiceImpl) ListNodePods(name string) (*v1.PodList, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	podList, err := c.PodsRepository.List(ctx, "", pods.ListOptions{FieldSelector: fmt.Sprintf("spec.nodeName=%s", name)})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods for node: %v", err)
+	}
+
+	return podList, nil
+}
+
+// CordonNode sets whether a node is unschedulable, equivalent to `kubectl cordon` and `kubectl uncordon`.
+func (c *K8sServiceImpl) CordonNode(name string, unschedulable bool) (string, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := c.NodesRepository.Patch(ctx, name, types.MergePatchType, cordonPatch(unschedulable))
+
+	if err != nil {
+		return "", fmt.Errorf("failed to cordon node: %v", err)
+	}
+
+	return name, nil
+}
+
+const (
+	// drainRetryInterval is how long to wait before retrying an eviction that was blocked by a PodDisruptionBudget.
+	drainRetryInterval = 5 * time.Second
+	// drainPollInterval is how often an evicted pod is checked to see if it has terminated.
+	drainPollInterval = time.Second
+)
+
+// DrainNode cordons a node and evicts all pods on it except DaemonSet pods and mirror pods.
+// The node stays cordoned if the drain is cancelled.
+func (c *K8sServiceImpl) DrainNode(ctx context.Context, name string, options nodes.DrainOptions) (<-chan nodes.DrainEvent, error) {
+
+	if _, err := c.CordonNode(name, true); err != nil {
+		return nil, err
+	}
+
+	podList, err := c.ListNodePods(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := nodes.CheckDrainable(podList.Items, options); err != nil {
+		return nil, fmt.Errorf("failed to drain node: %v", err)
+	}
+
+	// Buffered so that the initial status of every pod can be delivered without blocking the evictions.
+	events := make(chan nodes.DrainEvent, len(podList.Items))
+
+	go func() {
+		defer close(events)
+
+		errGroup := &errgroup.Group{}
+
+		for _, pod := range podList.Items {
+			if reason := nodes.SkipReason(pod); reason != "" {
+				sendDrainEvent(ctx, events, nodes.DrainEvent{Namespace: pod.Namespace, Name: pod.Name, Status: nodes.Skipped, Message: reason})
+				continue
+			}
+
+			errGroup.Go(func() error {
+				c.evictPod(ctx, pod, options, events)
+				return nil
+			})
+		}
+
+		_ = errGroup.Wait()
+	}()
+
+	return events, nil
+}
+
+// evictPod evicts a pod and waits for it to terminate, retrying the eviction while it is blocked by a PodDisruptionBudget.
+func (c *K8sServiceImpl) evictPod(ctx context.Context, pod v1.Pod, options nodes.DrainOptions, events chan<- nodes.DrainEvent) {
+	event := nodes.DrainEvent{Namespace: pod.Namespace, Name: pod.Name}
+
+	for {
+		err := c.PodsRepository.Evict(ctx, pod.Namespace, pod.Name, options.GracePeriodSeconds)
+
+		if err == nil {
+			break
+		}
+
+		switch {
+		case apierrors.IsNotFound(err):
+			event.Status = nodes.Evicted
+			sendDrainEvent(ctx, events, event)
+			return
+		case apierrors.IsTooManyRequests(err):
+			event.Status, event.Message = nodes.BlockedByPDB, err.Error()
+			if !sendDrainEvent(ctx, events, event) || !sleep(ctx, drainRetryInterval) {
+				return
+			}
+		default:
+			event.Status, event.Message = nodes.Failed, fmt.Sprintf("failed to evict pod: %v", err)
+			sendDrainEvent(ctx, events, event)
+			return
+		}
+	}
+
+	event.Status, event.Message = nodes.Waiting, ""
+	if !sendDrainEvent(ctx, events, event) {
+		return
+	}
+
+	for {
+		current, err := c.PodsRepository.Get(ctx, pod.Namespace, pod.Name)
+
+		// A pod with the same name but a different uid has replaced the evicted pod, as happens with statefulsets.
+		if apierrors.IsNotFound(err) || (err == nil && current.UID != pod.UID) {
+			event.Status = nodes.Evicted
+			sendDrainEvent(ctx, events, event)
+			return
+		}
+
+		if err != nil && ctx.Err() == nil {
+			event.Status, event.Message = nodes.Failed, fmt.Sprintf("failed to get pod: %v", err)
+			sendDrainEvent(ctx, events, event)
+			return
+		}
+
+		if !sleep(ctx, drainPollInterval) {
+			return
+		}
+	}
+}
+
+// sendDrainEvent delivers an event unless ctx is cancelled first, returning false if it was cancelled.
+func sendDrainEvent(ctx context.Context, events chan<- nodes.DrainEvent, event nodes.DrainEvent) bool {
+	select {
+	case events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// sleep waits for the given duration unless ctx is cancelled first, returning false if it was cancelled.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// ListConfigMaps fetches a list of configmaps in a namespace.
+func (c *K8sServiceImpl) ListConfigMaps(namespace string) (*v1.ConfigMapList, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	configMapList, err := c.ConfigMapsRepository.List(ctx, namespace)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configmaps: %v", err)
+	}
+
+	return configMapList, nil
+}
+
+// GetConfigMap fetches a single configmap.
+func (c *K8sServiceImpl) GetConfigMap(namespace, name string) (*v1.ConfigMap, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	configMap, err := c.ConfigMapsRepository.Get(ctx, namespace, name)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configmap: %v", err)
+	}
+
+	return configMap, nil
+}
+
+// ListSecrets fetches a list of secrets in a namespace.
+func (c *K8sServiceImpl) ListSecrets(namespace string) (*v1.SecretList, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	secretList, err := c.SecretsRepository.List(ctx, namespace)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %v", err)
+	}
+
+	return secretList, nil
+}
+
+// GetSecret fetches a single secret.
+func (c *K8sServiceImpl) GetSecret(namespace, name string) (*v1.Secret, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	secret, err := c.SecretsRepository.Get(ctx, namespace, name)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret: %v", err)
+	}
+
+	return secret, nil
+}
+
+// ListServices fetches a list of services in a namespace.
+func (c *K8sServiceImpl) ListServices(namespace string) (*v1.ServiceList, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	serviceList, err := c.ServicesRepository.List(ctx, namespace)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %v", err)
+	}
+
+	return serviceList, nil
+}
+
+// GetService fetches a single service along with the endpoint slices backing it and its events.
+func (c *K8sServiceImpl) GetService(namespace, name string) (*services.Service, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	service, err := c.ServicesRepository.Get(ctx, namespace, name)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service: %v", err)
+	}
+
+	endpointSlices, err := c.ServicesRepository.EndpointSlices(ctx, namespace, name)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service endpoint slices: %v", err)
+	}
+
+	events, err := c.ServicesRepository.Events(ctx, namespace, name)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service events: %v", err)
+	}
+
+	return &services.Service{
+		Service:        *service,
+		EndpointSlices: endpointSlices.Items,
+		Events:         events.Items,
+	}, nil
+}
+
+// ListIngressRoutes fetches the ingresses in a namespace and flattens them into routes.
+// The services of the namespace are fetched as well in order to check the backends of the routes.
+// Only the secrets referenced as tls secrets are fetched, in order to check that they exist without reading every secret of the namespace.
+// If the secrets may not be read, then the tls secrets are not checked.
+func (c *K8sServiceImpl) ListIngressRoutes(namespace string) ([]ingresses.Route, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	ingressList, err := c.IngressesRepository.List(ctx, namespace)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ingresses: %v", err)
+	}
+
+	serviceList, err := c.ServicesRepository.List(ctx, namespace)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %v", err)
+	}
+
+	secretNames, err := c.existingSecrets(ctx, namespace, ingresses.TLSSecretNames(ingressList.Items))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ingresses.Routes(ingressList.Items, serviceList.Items, secretNames), nil
+}
+
+// existingSecrets returns the names of the given secrets that exist.
+// It returns nil if the secrets may not be read, since it is then unknown which of them exist.
+func (c *K8sServiceImpl) existingSecrets(ctx context.Context, namespace string, names []string) ([]string, error) {
+	existing := []string{}
+
+	for _, name := range names {
+		_, err := c.SecretsRepository.Get(ctx, namespace, name)
+
+		switch {
+		case apierrors.IsForbidden(err):
+			return nil, nil
+		case apierrors.IsNotFound(err):
+		case err != nil:
+			return nil, fmt.Errorf("failed to get secret %s: %v", name, err)
+		default:
+			existing = append(existing, name)
+		}
+	}
+
+	return existing, nil
+}
+
+// ListResourceTypes fetches the types of resources served by the cluster.
+// Groups that fail discovery, such as those of unavailable aggregated api servers, are left out.
+func (c *K8sServiceImpl) ListResourceTypes() ([]resources.Resource, error) {
+
+	apiResourceLists, err := c.ResourcesRepository.ServerPreferredResources()
+
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("failed to discover resource types: %v", err)
+	}
+
+	resourceTypes, err := resources.NewResources(apiResourceLists)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover resource types: %v", err)
+	}
+
+	return resourceTypes, nil
+}
+
+// ListResources fetches resources of any type as a table.
+// The columns are the ones defined by the api server, including the additional printer columns of custom resources.
+// If the api server can not return a table, then only the name and age of each resource is listed.
+func (c *K8sServiceImpl) ListResources(resource resources.Resource, namespace string) (*resources.Table, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	table, err := c.ResourcesRepository.ListTable(ctx, resource, namespace)
+
+	if err == nil {
+		return resources.NewTable(table)
+	}
+
+	if !errors.Is(err, resources.ErrTableNotSupported) && !apierrors.IsNotAcceptable(err) {
+		return nil, fmt.Errorf("failed to list %s: %v", resource.Name(), err)
+	}
+
+	list, err := c.ResourcesRepository.List(ctx, resource, namespace)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %v", resource.Name(), err)
+	}
+
+	return resources.NewTableFromList(list), nil
+}
+
+// GetResource fetches a single resource of any type.
+func (c *K8sServiceImpl) GetResource(resource resources.Resource, namespace, name string) (*unstructured.Unstructured, error) {
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	object, err := c.ResourcesRepository.Get(ctx, resource, namespace, name)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %v", resource.Name(), err)
+	}
+
+	return object, nil
+}
+
+// cordonPatch creates a merge patch which sets whether a node is unschedulable.
+func cordonPatch(unschedulable bool) []byte {
+	return []byte(fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable))
+}
+
+// scalePatch creates a merge patch which sets the number of replicas of a workload.
+func scalePatch(replicas int32) []byte {
+	return []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas))
+}
+
+// restartPatch creates a strategic merge patch which changes the pod template of a workload, causing all of its pods to be replaced.
+func restartPatch(restartedAt time.Time) []byte {
+	return []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, RestartedAtAnnotation, restartedAt.Format(time.RFC3339)))
+}
+
+// updateError creates the error returned when an update of a resource of the given kind fails.
+// A conflict means that the resource has been changed by someone else since the version that was edited was fetched.
+func updateError(kind string, err error) error {
+	if apierrors.IsConflict(err) {
+		return fmt.Errorf("failed to update %s, it has been modified since it was loaded, refresh and try again: %v", kind, err)
+	}
+
+	return fmt.Errorf("failed to update %s: %v", kind, err)
+}
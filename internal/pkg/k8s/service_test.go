@@ -2,12 +2,34 @@ package k8s_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"kubeui/internal/pkg/k8s"
+	"kubeui/internal/pkg/k8s/nodes"
+	"kubeui/internal/pkg/k8s/pods"
+	"kubeui/internal/pkg/k8s/resources"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/life4/genesis/slices"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/util/exec"
 )
 
 type mockNamespaceRepository struct {
@@ -16,6 +38,10 @@ type mockNamespaceRepository struct {
 }
 
 func (c *mockNamespaceRepository) List(ctx context.Context) (*v1.NamespaceList, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if c.err != nil {
 		return nil, c.err
 	}
@@ -57,7 +83,7 @@ var listNamespacesTests = []listNamespacesTest{
 
 func TestListNamespaces(t *testing.T) {
 	for _, test := range listNamespacesTests {
-		service := k8s.NewK8sService(nil, test.repository)
+		service := k8s.NewK8sService(k8s.Repositories{NamespaceRepository: test.repository})
 		got, err := service.ListNamespaces()
 
 		if test.wantErr {
@@ -72,3 +98,668 @@ func TestListNamespaces(t *testing.T) {
 
 	}
 }
+
+func TestClose(t *testing.T) {
+	service := k8s.NewK8sService(k8s.Repositories{NamespaceRepository: &mockNamespaceRepository{&v1.NamespaceList{}, nil}})
+	service.Close()
+
+	_, err := service.ListNamespaces()
+
+	assert.ErrorContains(t, err, context.Canceled.Error())
+}
+
+func TestNewK8sServiceForConfig(t *testing.T) {
+	config := api.Config{
+		Clusters:       map[string]*api.Cluster{"dev": {Server: "https://dev.example.com:6443"}},
+		AuthInfos:      map[string]*api.AuthInfo{"admin": {Token: "token"}},
+		Contexts:       map[string]*api.Context{"dev": {Cluster: "dev", AuthInfo: "admin"}, "broken": {Cluster: "missing", AuthInfo: "admin"}},
+		CurrentContext: "dev",
+	}
+
+	restConfig, err := k8s.NewRestConfigForConfig(config)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://dev.example.com:6443", restConfig.Host)
+
+	_, err = k8s.NewK8sServiceForConfig(config)
+	assert.NoError(t, err)
+
+	config.CurrentContext = "broken"
+	_, err = k8s.NewK8sServiceForConfig(config)
+	assert.Error(t, err)
+}
+
+func TestServerVersion(t *testing.T) {
+	// Credentials are only sent to servers using tls.
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		fmt.Fprint(w, `{"gitVersion": "v1.32.1"}`)
+	}))
+	defer server.Close()
+
+	config := api.Config{
+		Clusters:       map[string]*api.Cluster{"dev": {Server: server.URL, InsecureSkipTLSVerify: true}, "down": {Server: "http://127.0.0.1:1"}},
+		AuthInfos:      map[string]*api.AuthInfo{"admin": {Token: "token"}, "other": {Token: "wrong"}},
+		Contexts:       map[string]*api.Context{"dev": {Cluster: "dev", AuthInfo: "admin"}, "unauthorized": {Cluster: "dev", AuthInfo: "other"}, "down": {Cluster: "down", AuthInfo: "admin"}},
+		CurrentContext: "dev",
+	}
+
+	tests := []struct {
+		context string
+		wantErr bool
+	}{
+		{"dev", false},
+		{"unauthorized", true},
+		{"down", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.context, func(t *testing.T) {
+			config.CurrentContext = tt.context
+			service, err := k8s.NewK8sServiceForConfig(config)
+			assert.NoError(t, err)
+
+			info, err := service.ServerVersion()
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, "v1.32.1", info.GitVersion)
+			}
+		})
+	}
+}
+
+func TestWatchPods(t *testing.T) {
+
+	clientSet := fake.NewClientset()
+	service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := service.WatchPods(ctx, "default", "")
+	assert.Nil(t, err)
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+
+	_, err = clientSet.CoreV1().Pods("default").Create(ctx, pod, metav1.CreateOptions{})
+	assert.Nil(t, err)
+
+	event := <-events
+	assert.Equal(t, pods.Added, event.Type)
+	assert.Equal(t, "test", event.Pod.Name)
+
+	pod.Status.Phase = v1.PodRunning
+	_, err = clientSet.CoreV1().Pods("default").UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+	assert.Nil(t, err)
+
+	event = <-events
+	assert.Equal(t, pods.Modified, event.Type)
+	assert.Equal(t, v1.PodRunning, event.Pod.Status.Phase)
+
+	err = clientSet.CoreV1().Pods("default").Delete(ctx, "test", metav1.DeleteOptions{})
+	assert.Nil(t, err)
+
+	event = <-events
+	assert.Equal(t, pods.Deleted, event.Type)
+	assert.Equal(t, "test", event.Pod.Name)
+
+	// Cancelling the context should close the events channel.
+	cancel()
+
+	_, ok := <-events
+	assert.False(t, ok)
+}
+
+func TestListAndWatchPods(t *testing.T) {
+
+	clientSet := fake.NewClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "web"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: "db"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other-1", Namespace: "other"}},
+	)
+	service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	podList, events, err := service.ListAndWatchPods(ctx, []string{"web", "db"}, pods.ListOptions{})
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"web-1", "db-1"}, slices.Map(podList.Items, func(p v1.Pod) string { return p.Name }))
+
+	// Events are delivered from the watches of all namespaces, but not from other namespaces.
+	_, err = clientSet.CoreV1().Pods("other").Create(ctx, &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other-2", Namespace: "other"}}, metav1.CreateOptions{})
+	assert.Nil(t, err)
+
+	_, err = clientSet.CoreV1().Pods("db").Create(ctx, &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-2", Namespace: "db"}}, metav1.CreateOptions{})
+	assert.Nil(t, err)
+
+	event := <-events
+	assert.Equal(t, pods.Added, event.Type)
+	assert.Equal(t, "db-2", event.Pod.Name)
+
+	// Cancelling the context should close the events channel.
+	cancel()
+
+	for range events {
+	}
+
+	// An empty namespace lists the pods in all namespaces.
+	allCtx, allCancel := context.WithCancel(context.Background())
+	defer allCancel()
+
+	allPods, _, err := service.ListAndWatchPods(allCtx, []string{""}, pods.ListOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, allPods.Items, 5)
+
+	// Only pods matching the selectors are listed.
+	_, err = clientSet.CoreV1().Pods("web").Create(allCtx, &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "web", Labels: map[string]string{"app": "web"}}}, metav1.CreateOptions{})
+	assert.Nil(t, err)
+
+	selectedPods, _, err := service.ListAndWatchPods(allCtx, []string{"web"}, pods.ListOptions{LabelSelector: "app=web"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"web-2"}, slices.Map(selectedPods.Items, func(p v1.Pod) string { return p.Name }))
+}
+
+func TestStreamLogs(t *testing.T) {
+
+	clientSet := fake.NewClientset()
+	service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+	lines, err := service.StreamLogs(context.Background(), "default", "test", "container")
+	assert.Nil(t, err)
+
+	// The fake clientset always responds with a single line of logs.
+	got := []string{}
+	for line := range lines {
+		got = append(got, line)
+	}
+
+	assert.Equal(t, []string{"fake logs"}, got)
+}
+
+// mockExecPodsRepository is a pods repository where exec returns err.
+type mockExecPodsRepository struct {
+	pods.Repository
+	err error
+}
+
+func (r *mockExecPodsRepository) Exec(ctx context.Context, namespace, name, container string, options pods.ExecOptions) error {
+	return r.err
+}
+
+func TestExec(t *testing.T) {
+	service := k8s.NewK8sService(k8s.Repositories{PodsRepository: &mockExecPodsRepository{err: exec.CodeExitError{Err: fmt.Errorf("command terminated with exit code 1"), Code: 1}}})
+
+	err := service.Exec(context.Background(), "default", "web", "c", pods.ExecOptions{})
+
+	// The exit code of the command can still be read from the error.
+	var codeExitErr exec.CodeExitError
+	assert.True(t, errors.As(err, &codeExitErr))
+	assert.Equal(t, 1, codeExitErr.Code)
+}
+
+func TestListDeploymentPods(t *testing.T) {
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+	}
+
+	clientSet := fake.NewClientset(
+		deployment,
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "other", Labels: map[string]string{"app": "web"}}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: "default", Labels: map[string]string{"app": "db"}}},
+	)
+
+	service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+	got, err := service.ListDeploymentPods("default", "web")
+	assert.Nil(t, err)
+	assert.Len(t, got.Items, 1)
+	assert.Equal(t, "web-1", got.Items[0].Name)
+
+	_, err = service.ListDeploymentPods("default", "not-there")
+	assert.Error(t, err)
+}
+
+func TestScaleAndRestartDeployment(t *testing.T) {
+
+	clientSet := fake.NewClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+	)
+
+	service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+	name, err := service.ScaleDeployment("default", "web", 3)
+	assert.Nil(t, err)
+	assert.Equal(t, "web", name)
+
+	name, err = service.RestartDeployment("default", "web")
+	assert.Nil(t, err)
+	assert.Equal(t, "web", name)
+
+	got, err := clientSet.AppsV1().Deployments("default").Get(context.Background(), "web", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), *got.Spec.Replicas)
+	assert.NotEmpty(t, got.Spec.Template.Annotations[k8s.RestartedAtAnnotation])
+
+	_, err = service.ScaleDeployment("default", "not-there", 1)
+	assert.Error(t, err)
+
+	_, err = service.RestartDeployment("default", "not-there")
+	assert.Error(t, err)
+}
+
+func TestScaleAndRestartStatefulSet(t *testing.T) {
+
+	clientSet := fake.NewClientset(
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}},
+	)
+
+	service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+	name, err := service.ScaleStatefulSet("default", "db", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "db", name)
+
+	name, err = service.RestartStatefulSet("default", "db")
+	assert.Nil(t, err)
+	assert.Equal(t, "db", name)
+
+	got, err := clientSet.AppsV1().StatefulSets("default").Get(context.Background(), "db", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), *got.Spec.Replicas)
+	assert.NotEmpty(t, got.Spec.Template.Annotations[k8s.RestartedAtAnnotation])
+
+	_, err = service.ScaleStatefulSet("default", "not-there", 1)
+	assert.Error(t, err)
+}
+
+func TestExecWithoutRestConfig(t *testing.T) {
+
+	clientSet := fake.NewClientset()
+
+	service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+	err := service.Exec(context.Background(), "default", "web-1", "web", pods.ExecOptions{Command: []string{"sh"}})
+	assert.Error(t, err)
+}
+
+func TestUpdateDeployment(t *testing.T) {
+
+	clientSet := fake.NewClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+	)
+
+	service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+	edited := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}}}
+
+	name, err := service.UpdateDeployment("default", edited)
+	assert.Nil(t, err)
+	assert.Equal(t, "web", name)
+
+	got, err := clientSet.AppsV1().Deployments("default").Get(context.Background(), "web", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "web", got.Labels["app"])
+
+	// The fake clientset does not check resource versions, so the conflict is simulated.
+	clientSet.PrependReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "web", fmt.Errorf("object has been modified"))
+	})
+
+	_, err = service.UpdateDeployment("default", edited)
+	assert.ErrorContains(t, err, "modified since it was loaded")
+}
+
+func TestUpdatePod(t *testing.T) {
+
+	clientSet := fake.NewClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}},
+	)
+
+	service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+	name, err := service.UpdatePod("default", &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Annotations: map[string]string{"note": "edited"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, "web-1", name)
+
+	got, err := clientSet.CoreV1().Pods("default").Get(context.Background(), "web-1", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "edited", got.Annotations["note"])
+
+	_, err = service.UpdatePod("default", &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "not-there", Namespace: "default"}})
+	assert.Error(t, err)
+}
+
+func TestDeletePods(t *testing.T) {
+
+	clientSet := fake.NewClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: "database"}},
+	)
+
+	service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+	podNames := []types.NamespacedName{
+		{Namespace: "default", Name: "web-1"},
+		{Namespace: "default", Name: "not-there"},
+		{Namespace: "database", Name: "db-1"},
+	}
+
+	results := service.DeletePods(podNames)
+
+	// A failed deletion does not stop the others and the results are in the order of the pods.
+	assert.Len(t, results, 3)
+	for i, result := range results {
+		assert.Equal(t, podNames[i].Namespace, result.Namespace)
+		assert.Equal(t, podNames[i].Name, result.Name)
+	}
+	assert.Nil(t, results[0].Err)
+	assert.Error(t, results[1].Err)
+	assert.Nil(t, results[2].Err)
+
+	remaining, err := service.ListPods("")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"web-2"}, slices.Map(remaining.Items, func(p v1.Pod) string { return p.Name }))
+}
+
+func TestListNodes(t *testing.T) {
+
+	clientSet := fake.NewClientset(
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}, Spec: v1.PodSpec{NodeName: "node-1"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: "database"}, Spec: v1.PodSpec{NodeName: "node-1"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pending-1", Namespace: "default"}},
+	)
+
+	service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+	got, err := service.ListNodes()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"node-1", "node-2"}, slices.Map(got.Items, func(n v1.Node) string { return n.Name }))
+
+	// Pods that are not scheduled on a node are not counted.
+	assert.Equal(t, map[string]int{"node-1": 2}, got.PodCounts)
+}
+
+func TestGetNode(t *testing.T) {
+
+	clientSet := fake.NewClientset(
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		&v1.Event{ObjectMeta: metav1.ObjectMeta{Name: "node-1.event", Namespace: "default"}, InvolvedObject: v1.ObjectReference{Kind: "Node", Name: "node-1"}},
+	)
+
+	service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+	got, err := service.GetNode("node-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "node-1", got.Node.Name)
+	assert.Len(t, got.Events, 1)
+
+	_, err = service.GetNode("not-there")
+	assert.Error(t, err)
+}
+
+func TestListNodePods(t *testing.T) {
+
+	clientSet := fake.NewClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}, Spec: v1.PodSpec{NodeName: "node-1"}},
+	)
+
+	// The fake clientset ignores field selectors, so the selector used is recorded instead.
+	var fieldSelector string
+	clientSet.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		fieldSelector = action.(k8stesting.ListAction).GetListRestrictions().Fields.String()
+		return false, nil, nil
+	})
+
+	service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+	got, err := service.ListNodePods("node-1")
+	assert.Nil(t, err)
+	assert.Len(t, got.Items, 1)
+	assert.Equal(t, "spec.nodeName=node-1", fieldSelector)
+}
+
+func TestCordonNode(t *testing.T) {
+
+	clientSet := fake.NewClientset(
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+	)
+
+	service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+	for _, unschedulable := range []bool{true, false} {
+		name, err := service.CordonNode("node-1", unschedulable)
+		assert.Nil(t, err)
+		assert.Equal(t, "node-1", name)
+
+		got, err := clientSet.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, unschedulable, got.Spec.Unschedulable)
+	}
+
+	_, err := service.CordonNode("not-there", true)
+	assert.Error(t, err)
+}
+
+func TestDrainNode(t *testing.T) {
+
+	daemonSet := metav1.OwnerReference{Kind: "DaemonSet", Name: "logging", Controller: &[]bool{true}[0]}
+	emptyDir := v1.Volume{Name: "cache", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}
+
+	newClientSet := func() *fake.Clientset {
+		clientSet := fake.NewClientset(
+			&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}, Spec: v1.PodSpec{NodeName: "node-1", Volumes: []v1.Volume{emptyDir}}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "logging-1", Namespace: "kube-system", OwnerReferences: []metav1.OwnerReference{daemonSet}}, Spec: v1.PodSpec{NodeName: "node-1"}},
+		)
+
+		// The fake clientset does not implement evictions, so they are simulated by deleting the pod.
+		clientSet.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() != "eviction" {
+				return false, nil, nil
+			}
+
+			eviction := action.(k8stesting.CreateAction).GetObject().(metav1.Object)
+			return true, nil, clientSet.Tracker().Delete(schema.GroupVersionResource{Version: "v1", Resource: "pods"}, eviction.GetNamespace(), eviction.GetName())
+		})
+
+		return clientSet
+	}
+
+	t.Run("Pods with emptyDir data are only evicted if allowed", func(t *testing.T) {
+		service := k8s.NewK8sService(k8s.NewRepositories(newClientSet(), nil))
+
+		_, err := service.DrainNode(context.Background(), "node-1", nodes.DrainOptions{})
+		assert.ErrorContains(t, err, "default/web-1")
+	})
+
+	t.Run("Evicts pods and skips DaemonSet pods", func(t *testing.T) {
+		clientSet := newClientSet()
+		service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+		events, err := service.DrainNode(context.Background(), "node-1", nodes.DrainOptions{DeleteEmptyDirData: true})
+		assert.Nil(t, err)
+
+		statuses := map[string][]nodes.DrainStatus{}
+		for event := range events {
+			statuses[event.Name] = append(statuses[event.Name], event.Status)
+		}
+
+		assert.Equal(t, map[string][]nodes.DrainStatus{
+			"web-1":     {nodes.Waiting, nodes.Evicted},
+			"logging-1": {nodes.Skipped},
+		}, statuses)
+
+		node, err := clientSet.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+		assert.Nil(t, err)
+		assert.True(t, node.Spec.Unschedulable)
+	})
+
+	t.Run("Evictions blocked by a PodDisruptionBudget are retried until cancelled", func(t *testing.T) {
+		clientSet := newClientSet()
+		clientSet.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		})
+
+		service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := service.DrainNode(ctx, "node-1", nodes.DrainOptions{DeleteEmptyDirData: true})
+		assert.Nil(t, err)
+
+		for event := range events {
+			if event.Name == "web-1" {
+				assert.Equal(t, nodes.BlockedByPDB, event.Status)
+				assert.Contains(t, event.Message, "disruption budget")
+				cancel()
+			}
+		}
+
+		_, err = clientSet.CoreV1().Pods("default").Get(context.Background(), "web-1", metav1.GetOptions{})
+		assert.Nil(t, err)
+	})
+}
+
+func TestListAndGetSecrets(t *testing.T) {
+
+	clientSet := fake.NewClientset(
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret-1", Namespace: "default"}, Data: map[string][]byte{"password": []byte("hunter2")}},
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret-2", Namespace: "other"}},
+	)
+
+	service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+	list, err := service.ListSecrets("default")
+	assert.Nil(t, err)
+	assert.Len(t, list.Items, 1)
+
+	got, err := service.GetSecret("default", "secret-1")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("hunter2"), got.Data["password"])
+
+	_, err = service.GetSecret("default", "secret-2")
+	assert.Error(t, err)
+}
+
+func TestListAndGetResources(t *testing.T) {
+
+	clientSet := fake.NewClientset()
+	clientSet.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "example.com/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "widgets", Kind: "Widget", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+			},
+		},
+	}
+
+	widget := &unstructured.Unstructured{}
+	widget.SetAPIVersion("example.com/v1")
+	widget.SetKind("Widget")
+	widget.SetName("widget-1")
+	widget.SetNamespace("default")
+
+	widgets := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{widgets: "WidgetList"}, widget)
+
+	repositories := k8s.NewRepositories(clientSet, nil)
+	repositories.ResourcesRepository = resources.NewRepository(clientSet.Discovery(), dynamicClient)
+	service := k8s.NewK8sService(repositories)
+
+	resourceTypes, err := service.ListResourceTypes()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"widgets.example.com"}, slices.Map(resourceTypes, func(r resources.Resource) string { return r.Name() }))
+
+	// The fake clients can not return tables, so only the name and age are listed.
+	table, err := service.ListResources(resourceTypes[0], "default")
+	assert.Nil(t, err)
+	assert.Len(t, table.Columns, 2)
+	assert.Equal(t, []string{"widget-1"}, slices.Map(table.Rows, func(r resources.TableRow) string { return r.Name }))
+
+	table, err = service.ListResources(resourceTypes[0], "other")
+	assert.Nil(t, err)
+	assert.Empty(t, table.Rows)
+
+	got, err := service.GetResource(resourceTypes[0], "default", "widget-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "Widget", got.GetKind())
+
+	_, err = service.GetResource(resourceTypes[0], "default", "not-there")
+	assert.Error(t, err)
+}
+
+func TestGetService(t *testing.T) {
+
+	clientSet := fake.NewClientset(
+		&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		&discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Name: "web-abc", Namespace: "default", Labels: map[string]string{discoveryv1.LabelServiceName: "web"}}},
+		&discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Name: "api-abc", Namespace: "default", Labels: map[string]string{discoveryv1.LabelServiceName: "api"}}},
+	)
+
+	service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+	got, err := service.GetService("default", "web")
+	assert.Nil(t, err)
+	assert.Equal(t, "web", got.Service.Name)
+	assert.Equal(t, []string{"web-abc"}, slices.Map(got.EndpointSlices, func(e discoveryv1.EndpointSlice) string { return e.Name }))
+
+	_, err = service.GetService("default", "not-there")
+	assert.Error(t, err)
+}
+
+func TestListIngressRoutes(t *testing.T) {
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{SecretName: "missing-tls"}},
+			DefaultBackend: &networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{Name: "web", Port: networkingv1.ServiceBackendPort{Number: 80}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		forbidSecret bool
+		want         []string
+	}{
+		{"should report missing tls secrets", false, []string{"tls secret missing-tls not found"}},
+		{"should not check secrets that may not be read", true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientSet := fake.NewClientset(ingress, &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}, Spec: v1.ServiceSpec{Ports: []v1.ServicePort{{Port: 80}}}})
+
+			if tt.forbidSecret {
+				clientSet.PrependReactor("get", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "", fmt.Errorf("forbidden"))
+				})
+			}
+
+			service := k8s.NewK8sService(k8s.NewRepositories(clientSet, nil))
+
+			got, err := service.ListIngressRoutes("default")
+			assert.Nil(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, "web:80", got[0].Backend)
+			assert.Equal(t, tt.want, got[0].Problems)
+
+			// Only the referenced secrets are fetched, the secrets of the namespace are never listed.
+			for _, action := range clientSet.Actions() {
+				assert.False(t, action.Matches("list", "secrets"))
+			}
+		})
+	}
+}
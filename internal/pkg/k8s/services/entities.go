@@ -0,0 +1,143 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/life4/genesis/slices"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+)
+
+// Service contains extended information about a kubernetes service.
+type Service struct {
+	Service        v1.Service
+	EndpointSlices []discoveryv1.EndpointSlice
+	Events         []v1.Event
+}
+
+// Endpoint is a single endpoint of an endpoint slice backing a service.
+type Endpoint struct {
+	// Name of the endpoint slice that the endpoint belongs to.
+	Slice     string
+	Addresses []string
+	// Ports of the endpoint slice, formatted as port/protocol.
+	Ports       []string
+	Ready       bool
+	Terminating bool
+	// Name and namespace of the pod backing the endpoint, empty if the endpoint is not backed by a pod.
+	PodName      string
+	PodNamespace string
+	NodeName     string
+}
+
+// Status describes the state of the endpoint, the same way as the ready and terminating conditions are combined by `kubectl describe`.
+func (e Endpoint) Status() string {
+	switch {
+	case e.Terminating:
+		return "Terminating"
+	case e.Ready:
+		return "Ready"
+	}
+
+	return "NotReady"
+}
+
+// Endpoints returns the endpoints of all endpoint slices, in the order of the slices.
+// Endpoints without a ready condition are considered ready, since the condition is unknown.
+func Endpoints(endpointSlices []discoveryv1.EndpointSlice) []Endpoint {
+	endpoints := []Endpoint{}
+
+	for _, endpointSlice := range endpointSlices {
+		ports := slices.Map(endpointSlice.Ports, func(p discoveryv1.EndpointPort) string {
+			return formatEndpointPort(p)
+		})
+
+		for _, e := range endpointSlice.Endpoints {
+			endpoint := Endpoint{
+				Slice:       endpointSlice.Name,
+				Addresses:   e.Addresses,
+				Ports:       ports,
+				Ready:       e.Conditions.Ready == nil || *e.Conditions.Ready,
+				Terminating: e.Conditions.Terminating != nil && *e.Conditions.Terminating,
+			}
+
+			if e.TargetRef != nil && e.TargetRef.Kind == "Pod" {
+				endpoint.PodName = e.TargetRef.Name
+				endpoint.PodNamespace = e.TargetRef.Namespace
+			}
+
+			if e.NodeName != nil {
+				endpoint.NodeName = *e.NodeName
+			}
+
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	return endpoints
+}
+
+// formatEndpointPort formats a port of an endpoint slice as port/protocol, prefixed by the name of the port if it has one.
+func formatEndpointPort(port discoveryv1.EndpointPort) string {
+	builder := strings.Builder{}
+
+	if port.Name != nil && *port.Name != "" {
+		builder.WriteString(*port.Name + ":")
+	}
+
+	if port.Port != nil {
+		builder.WriteString(fmt.Sprintf("%d", *port.Port))
+	}
+
+	protocol := v1.ProtocolTCP
+	if port.Protocol != nil {
+		protocol = *port.Protocol
+	}
+
+	builder.WriteString("/" + string(protocol))
+
+	return builder.String()
+}
+
+// ForwardTarget is a pod backing a service, which is connected to when forwarding a port of the service.
+type ForwardTarget struct {
+	Namespace string
+	Pod       string
+	// Port of the pod that the service port targets.
+	Port int
+}
+
+// FindForwardTarget returns a ready pod backing a port of a service.
+// The port of the pod is taken from the endpoint slices, where named target ports have already been resolved to numbers.
+func FindForwardTarget(service Service, port int) (ForwardTarget, error) {
+	servicePort, err := slices.Find(service.Service.Spec.Ports, func(p v1.ServicePort) bool { return int(p.Port) == port })
+	if err != nil {
+		return ForwardTarget{}, fmt.Errorf("service %s has no port %d", service.Service.Name, port)
+	}
+
+	for _, endpointSlice := range service.EndpointSlices {
+		endpointPort, err := slices.Find(endpointSlice.Ports, func(p discoveryv1.EndpointPort) bool {
+			return p.Port != nil && (p.Name == nil && servicePort.Name == "" || p.Name != nil && *p.Name == servicePort.Name)
+		})
+		if err != nil {
+			continue
+		}
+
+		for _, e := range endpointSlice.Endpoints {
+			ready := e.Conditions.Ready == nil || *e.Conditions.Ready
+			if !ready || e.TargetRef == nil || e.TargetRef.Kind != "Pod" {
+				continue
+			}
+
+			namespace := e.TargetRef.Namespace
+			if namespace == "" {
+				namespace = service.Service.Namespace
+			}
+
+			return ForwardTarget{Namespace: namespace, Pod: e.TargetRef.Name, Port: int(*endpointPort.Port)}, nil
+		}
+	}
+
+	return ForwardTarget{}, fmt.Errorf("no ready pod backs port %d of service %s", port, service.Service.Name)
+}
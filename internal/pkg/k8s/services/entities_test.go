@@ -0,0 +1,97 @@
+package services_test
+
+import (
+	"testing"
+
+	"kubeui/internal/pkg/k8s/services"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestEndpoints(t *testing.T) {
+	endpointSlices := []discoveryv1.EndpointSlice{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-abc"},
+			Ports:      []discoveryv1.EndpointPort{{Name: ptr.To("http"), Port: ptr.To[int32](8080)}, {Port: ptr.To[int32](53), Protocol: ptr.To(v1.ProtocolUDP)}},
+			Endpoints: []discoveryv1.Endpoint{
+				{
+					Addresses:  []string{"10.0.0.1"},
+					Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+					TargetRef:  &v1.ObjectReference{Kind: "Pod", Name: "web-1", Namespace: "default"},
+					NodeName:   ptr.To("node-1"),
+				},
+				{
+					Addresses:  []string{"10.0.0.2"},
+					Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)},
+					TargetRef:  &v1.ObjectReference{Kind: "Pod", Name: "web-2", Namespace: "default"},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-def"},
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"192.168.0.1"}},
+				{Addresses: []string{"10.0.0.3"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false), Terminating: ptr.To(true)}},
+			},
+		},
+	}
+
+	got := services.Endpoints(endpointSlices)
+
+	assert.Equal(t, []services.Endpoint{
+		{Slice: "web-abc", Addresses: []string{"10.0.0.1"}, Ports: []string{"http:8080/TCP", "53/UDP"}, Ready: true, PodName: "web-1", PodNamespace: "default", NodeName: "node-1"},
+		{Slice: "web-abc", Addresses: []string{"10.0.0.2"}, Ports: []string{"http:8080/TCP", "53/UDP"}, PodName: "web-2", PodNamespace: "default"},
+		{Slice: "web-def", Addresses: []string{"192.168.0.1"}, Ports: []string{}, Ready: true},
+		{Slice: "web-def", Addresses: []string{"10.0.0.3"}, Ports: []string{}, Terminating: true},
+	}, got)
+
+	assert.Equal(t, []string{"Ready", "NotReady", "Ready", "Terminating"}, []string{got[0].Status(), got[1].Status(), got[2].Status(), got[3].Status()})
+}
+
+func TestFindForwardTarget(t *testing.T) {
+	service := services.Service{
+		Service: v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: v1.ServiceSpec{Ports: []v1.ServicePort{
+				{Name: "http", Port: 80},
+				{Name: "metrics", Port: 9090},
+			}},
+		},
+		EndpointSlices: []discoveryv1.EndpointSlice{
+			{
+				Ports: []discoveryv1.EndpointPort{{Name: ptr.To("http"), Port: ptr.To[int32](8080)}, {Name: ptr.To("metrics"), Port: ptr.To[int32](9100)}},
+				Endpoints: []discoveryv1.Endpoint{
+					{Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)}, TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-1", Namespace: "default"}},
+					{TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-2"}},
+				},
+			},
+		},
+	}
+
+	unbacked := services.Service{Service: service.Service}
+
+	tests := []struct {
+		name    string
+		service services.Service
+		port    int
+		want    services.ForwardTarget
+		wantErr bool
+	}{
+		{"Should use the first ready pod and the resolved target port", service, 80, services.ForwardTarget{Namespace: "default", Pod: "web-2", Port: 8080}, false},
+		{"Should match the endpoint port by name", service, 9090, services.ForwardTarget{Namespace: "default", Pod: "web-2", Port: 9100}, false},
+		{"Should return an error for an unknown port", service, 443, services.ForwardTarget{}, true},
+		{"Should return an error if no pod backs the service", unbacked, 80, services.ForwardTarget{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := services.FindForwardTarget(tt.service, tt.port)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	typeddiscoveryv1 "k8s.io/client-go/kubernetes/typed/discovery/v1"
+)
+
+// Repository defines the interface for the services repository.
+type Repository interface {
+	Get(ctx context.Context, namespace, name string) (*v1.Service, error)
+	List(ctx context.Context, namespace string) (*v1.ServiceList, error)
+	EndpointSlices(ctx context.Context, namespace, name string) (*discoveryv1.EndpointSliceList, error)
+	Events(ctx context.Context, namespace, name string) (*v1.EventList, error)
+}
+
+// NewRepository creates a new Repository.
+func NewRepository(kubectl corev1.CoreV1Interface, discovery typeddiscoveryv1.DiscoveryV1Interface) Repository {
+	return &RepositoryImpl{
+		kubectl:   kubectl,
+		discovery: discovery,
+	}
+}
+
+// RepositoryImpl is used to fetch service related data from kubernetes.
+type RepositoryImpl struct {
+	kubectl   corev1.CoreV1Interface
+	discovery typeddiscoveryv1.DiscoveryV1Interface
+}
+
+// Get fetches a single service.
+func (c *RepositoryImpl) Get(ctx context.Context, namespace, name string) (*v1.Service, error) {
+	return c.kubectl.Services(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// List fetches all services in a namespace.
+func (c *RepositoryImpl) List(ctx context.Context, namespace string) (*v1.ServiceList, error) {
+	return c.kubectl.Services(namespace).List(ctx, metav1.ListOptions{})
+}
+
+// EndpointSlices fetches the endpoint slices backing a service, which are labeled with the name of the service.
+func (c *RepositoryImpl) EndpointSlices(ctx context.Context, namespace, name string) (*discoveryv1.EndpointSliceList, error) {
+	return c.discovery.EndpointSlices(namespace).List(ctx, metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", discoveryv1.LabelServiceName, name)})
+}
+
+// Events fetches the current events for a service.
+func (c *RepositoryImpl) Events(ctx context.Context, namespace, name string) (*v1.EventList, error) {
+	return c.kubectl.Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: fmt.Sprintf("involvedObject.name=%s,involvedObject.kind=Service", name)})
+}
@@ -0,0 +1,44 @@
+package statefulsets
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	typedappsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+)
+
+// Repository defines the interface for the statefulsets repository.
+type Repository interface {
+	Get(ctx context.Context, namespace, name string) (*appsv1.StatefulSet, error)
+	List(ctx context.Context, namespace string) (*appsv1.StatefulSetList, error)
+	Patch(ctx context.Context, namespace, name string, patchType types.PatchType, data []byte) (*appsv1.StatefulSet, error)
+}
+
+// NewRepository creates a new Repository.
+func NewRepository(apps typedappsv1.AppsV1Interface) Repository {
+	return &RepositoryImpl{
+		apps: apps,
+	}
+}
+
+// RepositoryImpl is used to fetch statefulset related data from kubernetes.
+type RepositoryImpl struct {
+	apps typedappsv1.AppsV1Interface
+}
+
+// Get fetches a single statefulset.
+func (c *RepositoryImpl) Get(ctx context.Context, namespace, name string) (*appsv1.StatefulSet, error) {
+	return c.apps.StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// List fetches a list of statefulsets for a given namespace.
+func (c *RepositoryImpl) List(ctx context.Context, namespace string) (*appsv1.StatefulSetList, error) {
+	return c.apps.StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+}
+
+// Patch applies a patch to a statefulset.
+func (c *RepositoryImpl) Patch(ctx context.Context, namespace, name string, patchType types.PatchType, data []byte) (*appsv1.StatefulSet, error) {
+	return c.apps.StatefulSets(namespace).Patch(ctx, name, patchType, data, metav1.PatchOptions{})
+}
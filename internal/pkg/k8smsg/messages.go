@@ -2,9 +2,17 @@
 package k8smsg
 
 import (
+	"kubeui/internal/pkg/k8s/deployments"
+	"kubeui/internal/pkg/k8s/ingresses"
+	"kubeui/internal/pkg/k8s/nodes"
 	"kubeui/internal/pkg/k8s/pods"
+	"kubeui/internal/pkg/k8s/resources"
+	"kubeui/internal/pkg/k8s/services"
 
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // ContextDeletedMsg is sent after a context has been deleted.
@@ -17,6 +25,16 @@ func NewContextDeletedMsg(name string) ContextDeletedMsg {
 	return ContextDeletedMsg{Name: name}
 }
 
+// ContextSwitchedMsg is sent after switching to another context.
+type ContextSwitchedMsg struct {
+	Name string
+}
+
+// NewContextSwitchedMsg creates a new ContextSwitched message.
+func NewContextSwitchedMsg(name string) ContextSwitchedMsg {
+	return ContextSwitchedMsg{Name: name}
+}
+
 // ListNamespacesMsg is sent after fetching a list of available namespaces.
 type ListNamespacesMsg struct {
 	NamespaceList *v1.NamespaceList
@@ -37,6 +55,51 @@ func NewListPodsMsg(podList *v1.PodList) ListPodsMsg {
 	return ListPodsMsg{PodList: podList}
 }
 
+// WatchPodsMsg is sent after listing pods and starting a watch for changes to them.
+type WatchPodsMsg struct {
+	PodList *v1.PodList
+	Events  <-chan pods.WatchEvent
+}
+
+// NewWatchPodsMsg creates a new WatchPods message.
+func NewWatchPodsMsg(podList *v1.PodList, events <-chan pods.WatchEvent) WatchPodsMsg {
+	return WatchPodsMsg{PodList: podList, Events: events}
+}
+
+// StreamMsg marks WatchPodsMsg as belonging to a stream.
+func (WatchPodsMsg) StreamMsg() {}
+
+// PodWatchEventMsg is sent for every event received on a pod watch.
+// Events identifies the watch that the event was received on.
+type PodWatchEventMsg struct {
+	Event  pods.WatchEvent
+	Events <-chan pods.WatchEvent
+}
+
+// StreamMsg marks PodWatchEventMsg as belonging to a stream.
+func (PodWatchEventMsg) StreamMsg() {}
+
+// PodWatchClosedMsg is sent when a pod watch has stopped delivering events.
+type PodWatchClosedMsg struct {
+	Events <-chan pods.WatchEvent
+}
+
+// StreamMsg marks PodWatchClosedMsg as belonging to a stream.
+func (PodWatchClosedMsg) StreamMsg() {}
+
+// NextPodWatchEvent creates a command that waits for the next event on a pod watch.
+// A PodWatchClosedMsg is returned once the events channel has been closed.
+func NextPodWatchEvent(events <-chan pods.WatchEvent) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-events
+		if !ok {
+			return PodWatchClosedMsg{Events: events}
+		}
+
+		return PodWatchEventMsg{Event: event, Events: events}
+	}
+}
+
 // PodDeletedMsg is sent after a pod has been deleted.
 type PodDeletedMsg struct {
 	Name string
@@ -47,6 +110,16 @@ func NewPodDeletedMsg(name string) PodDeletedMsg {
 	return PodDeletedMsg{Name: name}
 }
 
+// PodsDeletedMsg is sent after deleting several pods, containing the result of each deletion.
+type PodsDeletedMsg struct {
+	Results []pods.DeleteResult
+}
+
+// NewPodsDeletedMsg creates a new PodsDeleted message.
+func NewPodsDeletedMsg(results []pods.DeleteResult) PodsDeletedMsg {
+	return PodsDeletedMsg{Results: results}
+}
+
 // GetPodMsg is used as the result of fetching a pod in the current namespace.
 type GetPodMsg struct {
 	Pod *pods.Pod
@@ -56,3 +129,261 @@ type GetPodMsg struct {
 func NewGetPodMsg(pod *pods.Pod) GetPodMsg {
 	return GetPodMsg{Pod: pod}
 }
+
+// ListDeploymentsMsg is used as the result of fetching a list of deployments in the current namespace.
+type ListDeploymentsMsg struct {
+	DeploymentList *appsv1.DeploymentList
+}
+
+// NewListDeploymentsMsg creates a new ListDeployments message.
+func NewListDeploymentsMsg(deploymentList *appsv1.DeploymentList) ListDeploymentsMsg {
+	return ListDeploymentsMsg{DeploymentList: deploymentList}
+}
+
+// ListStatefulSetsMsg is used as the result of listing statefulsets in the current namespace.
+type ListStatefulSetsMsg struct {
+	StatefulSetList *appsv1.StatefulSetList
+}
+
+// NewListStatefulSetsMsg creates a new ListStatefulSets message.
+func NewListStatefulSetsMsg(statefulSetList *appsv1.StatefulSetList) ListStatefulSetsMsg {
+	return ListStatefulSetsMsg{StatefulSetList: statefulSetList}
+}
+
+// GetDeploymentMsg is used as the result of fetching a deployment in the current namespace.
+type GetDeploymentMsg struct {
+	Deployment *deployments.Deployment
+}
+
+// NewGetDeploymentMsg creates a new GetDeployment message.
+func NewGetDeploymentMsg(deployment *deployments.Deployment) GetDeploymentMsg {
+	return GetDeploymentMsg{Deployment: deployment}
+}
+
+// ListNodesMsg is used as the result of fetching the list of nodes in the cluster.
+type ListNodesMsg struct {
+	NodeList *nodes.NodeList
+}
+
+// NewListNodesMsg creates a new ListNodes message.
+func NewListNodesMsg(nodeList *nodes.NodeList) ListNodesMsg {
+	return ListNodesMsg{NodeList: nodeList}
+}
+
+// GetNodeMsg is used as the result of fetching a node.
+type GetNodeMsg struct {
+	Node *nodes.Node
+}
+
+// NewGetNodeMsg creates a new GetNode message.
+func NewGetNodeMsg(node *nodes.Node) GetNodeMsg {
+	return GetNodeMsg{Node: node}
+}
+
+// ListConfigMapsMsg is used as the result of fetching a list of configmaps in the current namespace.
+type ListConfigMapsMsg struct {
+	ConfigMapList *v1.ConfigMapList
+}
+
+// NewListConfigMapsMsg creates a new ListConfigMaps message.
+func NewListConfigMapsMsg(configMapList *v1.ConfigMapList) ListConfigMapsMsg {
+	return ListConfigMapsMsg{ConfigMapList: configMapList}
+}
+
+// GetConfigMapMsg is used as the result of fetching a configmap in the current namespace.
+type GetConfigMapMsg struct {
+	ConfigMap *v1.ConfigMap
+}
+
+// NewGetConfigMapMsg creates a new GetConfigMap message.
+func NewGetConfigMapMsg(configMap *v1.ConfigMap) GetConfigMapMsg {
+	return GetConfigMapMsg{ConfigMap: configMap}
+}
+
+// ListSecretsMsg is used as the result of fetching a list of secrets in the current namespace.
+type ListSecretsMsg struct {
+	SecretList *v1.SecretList
+}
+
+// NewListSecretsMsg creates a new ListSecrets message.
+func NewListSecretsMsg(secretList *v1.SecretList) ListSecretsMsg {
+	return ListSecretsMsg{SecretList: secretList}
+}
+
+// GetSecretMsg is used as the result of fetching a secret in the current namespace.
+type GetSecretMsg struct {
+	Secret *v1.Secret
+}
+
+// NewGetSecretMsg creates a new GetSecret message.
+func NewGetSecretMsg(secret *v1.Secret) GetSecretMsg {
+	return GetSecretMsg{Secret: secret}
+}
+
+// DrainStreamMsg is sent after starting to drain a node.
+type DrainStreamMsg struct {
+	Events <-chan nodes.DrainEvent
+}
+
+// NewDrainStreamMsg creates a new DrainStream message.
+func NewDrainStreamMsg(events <-chan nodes.DrainEvent) DrainStreamMsg {
+	return DrainStreamMsg{Events: events}
+}
+
+// StreamMsg marks DrainStreamMsg as belonging to a stream.
+func (DrainStreamMsg) StreamMsg() {}
+
+// DrainEventMsg is sent for every change in the status of a pod while draining a node.
+// Events identifies the drain that the event was received from.
+type DrainEventMsg struct {
+	Event  nodes.DrainEvent
+	Events <-chan nodes.DrainEvent
+}
+
+// StreamMsg marks DrainEventMsg as belonging to a stream.
+func (DrainEventMsg) StreamMsg() {}
+
+// DrainClosedMsg is sent when a drain has finished or been cancelled.
+type DrainClosedMsg struct {
+	Events <-chan nodes.DrainEvent
+}
+
+// StreamMsg marks DrainClosedMsg as belonging to a stream.
+func (DrainClosedMsg) StreamMsg() {}
+
+// NextDrainEvent creates a command that waits for the next event of a drain.
+// A DrainClosedMsg is returned once the events channel has been closed.
+func NextDrainEvent(events <-chan nodes.DrainEvent) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-events
+		if !ok {
+			return DrainClosedMsg{Events: events}
+		}
+
+		return DrainEventMsg{Event: event, Events: events}
+	}
+}
+
+// maxLogLinesBatch is the maximum number of log lines delivered in a single LogLinesMsg.
+const maxLogLinesBatch = 500
+
+// LogStreamMsg is sent after starting to stream the logs of a container.
+// Id identifies the request that started the stream.
+type LogStreamMsg struct {
+	Id    int
+	Lines <-chan string
+}
+
+// NewLogStreamMsg creates a new LogStream message.
+func NewLogStreamMsg(id int, lines <-chan string) LogStreamMsg {
+	return LogStreamMsg{Id: id, Lines: lines}
+}
+
+// StreamMsg marks LogStreamMsg as belonging to a stream.
+func (LogStreamMsg) StreamMsg() {}
+
+// LogLinesMsg contains log lines received on a log stream.
+// Stream identifies the log stream that the lines were received on.
+type LogLinesMsg struct {
+	Lines  []string
+	Stream <-chan string
+}
+
+// StreamMsg marks LogLinesMsg as belonging to a stream.
+func (LogLinesMsg) StreamMsg() {}
+
+// LogStreamClosedMsg is sent when a log stream has stopped delivering lines.
+type LogStreamClosedMsg struct {
+	Stream <-chan string
+}
+
+// StreamMsg marks LogStreamClosedMsg as belonging to a stream.
+func (LogStreamClosedMsg) StreamMsg() {}
+
+// NextLogLines creates a command that waits for the next line on a log stream.
+// Lines that are already buffered in the stream are delivered together in the same message,
+// and a LogStreamClosedMsg is returned once the stream has been closed.
+func NextLogLines(stream <-chan string) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-stream
+		if !ok {
+			return LogStreamClosedMsg{Stream: stream}
+		}
+
+		lines := []string{line}
+
+		for len(lines) < maxLogLinesBatch {
+			select {
+			case line, ok := <-stream:
+				if !ok {
+					return LogLinesMsg{Lines: lines, Stream: stream}
+				}
+				lines = append(lines, line)
+			default:
+				return LogLinesMsg{Lines: lines, Stream: stream}
+			}
+		}
+
+		return LogLinesMsg{Lines: lines, Stream: stream}
+	}
+}
+
+// ListServicesMsg is used as the result of fetching a list of services in the current namespace.
+type ListServicesMsg struct {
+	ServiceList *v1.ServiceList
+}
+
+// NewListServicesMsg creates a new ListServices message.
+func NewListServicesMsg(serviceList *v1.ServiceList) ListServicesMsg {
+	return ListServicesMsg{ServiceList: serviceList}
+}
+
+// GetServiceMsg is used as the result of fetching information about a service in the current namespace.
+type GetServiceMsg struct {
+	Service *services.Service
+}
+
+// NewGetServiceMsg creates a new GetService message.
+func NewGetServiceMsg(service *services.Service) GetServiceMsg {
+	return GetServiceMsg{Service: service}
+}
+
+// ListIngressRoutesMsg is used as the result of fetching the routes of the ingresses in the current namespace.
+type ListIngressRoutesMsg struct {
+	Routes []ingresses.Route
+}
+
+// NewListIngressRoutesMsg creates a new ListIngressRoutes message.
+func NewListIngressRoutesMsg(routes []ingresses.Route) ListIngressRoutesMsg {
+	return ListIngressRoutesMsg{Routes: routes}
+}
+
+// ListResourceTypesMsg is used as the result of discovering the types of resources served by the cluster.
+type ListResourceTypesMsg struct {
+	Resources []resources.Resource
+}
+
+// NewListResourceTypesMsg creates a new ListResourceTypes message.
+func NewListResourceTypesMsg(resourceTypes []resources.Resource) ListResourceTypesMsg {
+	return ListResourceTypesMsg{Resources: resourceTypes}
+}
+
+// ListResourcesMsg is used as the result of fetching a table of resources of the selected type.
+type ListResourcesMsg struct {
+	Table *resources.Table
+}
+
+// NewListResourcesMsg creates a new ListResources message.
+func NewListResourcesMsg(table *resources.Table) ListResourcesMsg {
+	return ListResourcesMsg{Table: table}
+}
+
+// GetResourceMsg is used as the result of fetching a single resource of the selected type.
+type GetResourceMsg struct {
+	Object *unstructured.Unstructured
+}
+
+// NewGetResourceMsg creates a new GetResource message.
+func NewGetResourceMsg(object *unstructured.Unstructured) GetResourceMsg {
+	return GetResourceMsg{Object: object}
+}
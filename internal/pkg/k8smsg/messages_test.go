@@ -1,11 +1,16 @@
 package k8smsg_test
 
 import (
+	"kubeui/internal/pkg/k8s/deployments"
+	"kubeui/internal/pkg/k8s/nodes"
 	"kubeui/internal/pkg/k8s/pods"
+	"kubeui/internal/pkg/k8s/resources"
+	"kubeui/internal/pkg/k8s/services"
 	"kubeui/internal/pkg/k8smsg"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -88,3 +93,232 @@ func TestNewGetPodMsg(t *testing.T) {
 		})
 	}
 }
+
+func TestNewWatchPodsMsg(t *testing.T) {
+
+	podList := &v1.PodList{Items: []v1.Pod{{Status: v1.PodStatus{Message: "test"}}}}
+	events := make(chan pods.WatchEvent)
+
+	got := k8smsg.NewWatchPodsMsg(podList, events)
+
+	assert.Equal(t, podList, got.PodList)
+	assert.Equal(t, (<-chan pods.WatchEvent)(events), got.Events)
+}
+
+func TestNextPodWatchEvent(t *testing.T) {
+
+	events := make(chan pods.WatchEvent, 1)
+	event := pods.WatchEvent{Type: pods.Added, Pod: v1.Pod{Status: v1.PodStatus{Message: "test"}}}
+
+	events <- event
+	assert.Equal(t, k8smsg.PodWatchEventMsg{Event: event, Events: events}, k8smsg.NextPodWatchEvent(events)())
+
+	close(events)
+	assert.Equal(t, k8smsg.PodWatchClosedMsg{Events: events}, k8smsg.NextPodWatchEvent(events)())
+}
+
+func TestNextLogLines(t *testing.T) {
+
+	stream := make(chan string, 3)
+
+	stream <- "line 1"
+	stream <- "line 2"
+	assert.Equal(t, k8smsg.LogLinesMsg{Lines: []string{"line 1", "line 2"}, Stream: stream}, k8smsg.NextLogLines(stream)())
+
+	stream <- "line 3"
+	close(stream)
+	assert.Equal(t, k8smsg.LogLinesMsg{Lines: []string{"line 3"}, Stream: stream}, k8smsg.NextLogLines(stream)())
+	assert.Equal(t, k8smsg.LogStreamClosedMsg{Stream: stream}, k8smsg.NextLogLines(stream)())
+}
+
+func TestNewListDeploymentsMsg(t *testing.T) {
+
+	expected := &appsv1.DeploymentList{Items: []appsv1.Deployment{{Status: appsv1.DeploymentStatus{Replicas: 1}}}}
+
+	tests := []struct {
+		name           string
+		deploymentList *appsv1.DeploymentList
+		want           k8smsg.ListDeploymentsMsg
+	}{
+		{"should work with nil", nil, k8smsg.ListDeploymentsMsg{DeploymentList: nil}},
+		{"should assign the same object", expected, k8smsg.ListDeploymentsMsg{DeploymentList: expected}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := k8smsg.NewListDeploymentsMsg(tt.deploymentList)
+			assert.Equal(t, tt.want, got, "")
+		})
+	}
+}
+
+func TestNewListStatefulSetsMsg(t *testing.T) {
+
+	expected := &appsv1.StatefulSetList{Items: []appsv1.StatefulSet{{Status: appsv1.StatefulSetStatus{Replicas: 1}}}}
+
+	tests := []struct {
+		name            string
+		statefulSetList *appsv1.StatefulSetList
+		want            k8smsg.ListStatefulSetsMsg
+	}{
+		{"should work with nil", nil, k8smsg.ListStatefulSetsMsg{StatefulSetList: nil}},
+		{"should assign the same object", expected, k8smsg.ListStatefulSetsMsg{StatefulSetList: expected}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := k8smsg.NewListStatefulSetsMsg(tt.statefulSetList)
+			assert.Equal(t, tt.want, got, "")
+		})
+	}
+}
+
+func TestNewGetDeploymentMsg(t *testing.T) {
+
+	expected := &deployments.Deployment{Deployment: appsv1.Deployment{Status: appsv1.DeploymentStatus{Replicas: 1}}}
+
+	tests := []struct {
+		name       string
+		deployment *deployments.Deployment
+		want       k8smsg.GetDeploymentMsg
+	}{
+		{"should work with nil", nil, k8smsg.GetDeploymentMsg{Deployment: nil}},
+		{"should assign the same object", expected, k8smsg.GetDeploymentMsg{Deployment: expected}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := k8smsg.NewGetDeploymentMsg(tt.deployment)
+			assert.Equal(t, tt.want, got, "")
+		})
+	}
+}
+
+func TestNewListNodesMsg(t *testing.T) {
+
+	expected := &nodes.NodeList{Items: []v1.Node{{Spec: v1.NodeSpec{Unschedulable: true}}}, PodCounts: map[string]int{"node-1": 2}}
+
+	tests := []struct {
+		name     string
+		nodeList *nodes.NodeList
+		want     k8smsg.ListNodesMsg
+	}{
+		{"should work with nil", nil, k8smsg.ListNodesMsg{NodeList: nil}},
+		{"should assign the same object", expected, k8smsg.ListNodesMsg{NodeList: expected}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := k8smsg.NewListNodesMsg(tt.nodeList)
+			assert.Equal(t, tt.want, got, "")
+		})
+	}
+}
+
+func TestNewGetNodeMsg(t *testing.T) {
+
+	expected := &nodes.Node{Node: v1.Node{Spec: v1.NodeSpec{Unschedulable: true}}}
+
+	tests := []struct {
+		name string
+		node *nodes.Node
+		want k8smsg.GetNodeMsg
+	}{
+		{"should work with nil", nil, k8smsg.GetNodeMsg{Node: nil}},
+		{"should assign the same object", expected, k8smsg.GetNodeMsg{Node: expected}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := k8smsg.NewGetNodeMsg(tt.node)
+			assert.Equal(t, tt.want, got, "")
+		})
+	}
+}
+
+func TestNextDrainEvent(t *testing.T) {
+
+	events := make(chan nodes.DrainEvent, 1)
+	event := nodes.DrainEvent{Namespace: "default", Name: "web-1", Status: nodes.Evicted}
+
+	events <- event
+	assert.Equal(t, k8smsg.DrainEventMsg{Event: event, Events: events}, k8smsg.NextDrainEvent(events)())
+
+	close(events)
+	assert.Equal(t, k8smsg.DrainClosedMsg{Events: events}, k8smsg.NextDrainEvent(events)())
+}
+
+func TestNewListConfigMapsMsg(t *testing.T) {
+
+	expected := &v1.ConfigMapList{Items: []v1.ConfigMap{{Data: map[string]string{"key": "value"}}}}
+
+	tests := []struct {
+		name          string
+		configMapList *v1.ConfigMapList
+		want          k8smsg.ListConfigMapsMsg
+	}{
+		{"should work with nil", nil, k8smsg.ListConfigMapsMsg{ConfigMapList: nil}},
+		{"should assign the same object", expected, k8smsg.ListConfigMapsMsg{ConfigMapList: expected}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := k8smsg.NewListConfigMapsMsg(tt.configMapList)
+			assert.Equal(t, tt.want, got, "")
+		})
+	}
+}
+
+func TestNewGetSecretMsg(t *testing.T) {
+
+	expected := &v1.Secret{Data: map[string][]byte{"key": []byte("value")}}
+
+	tests := []struct {
+		name   string
+		secret *v1.Secret
+		want   k8smsg.GetSecretMsg
+	}{
+		{"should work with nil", nil, k8smsg.GetSecretMsg{Secret: nil}},
+		{"should assign the same object", expected, k8smsg.GetSecretMsg{Secret: expected}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := k8smsg.NewGetSecretMsg(tt.secret)
+			assert.Equal(t, tt.want, got, "")
+		})
+	}
+}
+
+func TestNewListResourcesMsg(t *testing.T) {
+
+	expected := &resources.Table{Rows: []resources.TableRow{{Name: "resource-1"}}}
+
+	tests := []struct {
+		name  string
+		table *resources.Table
+		want  k8smsg.ListResourcesMsg
+	}{
+		{"should work with nil", nil, k8smsg.ListResourcesMsg{Table: nil}},
+		{"should assign the same object", expected, k8smsg.ListResourcesMsg{Table: expected}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := k8smsg.NewListResourcesMsg(tt.table)
+			assert.Equal(t, tt.want, got, "")
+		})
+	}
+}
+
+func TestNewGetServiceMsg(t *testing.T) {
+
+	expected := &services.Service{Service: v1.Service{Spec: v1.ServiceSpec{ClusterIP: "10.96.0.10"}}}
+
+	tests := []struct {
+		name    string
+		service *services.Service
+		want    k8smsg.GetServiceMsg
+	}{
+		{"should work with nil", nil, k8smsg.GetServiceMsg{Service: nil}},
+		{"should assign the same object", expected, k8smsg.GetServiceMsg{Service: expected}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := k8smsg.NewGetServiceMsg(tt.service)
+			assert.Equal(t, tt.want, got, "")
+		})
+	}
+}
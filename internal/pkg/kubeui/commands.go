@@ -30,10 +30,12 @@ type PushViewMsg struct {
 	// Indicates whether view.Init should be called.
 	// The results of this depends on the view, but most of the time this will result in a reload of data.
 	// If going back to a previous view without any changes then you probably want to set this to false.
+	// A view that is not already in the navigation stack is always initialized.
 	Initialize bool
 }
 
-// PushView navigates to a new view.
+// PushView navigates to a new view, which is put on top of the navigation stack.
+// If a view with the same id is already in the stack then the views above it are popped instead, making it the current view.
 // It is up the the application to map the id to an actual view.
 func PushView(id string, initialize bool) tea.Cmd {
 	return func() tea.Msg {
@@ -49,8 +51,8 @@ type PopViewMsg struct {
 	Initialize bool
 }
 
-// PopView navigates to the previous view.
-// It is basically used to simluate popups, or used to display an error view where you can choose to quit or go back to the previous view.
+// PopView navigates to the previous view in the navigation stack, the current view is destroyed.
+// It is used to go back from a view, for example to simluate popups, or to leave a view displaying an error.
 func PopView(initialize bool) tea.Cmd {
 	return func() tea.Msg {
 		return PopViewMsg{
@@ -58,3 +60,34 @@ func PopView(initialize bool) tea.Cmd {
 		}
 	}
 }
+
+// ReplaceViewMsg is used to replace the current view with another view.
+type ReplaceViewMsg struct {
+	Id         string
+	Initialize bool
+}
+
+// ReplaceView pops the current view and then pushes a view in the same way as PushView.
+// It is used by views that should not be returned to, such as a view used to select something before going back.
+func ReplaceView(id string, initialize bool) tea.Cmd {
+	return func() tea.Msg {
+		return ReplaceViewMsg{
+			Id:         id,
+			Initialize: initialize,
+		}
+	}
+}
+
+// PopToRootMsg is used to navigate to the first view in the navigation stack.
+type PopToRootMsg struct {
+	Initialize bool
+}
+
+// PopToRoot pops all views except the first view in the navigation stack.
+func PopToRoot(initialize bool) tea.Cmd {
+	return func() tea.Msg {
+		return PopToRootMsg{
+			Initialize: initialize,
+		}
+	}
+}
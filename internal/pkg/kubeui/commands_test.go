@@ -63,3 +63,30 @@ func TestPopView(t *testing.T) {
 		})
 	}
 }
+
+func TestReplaceView(t *testing.T) {
+	cmd := kubeui.ReplaceView("some-view", true)
+	assert.Equal(t, kubeui.ReplaceViewMsg{Id: "some-view", Initialize: true}, cmd())
+}
+
+func TestPopToRoot(t *testing.T) {
+	cmd := kubeui.PopToRoot(true)
+	assert.Equal(t, kubeui.PopToRootMsg{Initialize: true}, cmd())
+}
+
+func TestErrorDetails(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"plain error", fmt.Errorf("error"), ""},
+		{"detailed error", kubeui.DetailedError{Err: fmt.Errorf("error"), Details: "details"}, "details"},
+		{"wrapped detailed error", fmt.Errorf("wrapped: %w", kubeui.DetailedError{Err: fmt.Errorf("error"), Details: "details"}), "details"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, kubeui.ErrorDetails(tt.err))
+		})
+	}
+}
@@ -1,11 +1,64 @@
 package kubeui
 
+import "kubeui/internal/pkg/k8s/resources"
+
 // Context contains the context of the kubeui application.
 type Context struct {
 
 	// Currently selected namespace
 	Namespace string
 
-	// Name of currently selected pod.
-	SelectedPod string
+	// Namespaces that are listed when more than one namespace has been selected.
+	// Views that only support a single namespace ignore it and use Namespace.
+	Namespaces []string
+
+	// Indicates that resources in all namespaces are listed.
+	// Views that only support a single namespace ignore it and use Namespace.
+	AllNamespaces bool
+
+	// Name and namespace of currently selected pod.
+	// The namespace can differ from Namespace when pods in several namespaces are listed.
+	SelectedPod          string
+	SelectedPodNamespace string
+
+	// Name of currently selected deployment.
+	SelectedDeployment string
+
+	// Name of currently selected node.
+	SelectedNode string
+
+	// Name of currently selected configmap.
+	SelectedConfigMap string
+
+	// Name of currently selected secret.
+	SelectedSecret string
+
+	// Name of currently selected service.
+	SelectedService string
+
+	// Currently selected type of resource.
+	SelectedResourceType resources.Resource
+
+	// Name and namespace of currently selected resource of the selected type.
+	// The namespace is empty for resources that are not namespaced.
+	SelectedResource          string
+	SelectedResourceNamespace string
+}
+
+// ListedNamespaces returns the namespaces that resources should be listed in, where an empty string means all namespaces.
+func (c Context) ListedNamespaces() []string {
+	if c.AllNamespaces {
+		return []string{""}
+	}
+
+	if len(c.Namespaces) > 0 {
+		return c.Namespaces
+	}
+
+	return []string{c.Namespace}
+}
+
+// MultipleNamespaces reports whether resources are listed in more than one namespace.
+func (c Context) MultipleNamespaces() bool {
+	return c.AllNamespaces || len(c.Namespaces) > 1
 }
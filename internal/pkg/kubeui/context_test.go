@@ -0,0 +1,29 @@
+package kubeui_test
+
+import (
+	"testing"
+
+	"kubeui/internal/pkg/kubeui"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext_ListedNamespaces(t *testing.T) {
+	tests := []struct {
+		name         string
+		context      kubeui.Context
+		want         []string
+		wantMultiple bool
+	}{
+		{"single namespace", kubeui.Context{Namespace: "default"}, []string{"default"}, false},
+		{"selected namespaces", kubeui.Context{Namespace: "default", Namespaces: []string{"web", "db"}}, []string{"web", "db"}, true},
+		{"one selected namespace", kubeui.Context{Namespace: "default", Namespaces: []string{"web"}}, []string{"web"}, false},
+		{"all namespaces", kubeui.Context{Namespace: "default", Namespaces: []string{"web", "db"}, AllNamespaces: true}, []string{""}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.context.ListedNamespaces())
+			assert.Equal(t, tt.wantMultiple, tt.context.MultipleNamespaces())
+		})
+	}
+}
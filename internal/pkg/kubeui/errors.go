@@ -0,0 +1,30 @@
+package kubeui
+
+import "errors"
+
+// DetailedError is an error with details, such as a diff of the changes that could not be applied,
+// which are displayed together with the error message.
+type DetailedError struct {
+	Err     error
+	Details string
+}
+
+// Error implements the error interface.
+func (e DetailedError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e DetailedError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorDetails extracts the details of an error, it returns an empty string if the error has no details.
+func ErrorDetails(err error) string {
+	var detailedError DetailedError
+	if errors.As(err, &detailedError) {
+		return detailedError.Details
+	}
+
+	return ""
+}
@@ -10,6 +10,8 @@ type GlobalKeyMap struct {
 	Help     key.Binding
 	ExitView key.Binding
 	Refresh  key.Binding
+	// Opens the command palette, ':' is only handled when the current view is not capturing text.
+	CommandPalette key.Binding
 }
 
 // NewGlobalKeyMap defines the actual key bindings and creates a GlobalKeyMap.
@@ -32,5 +34,9 @@ func NewGlobalKeyMap() GlobalKeyMap {
 			key.WithKeys("ctrl+r"),
 			key.WithHelp("ctrl+r", "Refresh the data"),
 		),
+		CommandPalette: key.NewBinding(
+			key.WithKeys(":", "ctrl+b"),
+			key.WithHelp(":,ctrl+b", "Open the command palette"),
+		),
 	}
 }
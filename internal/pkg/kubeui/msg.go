@@ -5,6 +5,13 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// StreamMsg is implemented by messages originating from a long running stream, such as a watch.
+// Stream messages are delivered to every view in an application and not only to the active one,
+// which allows a view to keep consuming its stream while another view is displayed.
+type StreamMsg interface {
+	StreamMsg()
+}
+
 // Msg wraps the bubbletea message in a way that allows us to simplify some things.
 type Msg struct {
 	TeaMsg tea.Msg
@@ -1,19 +1,25 @@
 package kubeui
 
 import (
+	"os"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/termenv"
 )
 
 // NewProgram creates a new bubbletea program given a bubbletea model.
-func NewProgram(model tea.Model, useAltScreen bool) *tea.Program {
+// The program is rendered to output, which is usually stdout, but can be stderr when stdout is used to print a result after the program exits.
+func NewProgram(model tea.Model, useAltScreen bool, output *os.File) *tea.Program {
+
+	terminal := termenv.NewOutput(output)
 
-	// Needed as lipgloss uses stdout/stdin to communicate with the terminal to check if it has a dark or light background
-	// Once the bubbletea program starts it takes control of stdout and stdin.
-	lipgloss.SetHasDarkBackground(termenv.HasDarkBackground())
+	// Needed as lipgloss uses the output to communicate with the terminal to check if it has a dark or light background
+	// Once the bubbletea program starts it takes control of the output and stdin.
+	lipgloss.SetHasDarkBackground(terminal.HasDarkBackground())
+	lipgloss.SetColorProfile(terminal.EnvColorProfile())
 
-	options := []tea.ProgramOption{}
+	options := []tea.ProgramOption{tea.WithOutput(output)}
 
 	if useAltScreen {
 		options = append(options, tea.WithAltScreen())
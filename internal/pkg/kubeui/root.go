@@ -0,0 +1,405 @@
+package kubeui
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+
+	"kubeui/internal/pkg/component/commandpalette"
+	"kubeui/internal/pkg/ui/help"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/life4/genesis/slices"
+)
+
+// ErrorViewId is the id of the view that is pushed when an error is returned as a message.
+const ErrorViewId = "error_info"
+
+// ViewConfig contains what is known about a view when it is created.
+type ViewConfig struct {
+	WindowWidth  int
+	WindowHeight int
+
+	// Id of the view below the new view in the navigation stack.
+	// It is empty if the new view is the first view in the stack.
+	ParentId string
+
+	// The error to display, it is only set when creating the view with the id ErrorViewId.
+	Err error
+}
+
+// RootOptions defines the views of a program and how it starts.
+type RootOptions struct {
+	// The context the program starts with.
+	Context Context
+
+	// Start is called when the program has started.
+	// It returns the context to use, along with a command that usually pushes the first view.
+	Start func(Context) (Context, tea.Cmd)
+
+	// NewView creates the view with the given id.
+	NewView func(id string, config ViewConfig) View
+
+	// Commands are the commands offered by the command palette, in the order they are listed before anything has been typed.
+	// The palette can not be opened if there are no commands.
+	Commands []Command
+}
+
+// Command is a command of the command palette along with the id of the view it pushes.
+type Command struct {
+	commandpalette.Command
+	ViewId string
+}
+
+// stackEntry is a view in the navigation stack along with its id.
+type stackEntry struct {
+	id   string
+	view View
+}
+
+// lastGeneration is the generation of the most recently created Root.
+var lastGeneration atomic.Int64
+
+// generationMsg is a message resulting from a command returned by a Root, tagged with the generation of the Root.
+type generationMsg struct {
+	generation int64
+	msg        tea.Msg
+}
+
+// Root is a bubbletea model that displays the views of a program.
+// It keeps a stack of views, where the view on top of the stack is displayed and handles most messages.
+// Views navigate by returning the commands PushView, PopView, ReplaceView and PopToRoot,
+// and views that are removed from the stack are destroyed.
+//
+// Every Root has its own generation, and the messages resulting from its commands are tagged with it.
+// When a Root is replaced, for instance after switching context, the results of requests that were still in flight are ignored by the new Root.
+type Root struct {
+	options RootOptions
+
+	generation int64
+
+	context Context
+
+	stack []stackEntry
+
+	windowWidth  int
+	windowHeight int
+
+	// The last error returned as a message, which is displayed by the error view.
+	err error
+
+	keys GlobalKeyMap
+
+	// The command palette, it is considered to be open if non nil.
+	palette *commandpalette.Model
+}
+
+// NewRoot creates a new Root.
+func NewRoot(options RootOptions) Root {
+	return Root{
+		options:    options,
+		context:    options.Context,
+		generation: lastGeneration.Add(1),
+		keys:       NewGlobalKeyMap(),
+	}
+}
+
+// startMsg is sent when the program starts.
+type startMsg struct{}
+
+// Init returns an initial command.
+// It is part of the bubbletea model interface.
+func (r Root) Init() tea.Cmd {
+	return r.tag(func() tea.Msg {
+		return startMsg{}
+	})
+}
+
+// Update updates the model and optionally returns a command.
+// It is part of the bubbletea model interface.
+func (r Root) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return r.UpdateRoot(msg)
+}
+
+// UpdateRoot works like Update but returns a Root, which is useful when the Root is part of another model.
+func (r Root) UpdateRoot(msg tea.Msg) (Root, tea.Cmd) {
+	msg, ok := r.Unwrap(msg)
+	if !ok {
+		return r, nil
+	}
+
+	r, cmd := r.update(msg)
+	return r, r.tag(cmd)
+}
+
+// Unwrap returns the message a command of a Root resulted in, without its generation.
+// The returned bool is false if the message belongs to another Root, in which case it should be ignored.
+// Messages that did not result from commands of a Root are returned unchanged.
+func (r Root) Unwrap(msg tea.Msg) (tea.Msg, bool) {
+	generationMsg, ok := msg.(generationMsg)
+	if !ok {
+		return msg, true
+	}
+
+	return generationMsg.msg, generationMsg.generation == r.generation
+}
+
+// teaPkgPath is the path of the bubbletea package.
+var teaPkgPath = reflect.TypeOf(tea.QuitMsg{}).PkgPath()
+
+// tag makes the message resulting from a command carry the generation of the Root.
+// Messages of the bubbletea package, such as the messages used to quit or to run a process, must reach the runtime and are not tagged,
+// but the commands of a batch are tagged individually.
+func (r Root) tag(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+
+	generation := r.generation
+
+	return func() tea.Msg {
+		msg := cmd()
+
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			cmds := tea.BatchMsg{}
+			for _, cmd := range batch {
+				cmds = append(cmds, r.tag(cmd))
+			}
+			return cmds
+		}
+
+		if msg == nil || reflect.TypeOf(msg).PkgPath() == teaPkgPath {
+			return msg
+		}
+
+		return generationMsg{generation: generation, msg: msg}
+	}
+}
+
+// update handles a message that has been unwrapped.
+func (r Root) update(msg tea.Msg) (Root, tea.Cmd) {
+	switch msgT := msg.(type) {
+	case startMsg:
+		if r.options.Start == nil {
+			return r, nil
+		}
+
+		var cmd tea.Cmd
+		r.context, cmd = r.options.Start(r.context)
+		return r, cmd
+
+	case tea.WindowSizeMsg:
+		r.windowWidth = msgT.Width
+		r.windowHeight = msgT.Height
+
+		for i, entry := range r.stack {
+			_, view, _ := entry.view.Update(r.context, Msg{TeaMsg: msg})
+			r.stack[i].view = view
+		}
+		return r, nil
+
+	case error:
+		r.err = msgT
+		return r.push(ErrorViewId, true)
+
+	case PushViewMsg:
+		return r.push(msgT.Id, msgT.Initialize)
+
+	case PopViewMsg:
+		// The first view can not be popped.
+		if len(r.stack) < 2 {
+			return r, nil
+		}
+
+		return r.popTo(len(r.stack)-2, msgT.Initialize)
+
+	case ReplaceViewMsg:
+		cmds := []tea.Cmd{}
+		if len(r.stack) > 0 {
+			cmds = append(cmds, r.stack[len(r.stack)-1].view.Destroy(r.context))
+			r.stack = r.stack[:len(r.stack)-1]
+		}
+
+		r, cmd := r.push(msgT.Id, msgT.Initialize)
+		return r, tea.Batch(append(cmds, cmd)...)
+
+	case PopToRootMsg:
+		if len(r.stack) == 0 {
+			return r, nil
+		}
+
+		return r.popTo(0, msgT.Initialize)
+
+	// Stream messages are delivered to all views, allowing views that are not currently displayed to keep consuming their streams.
+	case StreamMsg:
+		cmds := []tea.Cmd{}
+
+		for i, entry := range r.stack {
+			_, view, cmd := entry.view.Update(r.context, Msg{TeaMsg: msg})
+			r.stack[i].view = view
+			cmds = append(cmds, cmd)
+		}
+
+		return r, tea.Batch(cmds...)
+
+	case tea.KeyMsg:
+		if r.palette != nil {
+			// Every other key is handled by the palette while it is open.
+			if key.Matches(msgT, r.keys.Quit) {
+				return r, Exit()
+			}
+
+			palette, cmd := r.palette.Update(msg)
+			r.palette = &palette
+			return r, cmd
+		}
+
+		if r.opensPalette(msgT) {
+			palette := commandpalette.New(slices.Map(r.options.Commands, func(c Command) commandpalette.Command {
+				return c.Command
+			}))
+			r.palette = &palette
+			return r, nil
+		}
+
+	case commandpalette.Selection:
+		r.palette = nil
+
+		selected, err := slices.Find(r.options.Commands, func(c Command) bool {
+			return c.Name == msgT.Command.Name
+		})
+		if err != nil {
+			return r, Error(fmt.Errorf("program error, unknown command %s", msgT.Command.Name))
+		}
+
+		return r.push(selected.ViewId, true)
+
+	case commandpalette.Cancellation:
+		r.palette = nil
+		return r, nil
+	}
+
+	// There is no view to deliver the message to until the first view has been pushed.
+	if len(r.stack) == 0 {
+		return r, nil
+	}
+
+	top := len(r.stack) - 1
+	c, view, cmd := r.stack[top].view.Update(r.context, Msg{TeaMsg: msg})
+
+	r.context = c
+	r.stack[top].view = view
+
+	return r, cmd
+}
+
+// push makes the view with the given id the current view.
+// A view that is already in the stack is returned to by popping the views above it, other views are created and pushed on top of the stack.
+func (r Root) push(id string, initialize bool) (Root, tea.Cmd) {
+	for i, entry := range r.stack {
+		if entry.id == id {
+			return r.popTo(i, initialize)
+		}
+	}
+
+	r.stack = append(r.stack, stackEntry{id: id})
+
+	top := len(r.stack) - 1
+	r.stack[top].view = r.newView(top)
+
+	// A view that is not returned to has no data yet, so it is always initialized.
+	return r, r.stack[top].view.Init(r.context)
+}
+
+// popTo destroys the views above the given index in the stack, making the view at the index the current view.
+// If initialize is set then the view at the index is replaced by a new view which is initialized.
+func (r Root) popTo(index int, initialize bool) (Root, tea.Cmd) {
+	cmds := []tea.Cmd{}
+
+	for i := len(r.stack) - 1; i > index; i-- {
+		cmds = append(cmds, r.stack[i].view.Destroy(r.context))
+	}
+	r.stack = r.stack[:index+1]
+
+	if initialize {
+		cmds = append(cmds, r.stack[index].view.Destroy(r.context))
+		r.stack[index].view = r.newView(index)
+		cmds = append(cmds, r.stack[index].view.Init(r.context))
+	}
+
+	return r, tea.Batch(cmds...)
+}
+
+// newView creates the view for the entry at the given index in the stack.
+func (r Root) newView(index int) View {
+	config := ViewConfig{
+		WindowWidth:  r.windowWidth,
+		WindowHeight: r.windowHeight,
+	}
+
+	if index > 0 {
+		config.ParentId = r.stack[index-1].id
+	}
+
+	if r.stack[index].id == ErrorViewId {
+		config.Err = r.err
+	}
+
+	return r.options.NewView(r.stack[index].id, config)
+}
+
+// opensPalette reports whether a key opens the command palette.
+// The palette can not be opened until the first view is displayed, and typing ':' only opens it when the view is not capturing text.
+func (r Root) opensPalette(msg tea.KeyMsg) bool {
+	if len(r.options.Commands) == 0 || len(r.stack) == 0 || !key.Matches(msg, r.keys.CommandPalette) {
+		return false
+	}
+
+	if msg.String() != ":" {
+		return true
+	}
+
+	view, ok := r.stack[len(r.stack)-1].view.(TextInputView)
+	return !ok || !view.CapturesText()
+}
+
+// CurrentViewId returns the id of the view on top of the navigation stack, or an empty string if no view has been pushed.
+func (r Root) CurrentViewId() string {
+	if len(r.stack) == 0 {
+		return ""
+	}
+
+	return r.stack[len(r.stack)-1].id
+}
+
+// Destroy destroys all views in the navigation stack, starting with the view on top.
+// It is used when a Root is replaced, the Root should not be used afterwards.
+func (r Root) Destroy() tea.Cmd {
+	cmds := []tea.Cmd{}
+
+	for i := len(r.stack) - 1; i >= 0; i-- {
+		cmds = append(cmds, r.stack[i].view.Destroy(r.context))
+	}
+
+	return r.tag(tea.Batch(cmds...))
+}
+
+// View returns the view for the model.
+// It is part of the bubbletea model interface.
+func (r Root) View() string {
+	if len(r.stack) == 0 {
+		return "Initializing..."
+	}
+
+	if r.palette != nil {
+		builder := strings.Builder{}
+		builder.WriteString(help.Short(r.windowWidth, r.palette.KeyList()))
+		builder.WriteString("\n\n")
+		builder.WriteString(r.palette.View())
+		return builder.String()
+	}
+
+	return r.stack[len(r.stack)-1].view.View(r.context)
+}
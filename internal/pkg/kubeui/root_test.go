@@ -0,0 +1,248 @@
+package kubeui_test
+
+import (
+	"fmt"
+	"testing"
+
+	"kubeui/internal/pkg/component/commandpalette"
+	"kubeui/internal/pkg/kubeui"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeView records when it is initialized and destroyed, and renders its id along with the id of its parent.
+type fakeView struct {
+	id     string
+	config kubeui.ViewConfig
+	events *[]string
+}
+
+func (v fakeView) Init(c kubeui.Context) tea.Cmd {
+	*v.events = append(*v.events, "init "+v.id)
+	return nil
+}
+
+func (v fakeView) Update(c kubeui.Context, msg kubeui.Msg) (kubeui.Context, kubeui.View, tea.Cmd) {
+	return c, v, nil
+}
+
+func (v fakeView) View(c kubeui.Context) string {
+	if v.config.Err != nil {
+		return fmt.Sprintf("%s: %v", v.id, v.config.Err)
+	}
+
+	return fmt.Sprintf("%s (parent: %s)", v.id, v.config.ParentId)
+}
+
+func (v fakeView) Destroy(c kubeui.Context) tea.Cmd {
+	*v.events = append(*v.events, "destroy "+v.id)
+	return nil
+}
+
+// newRoot creates a Root with fake views, the events of the views are recorded in the returned slice.
+func newRoot() (kubeui.Root, *[]string) {
+	events := &[]string{}
+
+	root := kubeui.NewRoot(kubeui.RootOptions{
+		NewView: func(id string, config kubeui.ViewConfig) kubeui.View {
+			return fakeView{id: id, config: config, events: events}
+		},
+	})
+
+	return root, events
+}
+
+// update sends the messages to the root in order.
+func update(root kubeui.Root, msgs ...tea.Msg) kubeui.Root {
+	for _, msg := range msgs {
+		root, _ = root.UpdateRoot(msg)
+	}
+
+	return root
+}
+
+func TestRootNavigation(t *testing.T) {
+	tests := []struct {
+		name       string
+		msgs       []tea.Msg
+		wantView   string
+		wantEvents []string
+	}{
+		{
+			name:       "push",
+			msgs:       []tea.Msg{kubeui.PushViewMsg{Id: "a"}, kubeui.PushViewMsg{Id: "b"}},
+			wantView:   "b (parent: a)",
+			wantEvents: []string{"init a", "init b"},
+		},
+		{
+			name:       "pop twice",
+			msgs:       []tea.Msg{kubeui.PushViewMsg{Id: "a"}, kubeui.PushViewMsg{Id: "b"}, kubeui.PushViewMsg{Id: "c"}, kubeui.PopViewMsg{}, kubeui.PopViewMsg{}},
+			wantView:   "a (parent: )",
+			wantEvents: []string{"init a", "init b", "init c", "destroy c", "destroy b"},
+		},
+		{
+			name:       "first view is not popped",
+			msgs:       []tea.Msg{kubeui.PushViewMsg{Id: "a"}, kubeui.PopViewMsg{}},
+			wantView:   "a (parent: )",
+			wantEvents: []string{"init a"},
+		},
+		{
+			name:       "pop and initialize",
+			msgs:       []tea.Msg{kubeui.PushViewMsg{Id: "a"}, kubeui.PushViewMsg{Id: "b"}, kubeui.PopViewMsg{Initialize: true}},
+			wantView:   "a (parent: )",
+			wantEvents: []string{"init a", "init b", "destroy b", "destroy a", "init a"},
+		},
+		{
+			name:       "push view in stack returns to it",
+			msgs:       []tea.Msg{kubeui.PushViewMsg{Id: "a"}, kubeui.PushViewMsg{Id: "b"}, kubeui.PushViewMsg{Id: "c"}, kubeui.PushViewMsg{Id: "a"}},
+			wantView:   "a (parent: )",
+			wantEvents: []string{"init a", "init b", "init c", "destroy c", "destroy b"},
+		},
+		{
+			name:       "replace",
+			msgs:       []tea.Msg{kubeui.PushViewMsg{Id: "a"}, kubeui.PushViewMsg{Id: "b"}, kubeui.ReplaceViewMsg{Id: "c"}},
+			wantView:   "c (parent: a)",
+			wantEvents: []string{"init a", "init b", "destroy b", "init c"},
+		},
+		{
+			name:       "replace first view",
+			msgs:       []tea.Msg{kubeui.PushViewMsg{Id: "a"}, kubeui.ReplaceViewMsg{Id: "b"}},
+			wantView:   "b (parent: )",
+			wantEvents: []string{"init a", "destroy a", "init b"},
+		},
+		{
+			name:       "pop to root",
+			msgs:       []tea.Msg{kubeui.PushViewMsg{Id: "a"}, kubeui.PushViewMsg{Id: "b"}, kubeui.PushViewMsg{Id: "c"}, kubeui.PopToRootMsg{}},
+			wantView:   "a (parent: )",
+			wantEvents: []string{"init a", "init b", "init c", "destroy c", "destroy b"},
+		},
+		{
+			name:       "error",
+			msgs:       []tea.Msg{kubeui.PushViewMsg{Id: "a"}, fmt.Errorf("failed")},
+			wantView:   "error_info: failed",
+			wantEvents: []string{"init a", "init error_info"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, events := newRoot()
+
+			root = update(root, tt.msgs...)
+
+			assert.Equal(t, tt.wantView, root.View())
+			assert.Equal(t, tt.wantEvents, *events)
+		})
+	}
+}
+
+func TestRootStart(t *testing.T) {
+	root := kubeui.NewRoot(kubeui.RootOptions{
+		Context: kubeui.Context{Namespace: "default"},
+		Start: func(c kubeui.Context) (kubeui.Context, tea.Cmd) {
+			c.Namespace = "kube-system"
+			return c, kubeui.PushView(c.Namespace, true)
+		},
+		NewView: func(id string, config kubeui.ViewConfig) kubeui.View {
+			return fakeView{id: id, config: config, events: &[]string{}}
+		},
+	})
+
+	assert.Equal(t, "Initializing...", root.View())
+
+	root, cmd := root.UpdateRoot(root.Init()())
+	root, _ = root.UpdateRoot(cmd())
+
+	assert.Equal(t, "kube-system", root.CurrentViewId())
+}
+
+func TestRootDestroy(t *testing.T) {
+	root, events := newRoot()
+
+	root = update(root, kubeui.PushViewMsg{Id: "a"}, kubeui.PushViewMsg{Id: "b"})
+	root.Destroy()
+
+	assert.Equal(t, []string{"init a", "init b", "destroy b", "destroy a"}, *events)
+}
+
+func TestRootGeneration(t *testing.T) {
+	start := func(c kubeui.Context) (kubeui.Context, tea.Cmd) {
+		return c, tea.Batch(kubeui.PushView("a", true), tea.Quit)
+	}
+	newView := func(id string, config kubeui.ViewConfig) kubeui.View {
+		return fakeView{id: id, config: config, events: &[]string{}}
+	}
+
+	previous := kubeui.NewRoot(kubeui.RootOptions{Start: start, NewView: newView})
+	root := kubeui.NewRoot(kubeui.RootOptions{Start: start, NewView: newView})
+
+	// Messages resulting from commands of another Root are ignored.
+	msg := previous.Init()()
+	_, ok := root.Unwrap(msg)
+	assert.False(t, ok)
+
+	root, cmd := root.UpdateRoot(msg)
+	assert.Nil(t, cmd)
+
+	// Commands of a batch are tagged individually, while messages of the bubbletea runtime are passed on unchanged.
+	root, cmd = root.UpdateRoot(root.Init()())
+	batch, ok := cmd().(tea.BatchMsg)
+	assert.True(t, ok)
+	assert.Len(t, batch, 2)
+
+	root, _ = root.UpdateRoot(batch[0]())
+	assert.Equal(t, "a", root.CurrentViewId())
+
+	assert.Equal(t, tea.QuitMsg{}, batch[1]())
+}
+
+// inputView is a fakeView that captures typed text.
+type inputView struct {
+	fakeView
+}
+
+func (v inputView) CapturesText() bool {
+	return true
+}
+
+func TestRootCommandPalette(t *testing.T) {
+	colon := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{':'}}
+
+	newRootWithCommands := func(commands []kubeui.Command) kubeui.Root {
+		return kubeui.NewRoot(kubeui.RootOptions{
+			NewView: func(id string, config kubeui.ViewConfig) kubeui.View {
+				if id == "input" {
+					return inputView{fakeView{id: id, config: config, events: &[]string{}}}
+				}
+				return fakeView{id: id, config: config, events: &[]string{}}
+			},
+			Commands: commands,
+		})
+	}
+	commands := []kubeui.Command{{Command: commandpalette.Command{Name: "bravo"}, ViewId: "b"}}
+
+	// Selecting a command pushes its view.
+	root := update(newRootWithCommands(commands), kubeui.PushViewMsg{Id: "a"}, colon)
+	assert.NotEqual(t, "a (parent: )", root.View())
+
+	root, cmd := root.UpdateRoot(tea.KeyMsg{Type: tea.KeyEnter})
+	root, _ = root.UpdateRoot(cmd())
+	assert.Equal(t, "b", root.CurrentViewId())
+	assert.Equal(t, "b (parent: a)", root.View())
+
+	// The program can be quit while the palette is open.
+	root = update(root, colon)
+	_, cmd = root.UpdateRoot(tea.KeyMsg{Type: tea.KeyCtrlC})
+	assert.Equal(t, tea.QuitMsg{}, cmd())
+
+	// Typing ':' does not open the palette while the view captures text, but ctrl+b does.
+	root = update(newRootWithCommands(commands), kubeui.PushViewMsg{Id: "input"}, colon)
+	assert.Equal(t, "input (parent: )", root.View())
+
+	root = update(root, tea.KeyMsg{Type: tea.KeyCtrlB})
+	assert.NotEqual(t, "input (parent: )", root.View())
+
+	// The palette can not be opened without commands.
+	root = update(newRootWithCommands(nil), kubeui.PushViewMsg{Id: "a"}, colon)
+	assert.Equal(t, "a (parent: )", root.View())
+}
@@ -15,3 +15,10 @@ type View interface {
 	View(Context) string
 	Destroy(Context) tea.Cmd
 }
+
+// TextInputView is implemented by views that capture text typed by the user, for instance in a search field or an input.
+// Typing ':' opens the command palette unless the current view implements TextInputView and is capturing text.
+type TextInputView interface {
+	View
+	CapturesText() bool
+}
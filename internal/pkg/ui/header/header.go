@@ -0,0 +1,108 @@
+// Package header provides a stateless component displayed at the top of every view, showing the user where they are.
+// The location is displayed as breadcrumbs starting with the context, followed by the user and server of the context.
+package header
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/statusbar"
+
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// separator is displayed between the breadcrumbs.
+const separator = " › "
+
+// ContextClient represents the interface for working with kubernetes contexts needed by the header.
+type ContextClient interface {
+	CurrentContext() string
+	CurrentApiContext() (*api.Context, bool)
+	CurrentCluster() (*api.Cluster, bool)
+}
+
+// Location describes what a view displays.
+type Location struct {
+	// Namespace of the displayed resources, it is left empty for resources that are not namespaced.
+	Namespace string
+	// Path to the displayed resources, such as the kind and name of a resource.
+	Path []string
+	// Additional information about the view, displayed after the breadcrumbs.
+	Details []string
+}
+
+// Namespaces describes the namespaces listed by a view that supports listing resources in several namespaces.
+func Namespaces(c kubeui.Context) string {
+	if c.AllNamespaces {
+		return "all namespaces"
+	}
+
+	return strings.Join(c.ListedNamespaces(), ", ")
+}
+
+// Breadcrumbs returns the path from the context to the location.
+func Breadcrumbs(contextName string, location Location) string {
+	crumbs := []string{contextName}
+
+	if location.Namespace != "" {
+		crumbs = append(crumbs, location.Namespace)
+	}
+
+	crumbs = append(crumbs, location.Path...)
+
+	return strings.Join(crumbs, separator)
+}
+
+// cluster returns the user and server of the current context, any of them is left empty if it is unknown.
+func cluster(contextClient ContextClient) (user, server string) {
+	if apiContext, ok := contextClient.CurrentApiContext(); ok {
+		user = apiContext.AuthInfo
+	}
+
+	if cluster, ok := contextClient.CurrentCluster(); ok {
+		server = cluster.Server
+	}
+
+	return user, server
+}
+
+// New returns a string representing the header.
+// The header always fits on one line, the user and server are left out if there is not enough room for them.
+func New(width int, contextClient ContextClient, location Location) string {
+	if width <= 0 {
+		return ""
+	}
+
+	left := strings.Join(append([]string{Breadcrumbs(contextClient.CurrentContext(), location)}, location.Details...), "  ")
+
+	right := ""
+	switch user, server := cluster(contextClient); {
+	case user != "" && server != "":
+		right = fmt.Sprintf("%s @ %s", user, server)
+	case server != "":
+		right = server
+	}
+
+	line := truncate(left, width)
+	if right != "" && lipgloss.Width(left)+2+lipgloss.Width(right) <= width {
+		line = left + strings.Repeat(" ", width-lipgloss.Width(left)-lipgloss.Width(right)) + right
+	}
+
+	return statusbar.New(width, "", line)
+}
+
+// truncate shortens a text to fit within width, marking that it was shortened with an ellipsis.
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+
+	if width <= 1 {
+		return string(runes[:width])
+	}
+
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,91 @@
+package header_test
+
+import (
+	"strings"
+	"testing"
+
+	"kubeui/internal/pkg/kubeui"
+	"kubeui/internal/pkg/ui/header"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+type mockContextClient struct {
+	context *api.Context
+	cluster *api.Cluster
+}
+
+func (c mockContextClient) CurrentContext() string {
+	return "kind-dev"
+}
+
+func (c mockContextClient) CurrentApiContext() (*api.Context, bool) {
+	return c.context, c.context != nil
+}
+
+func (c mockContextClient) CurrentCluster() (*api.Cluster, bool) {
+	return c.cluster, c.cluster != nil
+}
+
+func TestBreadcrumbs(t *testing.T) {
+	tests := []struct {
+		name     string
+		location header.Location
+		want     string
+	}{
+		{"only context", header.Location{}, "kind-dev"},
+		{"namespace", header.Location{Namespace: "default", Path: []string{"pods"}}, "kind-dev › default › pods"},
+		{"not namespaced", header.Location{Path: []string{"nodes", "worker-1"}}, "kind-dev › nodes › worker-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, header.Breadcrumbs("kind-dev", tt.location))
+		})
+	}
+}
+
+func TestNamespaces(t *testing.T) {
+	tests := []struct {
+		name    string
+		context kubeui.Context
+		want    string
+	}{
+		{"single namespace", kubeui.Context{Namespace: "default"}, "default"},
+		{"several namespaces", kubeui.Context{Namespace: "default", Namespaces: []string{"web", "db"}}, "web, db"},
+		{"all namespaces", kubeui.Context{Namespace: "default", AllNamespaces: true}, "all namespaces"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, header.Namespaces(tt.context))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	client := mockContextClient{
+		context: &api.Context{AuthInfo: "admin"},
+		cluster: &api.Cluster{Server: "https://127.0.0.1:6443"},
+	}
+	location := header.Location{Namespace: "default", Path: []string{"pods", "web-1"}, Details: []string{"Query: app=web"}}
+
+	tests := []struct {
+		name   string
+		width  int
+		client mockContextClient
+		want   string
+	}{
+		{"user and server", 90, client, "kind-dev › default › pods › web-1  Query: app=web           admin @ https://127.0.0.1:6443"},
+		{"server without user", 80, mockContextClient{cluster: client.cluster}, "kind-dev › default › pods › web-1  Query: app=web         https://127.0.0.1:6443"},
+		{"no room for user and server", 50, client, "kind-dev › default › pods › web-1  Query: app=web"},
+		{"truncated", 20, client, "kind-dev › default …"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := strings.Split(header.New(tt.width, tt.client, location), "\n")
+
+			assert.Len(t, lines, 2, "the header is a single line followed by a border")
+			assert.Equal(t, tt.want, strings.TrimRight(lines[0], " "))
+		})
+	}
+}
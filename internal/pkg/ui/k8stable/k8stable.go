@@ -0,0 +1,408 @@
+// Package k8stable provides functions to create table columns and rows for kubernetes resources that are displayed by several views.
+package k8stable
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"kubeui/internal/pkg/component/columntable"
+	"kubeui/internal/pkg/k8s"
+	"kubeui/internal/pkg/k8s/ingresses"
+	"kubeui/internal/pkg/k8s/resources"
+	"kubeui/internal/pkg/ui/table"
+
+	"github.com/life4/genesis/slices"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/integer"
+)
+
+// EventColumnsAndRows creates the neccessary columns and row in order to display event information.
+func EventColumnsAndRows(maxWidth int, events []v1.Event) ([]table.DataColumn, []table.DataRow) {
+	eventColumns := []table.DataColumn{
+		{Desc: "Type", Width: 6},
+		{Desc: "Reason", Width: 8},
+		{Desc: "Age", Width: 5},
+		{Desc: "From", Width: 6},
+		{Desc: "Message", Width: 50},
+	}
+
+	eventRows := slices.Map(events, func(e v1.Event) table.DataRow {
+
+		eventFormat := k8s.NewListEventFormat(e, time.Now())
+
+		// Update widths of the name and status columns
+		eventColumns[0].Width = integer.IntMax(eventColumns[0].Width, len(eventFormat.Type)+2)
+		eventColumns[1].Width = integer.IntMax(eventColumns[1].Width, len(eventFormat.Reason)+2)
+		eventColumns[2].Width = integer.IntMax(eventColumns[2].Width, len(eventFormat.Age)+2)
+		eventColumns[3].Width = integer.IntMax(eventColumns[3].Width, len(eventFormat.From))
+
+		remainingWidth := maxWidth - slices.Reduce(eventColumns[0:5], 0, func(c table.DataColumn, acc int) int {
+			return acc + c.Width
+		})
+
+		eventColumns[4].Width = integer.IntMax(remainingWidth-1, len(eventFormat.Message))
+
+		if eventColumns[4].Width < 30 {
+			eventColumns[4].Width = 30
+		}
+
+		return table.DataRow{
+			Values: []string{eventFormat.Type, eventFormat.Reason, eventFormat.Age, eventFormat.From, eventFormat.Message},
+		}
+	})
+
+	return eventColumns, eventRows
+}
+
+// PodColumnsAndRows creates the neccessary columns and rows for a columntable in order to display pod information.
+// The ids of the rows contain both the namespace and the name of the pod, see RowId.
+// If showNamespace is set a namespace column is added, which is used when pods in several namespaces are listed.
+func PodColumnsAndRows(pods []v1.Pod, showNamespace bool) ([]*columntable.Column, []*columntable.Row) {
+	podColumns := []*columntable.Column{
+		{Desc: "Name", Width: 4},
+		{Desc: "Ready", Width: 5, Compare: columntable.CompareNumbers},
+		{Desc: "Status", Width: 6},
+		{Desc: "Restarts", Width: 8, Compare: columntable.CompareNumbers},
+		{Desc: "Age", Width: 3, Compare: columntable.CompareDurations},
+	}
+
+	namespaceColumn := &columntable.Column{Desc: "Namespace", Width: 9}
+	if showNamespace {
+		podColumns = append([]*columntable.Column{namespaceColumn}, podColumns...)
+	}
+
+	podRows := slices.Map(pods, func(p v1.Pod) *columntable.Row {
+		podFormat := k8s.NewListPodFormat(p)
+
+		values := []string{podFormat.Name, podFormat.Ready, podFormat.Status, podFormat.Restarts, podFormat.Age}
+		columns := podColumns
+
+		if showNamespace {
+			namespaceColumn.Width = integer.IntMax(namespaceColumn.Width, len(p.Namespace))
+			values = append([]string{p.Namespace}, values...)
+			columns = podColumns[1:]
+		}
+
+		// Update widths of the name and status columns
+		columns[0].Width = integer.IntMax(columns[0].Width, len(p.Name))
+		columns[1].Width = integer.IntMax(columns[1].Width, len(podFormat.Ready))
+		columns[2].Width = integer.IntMax(columns[2].Width, len(podFormat.Status))
+		columns[3].Width = integer.IntMax(columns[3].Width, len(podFormat.Restarts))
+		columns[4].Width = integer.IntMax(columns[4].Width, len(podFormat.Age))
+
+		return &columntable.Row{
+			Id:     RowId(p.Namespace, p.Name),
+			Values: values,
+		}
+	})
+
+	return podColumns, podRows
+}
+
+// RowId creates the id of a row displaying a namespaced resource.
+func RowId(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// ParseRowId splits the id of a row created by RowId into the namespace and name of the resource.
+// An id without a namespace is returned as the name.
+func ParseRowId(id string) (namespace, name string) {
+	namespace, name, found := strings.Cut(id, "/")
+	if !found {
+		return "", id
+	}
+
+	return namespace, name
+}
+
+// DeploymentColumnsAndRows creates the neccessary columns and rows for a columntable in order to display deployment information.
+func DeploymentColumnsAndRows(deployments []appsv1.Deployment) ([]*columntable.Column, []*columntable.Row) {
+	deploymentColumns := []*columntable.Column{
+		{Desc: "Name", Width: 4},
+		{Desc: "Ready", Width: 5, Compare: columntable.CompareNumbers},
+		{Desc: "Up-to-date", Width: 10, Compare: columntable.CompareNumbers},
+		{Desc: "Available", Width: 9, Compare: columntable.CompareNumbers},
+		{Desc: "Age", Width: 3, Compare: columntable.CompareDurations},
+	}
+
+	now := time.Now()
+
+	deploymentRows := slices.Map(deployments, func(d appsv1.Deployment) *columntable.Row {
+		deploymentFormat := k8s.NewListDeploymentFormat(d, now)
+
+		deploymentColumns[0].Width = integer.IntMax(deploymentColumns[0].Width, len(deploymentFormat.Name))
+		deploymentColumns[1].Width = integer.IntMax(deploymentColumns[1].Width, len(deploymentFormat.Ready))
+		deploymentColumns[2].Width = integer.IntMax(deploymentColumns[2].Width, len(deploymentFormat.UpToDate))
+		deploymentColumns[3].Width = integer.IntMax(deploymentColumns[3].Width, len(deploymentFormat.Available))
+		deploymentColumns[4].Width = integer.IntMax(deploymentColumns[4].Width, len(deploymentFormat.Age))
+
+		return &columntable.Row{
+			Id:     d.Name,
+			Values: []string{deploymentFormat.Name, deploymentFormat.Ready, deploymentFormat.UpToDate, deploymentFormat.Available, deploymentFormat.Age},
+		}
+	})
+
+	return deploymentColumns, deploymentRows
+}
+
+// StatefulSetColumnsAndRows creates the neccessary columns and rows for a columntable in order to display statefulset information.
+func StatefulSetColumnsAndRows(statefulSets []appsv1.StatefulSet) ([]*columntable.Column, []*columntable.Row) {
+	statefulSetColumns := []*columntable.Column{
+		{Desc: "Name", Width: 4},
+		{Desc: "Ready", Width: 5, Compare: columntable.CompareNumbers},
+		{Desc: "Age", Width: 3, Compare: columntable.CompareDurations},
+	}
+
+	now := time.Now()
+
+	statefulSetRows := slices.Map(statefulSets, func(s appsv1.StatefulSet) *columntable.Row {
+		statefulSetFormat := k8s.NewListStatefulSetFormat(s, now)
+
+		statefulSetColumns[0].Width = integer.IntMax(statefulSetColumns[0].Width, len(statefulSetFormat.Name))
+		statefulSetColumns[1].Width = integer.IntMax(statefulSetColumns[1].Width, len(statefulSetFormat.Ready))
+		statefulSetColumns[2].Width = integer.IntMax(statefulSetColumns[2].Width, len(statefulSetFormat.Age))
+
+		return &columntable.Row{
+			Id:     s.Name,
+			Values: []string{statefulSetFormat.Name, statefulSetFormat.Ready, statefulSetFormat.Age},
+		}
+	})
+
+	return statefulSetColumns, statefulSetRows
+}
+
+// NodeColumnsAndRows creates the neccessary columns and rows for a columntable in order to display node information.
+// podCounts contains the number of pods running on each node, keyed by the name of the node.
+func NodeColumnsAndRows(nodes []v1.Node, podCounts map[string]int) ([]*columntable.Column, []*columntable.Row) {
+	nodeColumns := []*columntable.Column{
+		{Desc: "Name", Width: 4},
+		{Desc: "Status", Width: 6},
+		{Desc: "Roles", Width: 5},
+		{Desc: "Version", Width: 7},
+		{Desc: "Age", Width: 3, Compare: columntable.CompareDurations},
+		{Desc: "CPU", Width: 3, Compare: compareQuantities},
+		{Desc: "Memory", Width: 6, Compare: compareQuantities},
+		{Desc: "Pods", Width: 4, Compare: columntable.CompareNumbers},
+	}
+
+	now := time.Now()
+
+	nodeRows := slices.Map(nodes, func(n v1.Node) *columntable.Row {
+		nodeFormat := k8s.NewListNodeFormat(n, podCounts[n.Name], now)
+
+		values := []string{nodeFormat.Name, nodeFormat.Status, nodeFormat.Roles, nodeFormat.Version, nodeFormat.Age, nodeFormat.CPU, nodeFormat.Memory, nodeFormat.Pods}
+
+		for i, value := range values {
+			nodeColumns[i].Width = integer.IntMax(nodeColumns[i].Width, len(value))
+		}
+
+		return &columntable.Row{
+			Id:     n.Name,
+			Values: values,
+		}
+	})
+
+	return nodeColumns, nodeRows
+}
+
+// compareQuantities compares values formatted as resource quantities, such as "3920m" or "15.6Gi".
+// Values that are not quantities sort before those that are.
+func compareQuantities(a, b string) int {
+	quantityA, errA := resource.ParseQuantity(a)
+	quantityB, errB := resource.ParseQuantity(b)
+
+	switch {
+	case errA != nil && errB != nil:
+		return 0
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	}
+
+	return quantityA.Cmp(quantityB)
+}
+
+// ConfigMapColumnsAndRows creates the neccessary columns and rows for a columntable in order to display configmap information.
+func ConfigMapColumnsAndRows(configMaps []v1.ConfigMap) ([]*columntable.Column, []*columntable.Row) {
+	configMapColumns := []*columntable.Column{
+		{Desc: "Name", Width: 4},
+		{Desc: "Data", Width: 4, Compare: columntable.CompareNumbers},
+		{Desc: "Age", Width: 3, Compare: columntable.CompareDurations},
+	}
+
+	now := time.Now()
+
+	configMapRows := slices.Map(configMaps, func(c v1.ConfigMap) *columntable.Row {
+		configMapFormat := k8s.NewListConfigMapFormat(c, now)
+
+		configMapColumns[0].Width = integer.IntMax(configMapColumns[0].Width, len(configMapFormat.Name))
+		configMapColumns[1].Width = integer.IntMax(configMapColumns[1].Width, len(configMapFormat.Data))
+		configMapColumns[2].Width = integer.IntMax(configMapColumns[2].Width, len(configMapFormat.Age))
+
+		return &columntable.Row{
+			Id:     c.Name,
+			Values: []string{configMapFormat.Name, configMapFormat.Data, configMapFormat.Age},
+		}
+	})
+
+	return configMapColumns, configMapRows
+}
+
+// SecretColumnsAndRows creates the neccessary columns and rows for a columntable in order to display secret information.
+// Only the number of values of each secret is displayed, never the values themselves.
+func SecretColumnsAndRows(secrets []v1.Secret) ([]*columntable.Column, []*columntable.Row) {
+	secretColumns := []*columntable.Column{
+		{Desc: "Name", Width: 4},
+		{Desc: "Type", Width: 4},
+		{Desc: "Data", Width: 4, Compare: columntable.CompareNumbers},
+		{Desc: "Age", Width: 3, Compare: columntable.CompareDurations},
+	}
+
+	now := time.Now()
+
+	secretRows := slices.Map(secrets, func(s v1.Secret) *columntable.Row {
+		secretFormat := k8s.NewListSecretFormat(s, now)
+
+		secretColumns[0].Width = integer.IntMax(secretColumns[0].Width, len(secretFormat.Name))
+		secretColumns[1].Width = integer.IntMax(secretColumns[1].Width, len(secretFormat.Type))
+		secretColumns[2].Width = integer.IntMax(secretColumns[2].Width, len(secretFormat.Data))
+		secretColumns[3].Width = integer.IntMax(secretColumns[3].Width, len(secretFormat.Age))
+
+		return &columntable.Row{
+			Id:     s.Name,
+			Values: []string{secretFormat.Name, secretFormat.Type, secretFormat.Data, secretFormat.Age},
+		}
+	})
+
+	return secretColumns, secretRows
+}
+
+// ResourceColumnsAndRows creates the neccessary columns and rows for a columntable in order to display a table of resources of any type.
+// Only the columns that `kubectl get` shows by default are included, which are the ones with priority 0.
+func ResourceColumnsAndRows(resourceTable *resources.Table, showNamespace bool) ([]*columntable.Column, []*columntable.Row) {
+	definitions := []metav1.TableColumnDefinition{}
+	indices := []int{}
+
+	for i, definition := range resourceTable.Columns {
+		if definition.Priority == 0 {
+			definitions = append(definitions, definition)
+			indices = append(indices, i)
+		}
+	}
+
+	resourceColumns := slices.Map(definitions, func(d metav1.TableColumnDefinition) *columntable.Column {
+		column := &columntable.Column{Desc: d.Name, Width: len(d.Name)}
+
+		switch d.Type {
+		case "integer", "number":
+			column.Compare = columntable.CompareNumbers
+		case "date":
+			column.Compare = columntable.CompareDurations
+		}
+
+		return column
+	})
+
+	if showNamespace {
+		resourceColumns = append([]*columntable.Column{{Desc: "Namespace", Width: 9}}, resourceColumns...)
+	}
+
+	now := time.Now()
+
+	resourceRows := slices.Map(resourceTable.Rows, func(r resources.TableRow) *columntable.Row {
+		values := []string{}
+
+		if showNamespace {
+			values = append(values, r.Namespace)
+		}
+
+		for i, definition := range definitions {
+			var cell interface{}
+			if indices[i] < len(r.Cells) {
+				cell = r.Cells[indices[i]]
+			}
+
+			values = append(values, k8s.FormatTableCell(definition, cell, now))
+		}
+
+		for i, value := range values {
+			resourceColumns[i].Width = integer.IntMax(resourceColumns[i].Width, len(value))
+		}
+
+		return &columntable.Row{
+			Id:     RowId(r.Namespace, r.Name),
+			Values: values,
+		}
+	})
+
+	return resourceColumns, resourceRows
+}
+
+// ServiceColumnsAndRows creates the neccessary columns and rows for a columntable in order to display service information.
+func ServiceColumnsAndRows(services []v1.Service) ([]*columntable.Column, []*columntable.Row) {
+	serviceColumns := []*columntable.Column{
+		{Desc: "Name", Width: 4},
+		{Desc: "Type", Width: 4},
+		{Desc: "Cluster IP", Width: 10},
+		{Desc: "External IP", Width: 11},
+		{Desc: "Ports", Width: 5},
+		{Desc: "Age", Width: 3, Compare: columntable.CompareDurations},
+		{Desc: "Selector", Width: 8},
+	}
+
+	now := time.Now()
+
+	serviceRows := slices.Map(services, func(s v1.Service) *columntable.Row {
+		serviceFormat := k8s.NewListServiceFormat(s, now)
+
+		values := []string{serviceFormat.Name, serviceFormat.Type, serviceFormat.ClusterIP, serviceFormat.ExternalIPs, serviceFormat.Ports, serviceFormat.Age, serviceFormat.Selector}
+
+		for i, value := range values {
+			serviceColumns[i].Width = integer.IntMax(serviceColumns[i].Width, len(value))
+		}
+
+		return &columntable.Row{
+			Id:     s.Name,
+			Values: values,
+		}
+	})
+
+	return serviceColumns, serviceRows
+}
+
+// IngressRouteColumnsAndRows creates the neccessary columns and rows for a columntable in order to display the routes of ingresses.
+// The id of each row is the index of its route.
+func IngressRouteColumnsAndRows(routes []ingresses.Route) ([]*columntable.Column, []*columntable.Row) {
+	routeColumns := []*columntable.Column{
+		{Desc: "Ingress", Width: 7},
+		{Desc: "Host", Width: 4},
+		{Desc: "Path", Width: 4},
+		{Desc: "Backend", Width: 7},
+		{Desc: "TLS", Width: 3},
+		{Desc: "Status", Width: 6},
+	}
+
+	routeRows := make([]*columntable.Row, 0, len(routes))
+
+	for i, route := range routes {
+		routeFormat := k8s.NewListIngressRouteFormat(route)
+
+		values := []string{routeFormat.Ingress, routeFormat.Host, routeFormat.Path, routeFormat.Backend, routeFormat.TLS, routeFormat.Status}
+
+		for j, value := range values {
+			routeColumns[j].Width = integer.IntMax(routeColumns[j].Width, len(value))
+		}
+
+		routeRows = append(routeRows, &columntable.Row{
+			Id:     strconv.Itoa(i),
+			Values: values,
+		})
+	}
+
+	return routeColumns, routeRows
+}
@@ -0,0 +1,132 @@
+package k8stable_test
+
+import (
+	"testing"
+
+	"kubeui/internal/pkg/component/columntable"
+	"kubeui/internal/pkg/k8s/ingresses"
+	"kubeui/internal/pkg/k8s/resources"
+	"kubeui/internal/pkg/ui/k8stable"
+
+	"github.com/life4/genesis/slices"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseRowId(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            string
+		wantNamespace string
+		wantName      string
+	}{
+		{"namespace and name", k8stable.RowId("default", "web-1"), "default", "web-1"},
+		{"name only", "web-1", "", "web-1"},
+		{"empty namespace", k8stable.RowId("", "web-1"), "", "web-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace, name := k8stable.ParseRowId(tt.id)
+			assert.Equal(t, tt.wantNamespace, namespace)
+			assert.Equal(t, tt.wantName, name)
+		})
+	}
+}
+
+func TestPodColumnsAndRows(t *testing.T) {
+	pods := []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: "database-namespace"}},
+	}
+
+	tests := []struct {
+		name          string
+		showNamespace bool
+		wantColumns   []string
+		wantValues    []string
+	}{
+		{"without namespace", false, []string{"Name", "Ready", "Status", "Restarts", "Age"}, []string{"web-1", "db-1"}},
+		{"with namespace", true, []string{"Namespace", "Name", "Ready", "Status", "Restarts", "Age"}, []string{"default", "database-namespace"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, rows := k8stable.PodColumnsAndRows(pods, tt.showNamespace)
+
+			assert.Equal(t, tt.wantColumns, slices.Map(columns, func(c *columntable.Column) string { return c.Desc }))
+			assert.Equal(t, tt.wantValues, slices.Map(rows, func(r *columntable.Row) string { return r.Values[0] }))
+			assert.Equal(t, []string{"default/web-1", "database-namespace/db-1"}, slices.Map(rows, func(r *columntable.Row) string { return r.Id }))
+
+			if tt.showNamespace {
+				assert.Equal(t, len("database-namespace"), columns[0].Width)
+			}
+		})
+	}
+}
+
+func TestNodeColumnsAndRows(t *testing.T) {
+	nodes := []v1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}, Status: v1.NodeStatus{Allocatable: v1.ResourceList{v1.ResourceMemory: resource.MustParse("16Gi")}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}, Status: v1.NodeStatus{Allocatable: v1.ResourceList{v1.ResourceMemory: resource.MustParse("512Mi")}}},
+	}
+
+	columns, rows := k8stable.NodeColumnsAndRows(nodes, map[string]int{"node-1": 12})
+
+	assert.Equal(t, []string{"Name", "Status", "Roles", "Version", "Age", "CPU", "Memory", "Pods"}, slices.Map(columns, func(c *columntable.Column) string { return c.Desc }))
+	assert.Equal(t, []string{"node-1", "node-2"}, slices.Map(rows, func(r *columntable.Row) string { return r.Id }))
+	assert.Equal(t, []string{"12", "0"}, slices.Map(rows, func(r *columntable.Row) string { return r.Values[7] }))
+
+	// Memory is compared by quantity rather than lexically.
+	assert.Equal(t, 1, columns[6].Compare(rows[0].Values[6], rows[1].Values[6]))
+	assert.Equal(t, -1, columns[5].Compare("500m", "2"))
+	assert.Equal(t, -1, columns[6].Compare("<unknown>", "1Ki"))
+}
+
+func TestResourceColumnsAndRows(t *testing.T) {
+	table := &resources.Table{
+		Columns: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Replicas", Type: "integer"},
+			{Name: "Image", Type: "string", Priority: 1},
+			{Name: "Age", Type: "date"},
+		},
+		Rows: []resources.TableRow{
+			{Namespace: "default", Name: "database", Cells: []interface{}{"database", float64(3), "postgres", "not-a-date"}},
+			{Namespace: "other", Name: "cache", Cells: []interface{}{"cache", nil, "redis"}},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		showNamespace bool
+		wantColumns   []string
+		wantValues    [][]string
+	}{
+		{"should hide columns with a priority", false, []string{"Name", "Replicas", "Age"}, [][]string{{"database", "3", "not-a-date"}, {"cache", "<none>", "<none>"}}},
+		{"should show the namespace", true, []string{"Namespace", "Name", "Replicas", "Age"}, [][]string{{"default", "database", "3", "not-a-date"}, {"other", "cache", "<none>", "<none>"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, rows := k8stable.ResourceColumnsAndRows(table, tt.showNamespace)
+
+			assert.Equal(t, tt.wantColumns, slices.Map(columns, func(c *columntable.Column) string { return c.Desc }))
+			assert.Equal(t, tt.wantValues, slices.Map(rows, func(r *columntable.Row) []string { return r.Values }))
+			assert.Equal(t, []string{"default/database", "other/cache"}, slices.Map(rows, func(r *columntable.Row) string { return r.Id }))
+		})
+	}
+}
+
+func TestIngressRouteColumnsAndRows(t *testing.T) {
+	routes := []ingresses.Route{
+		{Ingress: "main", Host: "www.example.com", Path: "/", Backend: "web:80"},
+		{Ingress: "main", Host: "www.example.com", Path: "/api", Backend: "api:80", Problems: []string{"service api not found"}},
+	}
+
+	columns, rows := k8stable.IngressRouteColumnsAndRows(routes)
+
+	assert.Equal(t, []string{"Ingress", "Host", "Path", "Backend", "TLS", "Status"}, slices.Map(columns, func(c *columntable.Column) string { return c.Desc }))
+	assert.Equal(t, []string{"0", "1"}, slices.Map(rows, func(r *columntable.Row) string { return r.Id }))
+	assert.Equal(t, []string{"OK", "service api not found"}, slices.Map(rows, func(r *columntable.Row) string { return r.Values[5] }))
+	assert.Equal(t, len("service api not found"), columns[5].Width)
+}
@@ -3,9 +3,11 @@
 package table
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/life4/genesis/maps"
 	"k8s.io/utils/integer"
 )
 
@@ -73,3 +75,34 @@ func RowsToString(columns []DataColumn, rows []DataRow) string {
 	return strings.Join(rowStrings, "\n")
 
 }
+
+// StringMapColumnsAndRows creates the neccessary columns and rows in order to display a map[string]string as a table.
+// The rows are sorted by key and the value column is made wide enough to fill up the remaining width.
+func StringMapColumnsAndRows(maxWidth int, col1 string, col2 string, data map[string]string) ([]DataColumn, []DataRow) {
+
+	columns := []DataColumn{
+		{Desc: col1, Width: len(col1) + 2},
+		{Desc: col2, Width: len(col2)},
+	}
+
+	keys := maps.Keys(data)
+	sort.Strings(keys)
+
+	rows := []DataRow{}
+
+	for _, key := range keys {
+
+		value := data[key]
+		// Update widths of the columns
+		columns[0].Width = integer.IntMax(columns[0].Width, len(key)+2)
+
+		remainingWidth := maxWidth - columns[0].Width
+		columns[1].Width = integer.IntMax(remainingWidth-1, len(value))
+
+		rows = append(rows, DataRow{
+			Values: []string{key, value},
+		})
+	}
+
+	return columns, rows
+}
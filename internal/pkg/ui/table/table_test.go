@@ -85,3 +85,36 @@ func TestRowsString(t *testing.T) {
 		})
 	}
 }
+
+func TestStringMapColumnsAndRows(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		maxWidth    int
+		data        map[string]string
+		wantColumns []table.DataColumn
+		wantRows    []table.DataRow
+	}{
+		{
+			"Empty map should only give columns",
+			20,
+			map[string]string{},
+			[]table.DataColumn{{Desc: "Key", Width: 5}, {Desc: "Value", Width: 5}},
+			[]table.DataRow{},
+		},
+		{
+			"Rows should be sorted by key and the value column should fill up the remaining width",
+			20,
+			map[string]string{"b": "2", "app": "web"},
+			[]table.DataColumn{{Desc: "Key", Width: 5}, {Desc: "Value", Width: 14}},
+			[]table.DataRow{{Values: []string{"app", "web"}}, {Values: []string{"b", "2"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, rows := table.StringMapColumnsAndRows(tt.maxWidth, "Key", "Value", tt.data)
+			assert.Equal(t, tt.wantColumns, columns)
+			assert.Equal(t, tt.wantRows, rows)
+		})
+	}
+}
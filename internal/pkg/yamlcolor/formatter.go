@@ -0,0 +1,182 @@
+// Package yamlcolor provides a way to render json compatible data as yaml with syntax highlighting in the terminal.
+// It uses the same ColorSet as the jsoncolor package, so that both formats are rendered with the same colors.
+package yamlcolor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"kubeui/internal/pkg/jsoncolor"
+
+	"github.com/charmbracelet/lipgloss"
+	"sigs.k8s.io/yaml"
+)
+
+const null = "null"
+const emptyMap = "{}"
+const emptyArray = "[]"
+const listItem = "- "
+
+// Formatter provides configuration and marshaling of json compatible objects into colored yaml strings.
+type Formatter struct {
+	jsoncolor.ColorSet
+	Indent int
+}
+
+// NewFormatter creates a new formatter.
+func NewFormatter() *Formatter {
+	return &Formatter{
+		ColorSet: jsoncolor.NewColorSet(),
+		Indent:   2,
+	}
+}
+
+func (f *Formatter) sprintColor(c lipgloss.Color, s string) string {
+	return lipgloss.NewStyle().Foreground(c).Render(s)
+}
+
+func (f *Formatter) writeIndent(buf *bytes.Buffer, depth int) {
+	buf.WriteString(strings.Repeat(" ", f.Indent*depth))
+}
+
+// Marshal marshals the object into a colored yaml string.
+// The object is expected to consist of the types produced when unmarshaling json into an interface{}.
+func (f *Formatter) Marshal(obj interface{}) ([]byte, error) {
+	buffer := bytes.Buffer{}
+
+	switch v := obj.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			buffer.WriteString(emptyMap + "\n")
+		} else {
+			f.marshalMap(v, &buffer, 0, false)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			buffer.WriteString(emptyArray + "\n")
+		} else {
+			f.marshalArray(v, &buffer, 0)
+		}
+	default:
+		f.marshalScalar(v, &buffer)
+		buffer.WriteString("\n")
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// marshalMap writes the keys of a map in sorted order, one per line.
+// If inline is true then the first key is written without indentation, as it follows a list item marker.
+func (f *Formatter) marshalMap(m map[string]interface{}, buf *bytes.Buffer, depth int, inline bool) {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for i, key := range keys {
+		if !inline || i > 0 {
+			f.writeIndent(buf, depth)
+		}
+
+		buf.WriteString(f.sprintColor(f.KeyColor, quoteString(key)+":"))
+
+		switch v := m[key].(type) {
+		case map[string]interface{}:
+			if len(v) == 0 {
+				buf.WriteString(" " + emptyMap + "\n")
+				continue
+			}
+			buf.WriteString("\n")
+			f.marshalMap(v, buf, depth+1, false)
+		case []interface{}:
+			if len(v) == 0 {
+				buf.WriteString(" " + emptyArray + "\n")
+				continue
+			}
+			// Like kubectl, list items are written at the same indentation as their key.
+			buf.WriteString("\n")
+			f.marshalArray(v, buf, depth)
+		default:
+			buf.WriteString(" ")
+			f.marshalScalar(v, buf)
+			buf.WriteString("\n")
+		}
+	}
+}
+
+// marshalArray writes the items of an array, one list item per line.
+func (f *Formatter) marshalArray(a []interface{}, buf *bytes.Buffer, depth int) {
+	for _, item := range a {
+		f.writeIndent(buf, depth)
+		buf.WriteString(listItem)
+
+		switch v := item.(type) {
+		case map[string]interface{}:
+			if len(v) == 0 {
+				buf.WriteString(emptyMap + "\n")
+				continue
+			}
+			f.marshalMap(v, buf, depth+1, true)
+		case []interface{}:
+			if len(v) == 0 {
+				buf.WriteString(emptyArray + "\n")
+				continue
+			}
+			buf.WriteString("\n")
+			f.marshalArray(v, buf, depth+1)
+		default:
+			f.marshalScalar(v, buf)
+			buf.WriteString("\n")
+		}
+	}
+}
+
+func (f *Formatter) marshalScalar(val interface{}, buf *bytes.Buffer) {
+	switch v := val.(type) {
+	case string:
+		buf.WriteString(f.sprintColor(f.StringColor, quoteString(v)))
+	case float64:
+		buf.WriteString(f.sprintColor(f.NumberColor, strconv.FormatFloat(v, 'f', -1, 64)))
+	case float32:
+		buf.WriteString(f.sprintColor(f.NumberColor, strconv.FormatFloat(float64(v), 'f', -1, 32)))
+	// Unstructured kubernetes objects contain integers rather than float64.
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		buf.WriteString(f.sprintColor(f.NumberColor, fmt.Sprint(v)))
+	case bool:
+		buf.WriteString(f.sprintColor(f.BoolColor, strconv.FormatBool(v)))
+	case nil:
+		buf.WriteString(f.sprintColor(f.NullColor, null))
+	case json.Number:
+		buf.WriteString(f.sprintColor(f.NumberColor, v.String()))
+	default:
+		buf.WriteString(fmt.Sprint(v))
+	}
+}
+
+// quoteString quotes a string if it would otherwise not be read back as the same string, for example "true" or "1".
+// Strings spanning several lines are written as double quoted strings with escaped line breaks.
+func quoteString(str string) string {
+	if strings.Contains(str, "\n") {
+		quoted, _ := json.Marshal(str)
+		return string(quoted)
+	}
+
+	quoted, err := yaml.Marshal(str)
+	if err != nil {
+		quoted, _ = json.Marshal(str)
+		return string(quoted)
+	}
+
+	return strings.TrimSuffix(string(quoted), "\n")
+}
+
+// Marshal marshals json compatible data with default options.
+func Marshal(obj interface{}) ([]byte, error) {
+	return NewFormatter().Marshal(obj)
+}
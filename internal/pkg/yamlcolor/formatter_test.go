@@ -0,0 +1,93 @@
+package yamlcolor_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"kubeui/internal/pkg/yamlcolor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshal(t *testing.T) {
+
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{
+			"Should sort keys and indent nested maps",
+			`{"metadata": {"name": "web", "labels": {"app": "web"}}, "apiVersion": "v1"}`,
+			"apiVersion: v1\nmetadata:\n  labels:\n    app: web\n  name: web\n",
+		},
+		{
+			"Should write list items at the same indentation as their key",
+			`{"containers": [{"name": "web", "ports": [{"containerPort": 80}]}, {"name": "sidecar"}]}`,
+			"containers:\n- name: web\n  ports:\n  - containerPort: 80\n- name: sidecar\n",
+		},
+		{
+			"Should quote strings that would be read as other types",
+			`{"a": "true", "b": "1", "c": true, "d": 1.5, "e": null, "f": ""}`,
+			"a: \"true\"\nb: \"1\"\nc: true\nd: 1.5\ne: null\nf: \"\"\n",
+		},
+		{
+			"Should write empty collections inline",
+			`{"a": {}, "b": [], "c": [[], {}, "x"]}`,
+			"a: {}\nb: []\nc:\n- []\n- {}\n- x\n",
+		},
+		{
+			"Should escape multiline strings",
+			`{"script": "echo a\necho b"}`,
+			"script: \"echo a\\necho b\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var obj interface{}
+			assert.Nil(t, json.Unmarshal([]byte(tt.json), &obj))
+
+			got, err := yamlcolor.Marshal(obj)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestMarshalGoValues(t *testing.T) {
+
+	tests := []struct {
+		name string
+		obj  interface{}
+		want string
+	}{
+		{
+			"Should write integers",
+			map[string]interface{}{"a": 1, "b": int32(-2), "c": int64(3), "d": uint8(4), "e": uint64(5)},
+			"a: 1\nb: -2\nc: 3\nd: 4\ne: 5\n",
+		},
+		{
+			"Should write floats",
+			map[string]interface{}{"a": float32(1.5), "b": 2.25},
+			"a: 1.5\nb: 2.25\n",
+		},
+		{
+			"Should write integers in lists",
+			map[string]interface{}{"ports": []interface{}{int64(80), int64(443)}},
+			"ports:\n- 80\n- 443\n",
+		},
+		{
+			"Should write other values as formatted by fmt",
+			map[string]interface{}{"timeout": 3 * time.Second},
+			"timeout: 3s\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := yamlcolor.Marshal(tt.obj)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}